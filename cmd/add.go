@@ -1,32 +1,78 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/overwrite"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Flags of add for files that already exist.
+var (
+	addForce        bool
+	addSkipExisting bool
 )
 
 var addCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add new components to your Vandor project",
-	Long:  `Add various components like schemas, domains, usecases, services, jobs, etc. to your Vandor project.`,
+	Long: `Add various components like schemas, domains, usecases, services, jobs, etc. to your Vandor project.
+
+With --dry-run or --diff, nothing is written: the files the command would
+create or modify, including auto-synced registries, are listed or shown as a
+diff. Steps that run external programs are skipped in a preview.
+
+A component file that already exists is never overwritten silently. On a
+terminal you are asked what to do with it: overwrite it, keep it, see the
+diff, or keep it and write the generated version next to it as <file>.new to
+merge by hand. Elsewhere, and in a preview, the command fails instead, unless
+--force overwrites existing files or --skip-existing keeps them.`,
 }
 
 func init() {
 	rootCmd.AddCommand(addCmd)
+	addGenerationHooks(addCmd)
+	addCmd.PersistentFlags().BoolVar(&addForce, "force", false, "Overwrite component files that already exist")
+	addCmd.PersistentFlags().BoolVar(&addSkipExisting, "skip-existing", false, "Keep component files that already exist and create the rest")
+	addCmd.MarkFlagsMutuallyExclusive("force", "skip-existing")
+
+	// Apply the overwrite mode before the generation hooks run
+	hooks := addCmd.PersistentPreRun
+	addCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		switch {
+		case addForce:
+			overwrite.Use(overwrite.Force)
+		case addSkipExisting:
+			overwrite.Use(overwrite.Skip)
+		}
+		hooks(cmd, args)
+	}
 }
 
-// Helper function to run Go commands
+// Helper function to run Go commands. Nothing is run during a preview, since
+// the command would write to disk directly.
 func runGoCommand(args ...string) error {
+	if vfs.IsVirtual() {
+		fmt.Fprintf(os.Stderr, "⚠️  Preview: not running go %s\n", strings.Join(args, " "))
+		return nil
+	}
 	cmd := exec.Command("go", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
 
-// Helper function to run shell scripts
+// Helper function to run shell scripts. Nothing is run during a preview.
 func runScript(script string, args ...string) error {
+	if vfs.IsVirtual() {
+		fmt.Fprintf(os.Stderr, "⚠️  Preview: not running %s\n", script)
+		return nil
+	}
 	fullArgs := append([]string{script}, args...)
 	cmd := exec.Command("bash", fullArgs...)
 	cmd.Stdout = os.Stdout
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/command"
+	"github.com/alfariiizi/vandor-cli/internal/tui"
+)
+
+// addComponentCommands adds an add subcommand for every custom component
+// declared in vandor-config.yaml. They are registered with the unified
+// command registry at startup, so this runs from Execute rather than init.
+func addComponentCommands() {
+	for _, unifiedCmd := range command.GetGlobalRegistry().List("add") {
+		meta := unifiedCmd.GetMetadata()
+		if _, ok := unifiedCmd.(*command.AddComponentCommand); !ok || hasSubcommand(addCmd, meta.Name) {
+			continue
+		}
+		addCmd.AddCommand(newAddComponentCmd(meta))
+	}
+}
+
+func newAddComponentCmd(meta command.CommandMetadata) *cobra.Command {
+	use := meta.Name
+	for _, arg := range meta.Args {
+		use += " [" + arg + "]"
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: meta.Description,
+		Long: fmt.Sprintf(`%s. Declared under components in vandor-config.yaml.
+If no arguments are provided, opens TUI for interactive input.`, meta.Description),
+		Args: cobra.MaximumNArgs(len(meta.Args)),
+		Run: func(cmd *cobra.Command, args []string) {
+			// If no arguments provided, launch TUI for this specific command
+			if len(args) == 0 {
+				if err := tui.LaunchDirectCommand("add", meta.Name); err != nil {
+					er(fmt.Sprintf("Failed to launch TUI: %v", err))
+				}
+				return
+			}
+
+			if len(args) < len(meta.Args) {
+				er(fmt.Sprintf("%s required. Usage: %s", strings.Join(meta.Args, ", "), meta.Usage))
+			}
+
+			unifiedCmd, exists := command.GetGlobalRegistry().Get("add", meta.Name)
+			if !exists {
+				er(fmt.Sprintf("%s command not found in registry", meta.Name))
+			}
+
+			ctx := command.NewCommandContext(args)
+			if err := unifiedCmd.Execute(ctx); err != nil {
+				er(fmt.Sprintf("Failed to execute %s command: %v", meta.Name, err))
+			}
+		},
+	}
+}
+
+func hasSubcommand(parent *cobra.Command, name string) bool {
+	for _, sub := range parent.Commands() {
+		if sub.Name() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/alfariiizi/vandor-cli/internal/naming"
 	"github.com/alfariiizi/vandor-cli/internal/tui"
 )
 
@@ -24,6 +25,9 @@ var addEnumCmd = &cobra.Command{
 		}
 
 		name := args[0]
+		if _, err := naming.Component("enum", name); err != nil {
+			er(err.Error())
+		}
 		fmt.Printf("Creating new enum: %s\n", name)
 
 		// Convert to lowercase as per the original task
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -11,11 +12,36 @@ import (
 	"github.com/alfariiizi/vandor-cli/internal/utils"
 )
 
+// Flags of add handler.
+var (
+	handlerRoute    string
+	handlerPath     []string
+	handlerQuery    []string
+	handlerBody     []string
+	handlerResponse []string
+	handlerService  string
+)
+
 var addHandlerCmd = &cobra.Command{
 	Use:   "handler [group] [name] [method]",
 	Short: "Create a new HTTP handler",
-	Long:  `Create a new HTTP handler with the specified group, name, and HTTP method. If no arguments are provided, opens TUI for interactive input.`,
-	Args:  cobra.MaximumNArgs(3),
+	Long: `Create a new huma HTTP handler with the specified group, name, and HTTP
+method. If no arguments are provided, opens TUI for interactive input.
+
+The route defaults to /<name> and may contain {param} segments, which become
+path parameters. Fields are name[:type][:required][:default=<value>], with
+types string, int, int32, int64, float, bool, time, uuid, strings and ints;
+path and query fields take the scalar types only. Optional body fields are
+pointers.
+
+With --service group.Name the handler calls that service through
+service.Services, passing the request fields and returning the output fields
+whose names and types match its Input and Output structs.
+
+Examples:
+  vandor add handler user ListUserOrders GET --route /users/{id}/orders --path id:int --query page:int:default=1
+  vandor add handler user UpdateProfile PATCH --route /users/{id} --body name --body age:int --service user.UpdateProfile`,
+	Args: cobra.MaximumNArgs(3),
 	Run: func(cmd *cobra.Command, args []string) {
 		// If no arguments provided, launch TUI for this specific command
 		if len(args) == 0 {
@@ -36,8 +62,33 @@ var addHandlerCmd = &cobra.Command{
 
 		fmt.Printf("Creating new HTTP handler: %s in group %s with method %s\n", name, group, method)
 
+		spec := generators.HandlerSpec{
+			Name:    name,
+			Group:   group,
+			Method:  method,
+			Route:   handlerRoute,
+			Service: handlerService,
+		}
+		for _, fields := range []struct {
+			args []string
+			dst  *[]generators.HandlerFieldSpec
+		}{
+			{handlerPath, &spec.Path},
+			{handlerQuery, &spec.Query},
+			{handlerBody, &spec.Body},
+			{handlerResponse, &spec.Response},
+		} {
+			for _, arg := range fields.args {
+				field, err := generators.ParseHandlerField(arg)
+				if err != nil {
+					er(err)
+				}
+				*fields.dst = append(*fields.dst, field)
+			}
+		}
+
 		// Create new HTTP handler using Jennifer generator
-		if err := generators.GenerateHandler(name, group, method); err != nil {
+		if err := generators.GenerateHandlerFromSpec(spec); err != nil {
 			er(fmt.Sprintf("Failed to create HTTP handler: %v", err))
 		}
 
@@ -54,8 +105,14 @@ var addHandlerCmd = &cobra.Command{
 var addHandlerCrudCmd = &cobra.Command{
 	Use:   "handler-crud [model]",
 	Short: "Generate CRUD HTTP handlers for a model",
-	Long:  `Generate CRUD (Create, Read, Update, Delete) HTTP handlers for the specified model. If no model is provided, opens TUI for interactive input.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Generate CRUD (Create, Read, Update, Delete) code for an ent schema in
+database/schema: a domain, usecases and services for list, get, create, update
+and delete, and huma HTTP handlers for them. List supports pagination, sorting
+and filtering by text and enum fields; request and response types are derived
+from the schema fields, and sensitive fields are never returned.
+
+If no model is provided, opens TUI for interactive input.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		// If no arguments provided, launch TUI for this specific command
 		if len(args) == 0 {
@@ -70,15 +127,23 @@ var addHandlerCrudCmd = &cobra.Command{
 
 		fmt.Printf("Generating CRUD HTTP handlers for model: %s\n", modelTitle)
 
-		// Generate CRUD handlers
-		if err := runGoCommand("run", "./internal/cmd/http/crud/main.go", modelTitle); err != nil {
+		// Generate domain, usecases, services and handlers from the ent schema
+		if err := generators.GenerateCRUD(modelTitle); err != nil {
 			er(fmt.Sprintf("Failed to generate CRUD handlers: %v", err))
 		}
 
-		// Auto-sync handler registry
-		fmt.Println("Auto-syncing handler registry...")
-		if err := generators.GenerateHandlerRegistry(); err != nil {
-			er(fmt.Sprintf("Failed to sync handler registry: %v", err))
+		// Auto-sync the registries of every generated layer
+		fmt.Println("Auto-syncing domain, usecase, service and handler registries...")
+		registries := []generators.RegistryGenerator{
+			generators.DomainRegistry,
+			generators.UsecaseRegistry,
+			generators.ServiceRegistry,
+			generators.HandlerRegistry,
+		}
+		for _, registry := range registries {
+			if err := registry.Generate(os.Stdout); err != nil {
+				er(fmt.Sprintf("Failed to sync %s registry: %v", registry.Name, err))
+			}
 		}
 
 		fmt.Printf("✅ CRUD HTTP handlers for model '%s' generated and synced successfully!\n", modelTitle)
@@ -111,7 +176,7 @@ var addServiceHandlerCmd = &cobra.Command{
 		fmt.Printf("Creating new service and HTTP handler: %s in group %s with method %s\n", name, group, method)
 
 		// Create service using Jennifer generator
-		if err := generators.GenerateService(name); err != nil {
+		if err := generators.GenerateService(group, name); err != nil {
 			er(fmt.Sprintf("Failed to create service: %v", err))
 		}
 
@@ -134,6 +199,12 @@ var addServiceHandlerCmd = &cobra.Command{
 }
 
 func init() {
+	addHandlerCmd.Flags().StringVar(&handlerRoute, "route", "", "Route pattern, e.g. /users/{id}/orders (default /<name>)")
+	addHandlerCmd.Flags().StringArrayVar(&handlerPath, "path", nil, "Path parameter as name[:type] (repeatable)")
+	addHandlerCmd.Flags().StringArrayVar(&handlerQuery, "query", nil, "Query parameter as name[:type][:required][:default=<value>] (repeatable)")
+	addHandlerCmd.Flags().StringArrayVar(&handlerBody, "body", nil, "Body field as name[:type][:required][:default=<value>] (repeatable)")
+	addHandlerCmd.Flags().StringArrayVar(&handlerResponse, "response", nil, "Response data field as name[:type][:required] (repeatable)")
+	addHandlerCmd.Flags().StringVar(&handlerService, "service", "", "Service to call as group.Name")
 	addCmd.AddCommand(addHandlerCmd)
 	addCmd.AddCommand(addHandlerCrudCmd)
 	addCmd.AddCommand(addServiceHandlerCmd)
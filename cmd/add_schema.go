@@ -5,14 +5,39 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/alfariiizi/vandor-cli/internal/generators"
 	"github.com/alfariiizi/vandor-cli/internal/tui"
 )
 
+// Flags of add schema.
+var (
+	schemaEdges   []string
+	schemaIndexes []string
+	schemaMixins  []string
+)
+
 var addSchemaCmd = &cobra.Command{
-	Use:   "schema [name]",
+	Use:   "schema [name] [field...]",
 	Short: "Create a new schema",
-	Long:  `Create a new schema using ent-tools. If no name is provided, opens TUI for interactive input.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Create an ent schema in database/schema, or add fields, edges, indexes and
+mixins to an existing one. If no name is provided, opens TUI for interactive
+input.
+
+Fields are name:type[:modifier...]. Types are string, text, enum, bool, int,
+int8-int64, uint, uint8-uint64, float, float32, time, uuid, bytes and strings.
+Modifiers are optional, nillable, unique, immutable, sensitive, index,
+default=<value> and, for enums, values=a|b. Time and uuid fields take
+default=now and default=new.
+
+Edges are name:Type[:modifier...] and point to a single Type unless many is
+set. Modifiers are required, many, field (adds a <name>_id foreign key field)
+and ref=<edge> (the inverse of an edge declared on Type).
+
+Examples:
+  vandor add schema User name:string email:string:unique age:int:optional --mixin timestamps
+  vandor add schema Post title:string body:text --edge author:User:required:field
+  vandor add schema User role:enum:values=admin|member:default=member --index name,role`,
+	Args: cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		// If no arguments provided, launch TUI for this specific command
 		if len(args) == 0 {
@@ -22,17 +47,53 @@ var addSchemaCmd = &cobra.Command{
 			return
 		}
 
-		name := args[0]
-		fmt.Printf("Creating new schema: %s\n", name)
+		spec := generators.SchemaSpec{Name: args[0], Mixins: schemaMixins}
+		for _, arg := range args[1:] {
+			field, err := generators.ParseSchemaField(arg)
+			if err != nil {
+				er(err)
+			}
+			spec.Fields = append(spec.Fields, field)
+		}
+		for _, arg := range schemaEdges {
+			edge, err := generators.ParseSchemaEdge(arg)
+			if err != nil {
+				er(err)
+			}
+			spec.Edges = append(spec.Edges, edge)
+		}
+		for _, arg := range schemaIndexes {
+			index, err := generators.ParseSchemaIndex(arg)
+			if err != nil {
+				er(err)
+			}
+			spec.Indexes = append(spec.Indexes, index)
+		}
+		for _, mixin := range schemaMixins {
+			if err := generators.ValidateSchemaMixin(mixin); err != nil {
+				er(err)
+			}
+		}
+
+		fmt.Printf("Creating new schema: %s\n", spec.Name)
 
-		if err := runScript("./internal/scripts/ent-tools.sh", "new", name); err != nil {
+		updated, err := generators.GenerateSchema(spec)
+		if err != nil {
 			er(fmt.Sprintf("Failed to create schema: %v", err))
 		}
 
-		fmt.Printf("✅ Schema '%s' created successfully!\n", name)
+		if updated {
+			fmt.Printf("✅ Schema '%s' updated successfully!\n", spec.Name)
+		} else {
+			fmt.Printf("✅ Schema '%s' created successfully!\n", spec.Name)
+		}
+		fmt.Println("Run 'vandor sync db-model' to regenerate the ent code.")
 	},
 }
 
 func init() {
+	addSchemaCmd.Flags().StringArrayVar(&schemaEdges, "edge", nil, "Edge as name:Type[:required][:many][:field][:ref=<edge>] (repeatable)")
+	addSchemaCmd.Flags().StringArrayVar(&schemaIndexes, "index", nil, "Index as field[,field...][:unique] (repeatable)")
+	addSchemaCmd.Flags().StringSliceVar(&schemaMixins, "mixin", nil, "Mixins to apply: timestamps, soft-delete")
 	addCmd.AddCommand(addSchemaCmd)
 }
@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/alfariiizi/vandor-cli/internal/naming"
+	"github.com/alfariiizi/vandor-cli/internal/regenerate/seed"
 	"github.com/alfariiizi/vandor-cli/internal/tui"
 )
 
@@ -23,6 +26,9 @@ var addSeedCmd = &cobra.Command{
 		}
 
 		name := args[0]
+		if _, err := naming.Component("seed", name); err != nil {
+			er(err.Error())
+		}
 		fmt.Printf("Creating new seed: %s\n", name)
 
 		// Create new seed
@@ -30,8 +36,8 @@ var addSeedCmd = &cobra.Command{
 			er(fmt.Sprintf("Failed to create seed: %v", err))
 		}
 
-		// Generate seed code
-		if err := runGoCommand("run", "./internal/cmd/seed/cmd-generate/main.go"); err != nil {
+		// Regenerate seed runner
+		if err := seed.RegenerateSeed(os.Stdout); err != nil {
 			er(fmt.Sprintf("Failed to generate seed code: %v", err))
 		}
 
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+	"github.com/alfariiizi/vandor-cli/internal/templates"
+)
+
+// Flags of config.
+var configGlobal bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and edit the project configuration",
+	Long: `View and edit vandor-config.yaml, keeping its comments and the order of its
+keys. Values are checked before they are written.
+
+With --global, the user-level configuration is edited instead
+(~/.config/vandor/config.yaml on Linux). Its settings apply to every project,
+and those of vandor-config.yaml take precedence.
+
+Keys are dotted paths, e.g. vandor.architecture or layout.usecase. Lists are
+given comma-separated:
+
+  vandor config set registries https://example.com/registry.yaml,https://example.org/registry.yaml`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:               "get <key>",
+	Short:             "Print the value of a key",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigKeys,
+	Run: func(cmd *cobra.Command, args []string) {
+		doc := openConfig()
+		value := doc.Get(args[0])
+		if value == nil {
+			er(fmt.Sprintf("%s is not set in %s", args[0], doc.Path))
+		}
+		fmt.Println(config.Format(value))
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:               "set <key> <value>",
+	Short:             "Set the value of a key",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeConfigKeys,
+	Run: func(cmd *cobra.Command, args []string) {
+		key, value := args[0], args[1]
+		doc := openConfig()
+		if err := doc.Set(key, value); err != nil {
+			er(fmt.Sprintf("Cannot set %s: %v", key, err))
+		}
+		if err := validateConfig(doc); err != nil {
+			er(fmt.Sprintf("Cannot set %s:\n%v", key, err))
+		}
+		if err := doc.Save(); err != nil {
+			er(err.Error())
+		}
+		fmt.Printf("✅ Set %s to %q in %s\n", key, value, doc.Path)
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:               "unset <key>",
+	Short:             "Remove a key",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigKeys,
+	Run: func(cmd *cobra.Command, args []string) {
+		doc := openConfig()
+		if !doc.Unset(args[0]) {
+			er(fmt.Sprintf("%s is not set in %s", args[0], doc.Path))
+		}
+		if err := doc.Save(); err != nil {
+			er(err.Error())
+		}
+		fmt.Printf("✅ Removed %s from %s\n", args[0], doc.Path)
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the keys set in the configuration file",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		doc := openConfig()
+		entries := doc.Entries()
+		if len(entries) == 0 {
+			fmt.Printf("Nothing is set in %s\n", doc.Path)
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KEY\tVALUE")
+		for _, entry := range entries {
+			fmt.Fprintf(w, "%s\t%s\n", entry.Key, entry.Value)
+		}
+		_ = w.Flush()
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration file",
+	Long: `Check the configuration file against its schema, then check its values: the
+architecture, registry URLs, vpkg packages, layout directories and custom
+components.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		doc := openConfig()
+		if err := validateConfig(doc); err != nil {
+			er(fmt.Sprintf("❌ %v", err))
+		}
+		fmt.Printf("✅ %s is valid\n", doc.Path)
+	},
+}
+
+// openConfig opens the configuration file config commands edit: the
+// user-level one with --global, which is empty until set, or the project's.
+func openConfig() *config.Document {
+	var path string
+	var err error
+	if configGlobal {
+		path, err = config.GlobalPath()
+	} else {
+		path, err = config.Find(".")
+	}
+	if err != nil {
+		er(fmt.Sprintf("Failed to find the configuration file: %v", err))
+	}
+	if path == "" {
+		er(fmt.Sprintf("No %s found in this directory or its parents (run vandor init, or use --global)", config.FileName))
+	}
+
+	doc, err := config.Open(path)
+	if err != nil {
+		er(err.Error())
+	}
+	return doc
+}
+
+// validateConfig checks a configuration document, including the layout and
+// custom components it declares.
+func validateConfig(doc *config.Document) error {
+	cfg, err := doc.Config()
+	if err != nil {
+		return err
+	}
+	if _, err := layout.FromConfig(cfg); err != nil {
+		return err
+	}
+	_, err = templates.ComponentsOf(cfg)
+	return err
+}
+
+// completeConfigKeys completes the key argument of config commands.
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var keys []string
+	for _, key := range config.Keys() {
+		if key == "layout.*" {
+			for _, kind := range layout.Kinds() {
+				keys = append(keys, "layout."+kind)
+			}
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.PersistentFlags().BoolVar(&configGlobal, "global", false, "Edit the user-level configuration instead of the project's")
+}
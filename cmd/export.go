@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+	"github.com/alfariiizi/vandor-cli/internal/generators"
+	"github.com/alfariiizi/vandor-cli/internal/openapi"
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+)
+
+// Flags of export openapi.
+var (
+	exportOutput string
+	exportFormat string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export API contracts from your Vandor project",
+	Long:  `Export API contracts such as OpenAPI documents from the code of your Vandor project.`,
+}
+
+var exportOpenAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Export an OpenAPI 3.1 document from the HTTP handlers",
+	Long: `Export an OpenAPI 3.1 document by statically analyzing the HTTP handlers in
+internal/delivery/http/route, without building or running the server.
+
+Operations come from the method.<METHOD>(...) and huma.Register calls in the
+handlers' RegisterRoutes methods, and their parameters, request bodies and
+responses from the handlers' Input and Output types and the huma struct tags
+on them. The title and version are read from vandor-config.yaml.
+
+The document is printed to stdout unless --output is given, as YAML unless
+--format json is given or the output file ends in .json. Output is sorted so
+that exports of unchanged code are identical, which makes API changes easy to
+review in diffs.
+
+Examples:
+  vandor export openapi > openapi.yaml
+  vandor export openapi -o api/openapi.json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format := exportFormat
+		if format == "" {
+			format = "yaml"
+			if strings.EqualFold(filepath.Ext(exportOutput), ".json") {
+				format = "json"
+			}
+		}
+		if format != "yaml" && format != "json" {
+			er(fmt.Sprintf("Unknown format %q: use yaml or json", format))
+		}
+
+		doc, warnings, err := generators.ExportOpenAPI(exportInfo())
+		if err != nil {
+			er(fmt.Sprintf("Failed to export OpenAPI document: %v", err))
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+		}
+
+		var out bytes.Buffer
+		if format == "json" {
+			enc := json.NewEncoder(&out)
+			enc.SetIndent("", "  ")
+			enc.SetEscapeHTML(false)
+			err = enc.Encode(doc)
+		} else {
+			enc := yaml.NewEncoder(&out)
+			enc.SetIndent(2)
+			err = enc.Encode(doc)
+		}
+		if err != nil {
+			er(fmt.Sprintf("Failed to encode OpenAPI document: %v", err))
+		}
+
+		if exportOutput == "" {
+			fmt.Print(out.String())
+			return
+		}
+		if dir := filepath.Dir(exportOutput); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				er(fmt.Sprintf("Failed to create %s: %v", dir, err))
+			}
+		}
+		if err := os.WriteFile(exportOutput, out.Bytes(), 0644); err != nil {
+			er(fmt.Sprintf("Failed to write %s: %v", exportOutput, err))
+		}
+		fmt.Printf("✅ Exported %d path(s) to %s\n", len(doc.Paths), exportOutput)
+	},
+}
+
+// exportInfo returns the document info from vandor-config.yaml, falling back
+// to the module name.
+func exportInfo() openapi.Info {
+	info := openapi.Info{Version: "0.1.0"}
+
+	project := config.Current().Project
+	info.Title = project.Name
+	if project.Version != "" {
+		info.Version = project.Version
+	}
+	if info.Title == "" {
+		info.Title = path.Base(utils.GetModuleName())
+	}
+	return info
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportOpenAPICmd)
+	exportOpenAPICmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write the document to a file instead of stdout")
+	exportOpenAPICmd.Flags().StringVar(&exportFormat, "format", "", "Output format: yaml or json (default yaml, or json for a .json output file)")
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/history"
+)
+
+var activeHistory *history.Recorder
+
+// startHistory journals the files written by the running command.
+func startHistory() {
+	if activeHistory == nil {
+		activeHistory = history.Start("vandor " + strings.Join(os.Args[1:], " "))
+	}
+}
+
+// finishHistory saves the journal entry of the running command, if any.
+func finishHistory() {
+	r := activeHistory
+	if r == nil {
+		return
+	}
+	activeHistory = nil
+
+	entry, err := r.Finish()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record history: %v\n", err)
+		return
+	}
+	if entry != nil {
+		fmt.Printf("📝 Recorded as history entry %d (%d file(s)); run 'vandor undo' to revert\n", entry.ID, len(entry.Files))
+	}
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history [id]",
+	Short: "List the files written by previous add, sync and vpkg add runs",
+	Long: `List journaled runs of add, sync and vpkg add, newest first. With an id, list
+the files that run created or modified.
+
+The journal is kept in .vandor/history. Files written by external programs
+(ent, enum and scheduler generators, go run scripts) are not journaled.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			entry, err := loadHistoryEntry(args[0])
+			if err != nil {
+				er(err)
+			}
+			printHistoryEntry(entry)
+			return
+		}
+
+		entries, err := history.List()
+		if err != nil {
+			er(fmt.Sprintf("Failed to read history: %v", err))
+		}
+		if len(entries) == 0 {
+			fmt.Println("No history recorded yet")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tTIME\tFILES\tSTATUS\tCOMMAND")
+		for _, entry := range entries {
+			status := "-"
+			if entry.Undone {
+				status = "undone"
+			}
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\n", entry.ID, entry.Time.Format("2006-01-02 15:04:05"), len(entry.Files), status, entry.Command)
+		}
+		_ = w.Flush()
+	},
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [id]",
+	Short: "Revert the files written by a previous run",
+	Long: `Revert the files written by a journaled add, sync or vpkg add run: files it
+created are removed and files it modified get their previous content back.
+Without an id the latest run that has not been undone is reverted.
+
+Undo refuses to run when any of the files were changed after the run, so
+manual edits are never lost.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var entry *history.Entry
+		var err error
+		if len(args) == 1 {
+			entry, err = loadHistoryEntry(args[0])
+		} else {
+			entry, err = history.Latest()
+		}
+		if err != nil {
+			er(err)
+		}
+
+		if err := history.Undo(entry); err != nil {
+			er(err)
+		}
+
+		fmt.Printf("✅ Undid history entry %d: %s\n", entry.ID, entry.Command)
+		printHistoryEntry(entry)
+	},
+}
+
+func loadHistoryEntry(arg string) (*history.Entry, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid history id %q", arg)
+	}
+	return history.Load(id)
+}
+
+func printHistoryEntry(entry *history.Entry) {
+	fmt.Printf("#%d %s (%s)\n", entry.ID, entry.Command, entry.Time.Format("2006-01-02 15:04:05"))
+	for _, file := range entry.Files {
+		action := "modified"
+		if file.Created {
+			action = "created"
+		}
+		fmt.Printf("  %-8s %s\n", action, file.Path)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(undoCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/generators"
+	"github.com/alfariiizi/vandor-cli/internal/openapi"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Generate code from external API contracts",
+	Long: `Generate handlers and services from external API contracts.
+
+With --dry-run or --diff, nothing is written: the files the command would
+create or modify, including auto-synced registries, are listed or shown as a
+diff.`,
+}
+
+var importOpenAPICmd = &cobra.Command{
+	Use:   "openapi <spec.yaml|spec.json>",
+	Short: "Generate handlers and services from an OpenAPI 3 document",
+	Long: `Generate a huma handler and a service stub for each operation of an OpenAPI
+3.0 or 3.1 document, then sync the service and handler registries.
+
+Operations are grouped by their first tag into handler groups under
+internal/delivery/http/route/<group> and service groups under
+internal/core/service/<group>; untagged operations go to the common group.
+Handlers and services are named after the operationId, or the method and
+path when there is none.
+
+Path, query, header and cookie parameters become fields of the handler input
+with huma tags, and JSON request and response bodies become types in the
+service group's openapi_types.go, which is regenerated on every import.
+Each handler passes its input to its service and returns the service's data;
+the service's Process method is left to implement.
+
+Handlers and services that already exist are skipped, so a document can be
+imported again after operations are added to it.
+
+Example:
+  vandor import openapi api/openapi.yaml`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		doc, err := openapi.Load(args[0])
+		if err != nil {
+			er(fmt.Sprintf("Failed to read OpenAPI document: %v", err))
+		}
+
+		fmt.Printf("Importing OpenAPI document: %s\n", args[0])
+
+		result, err := generators.ImportOpenAPI(doc)
+		if err != nil {
+			er(fmt.Sprintf("Failed to import OpenAPI document: %v", err))
+		}
+		for _, name := range result.Skipped {
+			fmt.Printf("⏭️  Skipping %s: its handler or service already exists\n", name)
+		}
+
+		// Auto-sync service and handler registries
+		fmt.Println("Auto-syncing service and handler registries...")
+		if err := generators.GenerateServiceRegistry(); err != nil {
+			er(fmt.Sprintf("Failed to sync service registry: %v", err))
+		}
+		if err := generators.GenerateHandlerRegistry(); err != nil {
+			er(fmt.Sprintf("Failed to sync handler registry: %v", err))
+		}
+
+		fmt.Printf("✅ Imported %d operation(s) from %s\n", len(result.Created), args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importOpenAPICmd)
+	addGenerationHooks(importCmd)
+}
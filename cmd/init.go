@@ -12,28 +12,10 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/alfariiizi/vandor-cli/internal/config"
 	"github.com/alfariiizi/vandor-cli/internal/theme"
 )
 
-type VandorConfig struct {
-	Project struct {
-		Name    string `yaml:"name"`
-		Module  string `yaml:"module"`
-		Version string `yaml:"version"`
-	} `yaml:"project"`
-	Vandor struct {
-		CLI          string `yaml:"cli"`
-		Architecture string `yaml:"architecture"`
-		Language     string `yaml:"language"`
-	} `yaml:"vandor"`
-}
-
-type VpkgItem struct {
-	Name    string   `yaml:"name"`
-	Version string   `yaml:"version"`
-	Tags    []string `yaml:"tags"`
-}
-
 type TemplateConfig struct {
 	Repositories map[string]string `yaml:"repositories"`
 }
@@ -116,8 +98,8 @@ func initializeGitRepo(dir string) error {
 }
 
 // createVandorConfig creates the vandor-config.yaml file in the target directory
-func createVandorConfig(targetDir string, config VandorConfig) error {
-	yamlData, err := yaml.Marshal(&config)
+func createVandorConfig(targetDir string, cfg config.Config) error {
+	yamlData, err := yaml.Marshal(&cfg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %v", err)
 	}
@@ -451,23 +433,23 @@ func initProject() error {
 		return fmt.Errorf("git is required for cloning templates")
 	}
 
-	config := VandorConfig{}
+	cfg := config.Config{}
 
 	// Get project information
 	fmt.Print(styles.Item.Render("Project name (e.g., my-clinic-app): "))
 	projectName, _ := reader.ReadString('\n')
-	config.Project.Name = strings.TrimSpace(projectName)
+	cfg.Project.Name = strings.TrimSpace(projectName)
 
-	if config.Project.Name == "" {
+	if cfg.Project.Name == "" {
 		fmt.Println(styles.Error.Render("❌ Project name is required"))
 		return fmt.Errorf("project name cannot be empty")
 	}
 
 	fmt.Print(styles.Item.Render("Go module path (e.g., github.com/your-org/my-clinic-app): "))
 	modulePath, _ := reader.ReadString('\n')
-	config.Project.Module = strings.TrimSpace(modulePath)
+	cfg.Project.Module = strings.TrimSpace(modulePath)
 
-	if config.Project.Module == "" {
+	if cfg.Project.Module == "" {
 		fmt.Println(styles.Error.Render("❌ Go module path is required"))
 		return fmt.Errorf("go module path cannot be empty")
 	}
@@ -478,13 +460,13 @@ func initProject() error {
 	if version == "" {
 		version = "0.1.0"
 	}
-	config.Project.Version = version
+	cfg.Project.Version = version
 
 	vandorVersion, _, _ := getVersionInfo()
 
 	// Set Vandor CLI version
-	config.Vandor.CLI = vandorVersion
-	config.Vandor.Language = "go"
+	cfg.Vandor.CLI = vandorVersion
+	cfg.Vandor.Language = "go"
 
 	// Ask for architecture type
 	fmt.Println()
@@ -499,20 +481,20 @@ func initProject() error {
 
 	switch choice {
 	case "1":
-		config.Vandor.Architecture = "full-backend"
+		cfg.Vandor.Architecture = "full-backend"
 	case "2":
-		config.Vandor.Architecture = "eda"
+		cfg.Vandor.Architecture = "eda"
 	case "3":
-		config.Vandor.Architecture = "minimal"
+		cfg.Vandor.Architecture = "minimal"
 	default:
-		config.Vandor.Architecture = "minimal"
+		cfg.Vandor.Architecture = "minimal"
 		fmt.Println(styles.Warning.Render("⚠️  No valid choice selected, defaulting to minimal"))
 	}
 
-	fmt.Println(styles.Success.Render(fmt.Sprintf("✅ Selected architecture: %s", config.Vandor.Architecture)))
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✅ Selected architecture: %s", cfg.Vandor.Architecture)))
 
 	// Check dependencies for the selected architecture
-	if err := checkAndInstallDependencies(config.Vandor.Architecture); err != nil {
+	if err := checkAndInstallDependencies(cfg.Vandor.Architecture); err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("❌ Dependency check failed: %v", err)))
 		return err
 	}
@@ -520,7 +502,7 @@ func initProject() error {
 	// Ask where to create the project
 	fmt.Println()
 	fmt.Println(styles.Title.Render("📁 Project Location:"))
-	fmt.Print(styles.Item.Render(fmt.Sprintf("Create new directory '%s' or use current directory? [new/current]: ", config.Project.Name)))
+	fmt.Print(styles.Item.Render(fmt.Sprintf("Create new directory '%s' or use current directory? [new/current]: ", cfg.Project.Name)))
 	locationChoice, _ := reader.ReadString('\n')
 	locationChoice = strings.ToLower(strings.TrimSpace(locationChoice))
 
@@ -529,12 +511,12 @@ func initProject() error {
 		targetDir = "."
 		fmt.Println(styles.Info.Render("📂 Using current directory"))
 	} else {
-		targetDir = config.Project.Name
+		targetDir = cfg.Project.Name
 		fmt.Println(styles.Info.Render(fmt.Sprintf("📂 Creating new directory: %s", targetDir)))
 	}
 
 	// Clone the project from GitHub template
-	if err := createProjectFromGitHubTemplate(config, targetDir); err != nil {
+	if err := createProjectFromGitHubTemplate(cfg, targetDir); err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("❌ Failed to create project from GitHub template: %v", err)))
 		return err
 	}
@@ -546,27 +528,27 @@ func initProject() error {
 }
 
 // createProjectFromGitHubTemplate creates a project by cloning from GitHub template
-func createProjectFromGitHubTemplate(config VandorConfig, targetDir string) error {
+func createProjectFromGitHubTemplate(cfg config.Config, targetDir string) error {
 	styles := theme.GetCurrentStyles()
 
 	// Get template repositories
 	templates := getTemplateRepositories()
 
 	// Get the repository URL for the selected architecture
-	repoURL, exists := templates[config.Vandor.Architecture]
+	repoURL, exists := templates[cfg.Vandor.Architecture]
 	if !exists {
-		return fmt.Errorf("no template repository configured for architecture: %s", config.Vandor.Architecture)
+		return fmt.Errorf("no template repository configured for architecture: %s", cfg.Vandor.Architecture)
 	}
 
 	fmt.Println()
-	fmt.Println(styles.Info.Render(fmt.Sprintf("📡 Cloning %s template from GitHub...", config.Vandor.Architecture)))
+	fmt.Println(styles.Info.Render(fmt.Sprintf("📡 Cloning %s template from GitHub...", cfg.Vandor.Architecture)))
 	fmt.Println(styles.Item.Render(fmt.Sprintf("Repository: %s", repoURL)))
 
 	// Clone the template
 	if targetDir == "." {
 		// For current directory, clone to temp and move files
 		tempDir := ".vandor-temp"
-		if err := cloneTemplate(repoURL, tempDir, config.Project.Name); err != nil {
+		if err := cloneTemplate(repoURL, tempDir, cfg.Project.Name); err != nil {
 			return err
 		}
 
@@ -584,19 +566,19 @@ func createProjectFromGitHubTemplate(config VandorConfig, targetDir string) erro
 		}
 	} else {
 		// Clone directly to subdirectory
-		if err := cloneTemplate(repoURL, targetDir, config.Project.Name); err != nil {
+		if err := cloneTemplate(repoURL, targetDir, cfg.Project.Name); err != nil {
 			return err
 		}
 	}
 
 	// Update project files with actual configuration
 	fmt.Println(styles.Info.Render("🔄 Customizing template with your project details..."))
-	if err := updateProjectConfiguration(targetDir, config); err != nil {
+	if err := updateProjectConfiguration(targetDir, cfg); err != nil {
 		fmt.Println(styles.Warning.Render(fmt.Sprintf("⚠️  Warning: Could not update project configuration: %v", err)))
 	}
 
 	// Create/update vandor-config.yaml in the target directory
-	if err := createVandorConfig(targetDir, config); err != nil {
+	if err := createVandorConfig(targetDir, cfg); err != nil {
 		fmt.Println(styles.Warning.Render(fmt.Sprintf("⚠️  Warning: Could not create vandor-config.yaml: %v", err)))
 	}
 
@@ -641,18 +623,18 @@ func moveTemplateFiles(src, dst string) error {
 }
 
 // updateProjectConfiguration updates project files with actual configuration
-func updateProjectConfiguration(projectDir string, config VandorConfig) error {
+func updateProjectConfiguration(projectDir string, cfg config.Config) error {
 	styles := theme.GetCurrentStyles()
 
 	// Replace all module names in the entire project
-	if err := replaceModuleNamesInProject(projectDir, config.Project.Module); err != nil {
+	if err := replaceModuleNamesInProject(projectDir, cfg.Project.Module); err != nil {
 		return fmt.Errorf("failed to replace module names: %v", err)
 	}
 
 	// Update README.md if it exists
 	readmePath := filepath.Join(projectDir, "README.md")
 	if _, err := os.Stat(readmePath); err == nil {
-		if err := updateReadme(readmePath, config.Project.Name); err != nil {
+		if err := updateReadme(readmePath, cfg.Project.Name); err != nil {
 			fmt.Println(styles.Warning.Render(fmt.Sprintf("⚠️  Warning: Could not update README.md: %v", err)))
 		} else {
 			fmt.Println(styles.Success.Render("✅ README.md updated!"))
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/generators"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+)
+
+// Flags of layout migrate.
+var layoutMigrateDryRun bool
+
+var layoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Show and migrate the project layout",
+	Long: `Show and migrate the project layout: the directory of each component kind.
+
+The add commands create components and sync discovers them in the same
+directories, so a component just added is always picked up by the next sync.
+Override any of them under layout in vandor-config.yaml, e.g.
+
+  layout:
+    domain: internal/domain
+    handler: internal/http/handler`,
+}
+
+var layoutShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the directory of each component kind",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		l, err := layout.Load()
+		if err != nil {
+			er(fmt.Sprintf("Failed to load layout: %v", err))
+		}
+		defaults := layout.Default()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "COMPONENT\tDIRECTORY\tSOURCE")
+		for _, kind := range layout.Kinds() {
+			dir, _ := l.Dir(kind)
+			source := "default"
+			if def, _ := defaults.Dir(kind); def != dir {
+				source = "configured"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", kind, filepath.ToSlash(dir), source)
+		}
+		_ = w.Flush()
+	},
+}
+
+var layoutMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move components into the project layout",
+	Long: `Move components into the directories of the project layout, then sync the
+registries.
+
+Components are moved from the directories earlier versions of the add
+commands created them in, which sync did not discover (domains in
+internal/core/domain, jobs in internal/core/job, handlers in
+internal/delivery/http/handler and schedulers in internal/cron/scheduler),
+and from the default directories of kinds whose directory is overridden in
+vandor-config.yaml. A component moved from a legacy directory takes the
+package of the directory it moves into, and generated files there are left
+for sync to regenerate. An overridden directory moves whole, and imports of
+its packages are rewritten across the project; the domain registry moves
+with the domain models. The job registry of earlier versions,
+internal/core/job/jobs.go and internal/delivery/worker/worker_gen.go, is
+removed: sync now generates it, RegisterJobs included, into the package of
+the jobs. Nothing is moved when any destination already exists.
+
+Moves are not recorded for vandor undo: run with --dry-run first, and commit
+before migrating.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		l, err := layout.Load()
+		if err != nil {
+			er(fmt.Sprintf("Failed to load layout: %v", err))
+		}
+
+		project, err := generators.CurrentProject()
+		if err != nil {
+			er(fmt.Sprintf("Failed to detect the Go module: %v", err))
+		}
+
+		migration, err := layout.Plan(project.Root, l)
+		if err != nil {
+			er(fmt.Sprintf("Cannot migrate: %v", err))
+		}
+		moves := migration.Moves
+		if len(moves) == 0 && len(migration.Removes) == 0 {
+			fmt.Println("✅ The project already follows its layout")
+			return
+		}
+
+		for _, move := range moves {
+			note := ""
+			if move.Package != "" {
+				note = fmt.Sprintf(" (package %s)", move.Package)
+			}
+			fmt.Printf("  %s: %s -> %s%s\n", move.Kind, move.From, move.To, note)
+		}
+		for _, path := range migration.Removes {
+			fmt.Printf("  retired: remove %s\n", path)
+		}
+		if layoutMigrateDryRun {
+			packages := make([]string, 0, len(migration.Packages))
+			for from := range migration.Packages {
+				packages = append(packages, from)
+			}
+			sort.Strings(packages)
+			for _, from := range packages {
+				to := migration.Packages[from]
+				fmt.Printf("  imports: %s/%s -> %s/%s\n", project.Module, filepath.ToSlash(from), project.Module, filepath.ToSlash(to))
+			}
+			fmt.Printf("%d file(s) would move and %d be removed (nothing was changed)\n", len(moves), len(migration.Removes))
+			return
+		}
+
+		if err := layout.Apply(project.Root, project.Module, migration); err != nil {
+			er(fmt.Sprintf("Failed to migrate: %v", err))
+		}
+
+		fmt.Println("Syncing registries...")
+		for _, registry := range []generators.RegistryGenerator{
+			generators.DomainRegistry,
+			generators.UsecaseRegistry,
+			generators.ServiceRegistry,
+			generators.JobRegistry,
+			generators.HandlerRegistry,
+		} {
+			if err := registry.Generate(os.Stdout); err != nil {
+				er(fmt.Sprintf("Failed to sync %s registry: %v", registry.Name, err))
+			}
+		}
+
+		fmt.Printf("✅ Moved %d file(s) into the project layout and removed %d\n", len(moves), len(migration.Removes))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(layoutCmd)
+	layoutCmd.AddCommand(layoutShowCmd)
+	layoutCmd.AddCommand(layoutMigrateCmd)
+	layoutMigrateCmd.Flags().BoolVar(&layoutMigrateDryRun, "dry-run", false, "List the files that would move without moving them")
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/textdiff"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Preview flags shared by the add and sync commands.
+var (
+	previewDryRun bool
+	previewDiff   bool
+)
+
+// preview is an in-progress --dry-run/--diff run.
+type preview struct {
+	overlay *vfs.Overlay
+	restore func()
+	stdout  *os.File
+}
+
+var activePreview *preview
+
+// addGenerationHooks registers --dry-run and --diff on a command group. Its
+// subcommands run against an in-memory filesystem when either is set, and
+// are journaled for vandor undo otherwise.
+func addGenerationHooks(group *cobra.Command) {
+	group.PersistentFlags().BoolVar(&previewDryRun, "dry-run", false, "Show which files would be created or modified without writing them")
+	group.PersistentFlags().BoolVar(&previewDiff, "diff", false, "Show the changes that would be made as a unified diff without writing them")
+	group.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if cmd.Parent() == addCmd && len(args) == 0 && (previewDryRun || previewDiff) {
+			er("--dry-run and --diff need the component arguments; the interactive TUI cannot be previewed")
+		}
+		startPreview()
+		if activePreview == nil {
+			startHistory()
+		}
+	}
+	group.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		finishPreview()
+		finishHistory()
+	}
+}
+
+// startPreview swaps in an overlay filesystem when a preview was requested.
+// Generator progress messages describe writes that do not happen, so stdout
+// is silenced until the preview report is printed.
+func startPreview() {
+	if !previewDryRun && !previewDiff {
+		return
+	}
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		er(fmt.Sprintf("Failed to start preview: %v", err))
+	}
+
+	overlay := vfs.NewOverlay(vfs.OS{})
+	activePreview = &preview{
+		overlay: overlay,
+		restore: vfs.Use(overlay),
+		stdout:  os.Stdout,
+	}
+	os.Stdout = devNull
+}
+
+// finishPreview restores the real filesystem and reports the changes the
+// command would have made.
+func finishPreview() {
+	p := activePreview
+	if p == nil {
+		return
+	}
+	activePreview = nil
+
+	p.restore()
+	_ = os.Stdout.Close()
+	os.Stdout = p.stdout
+
+	var changes []vfs.Change
+	for _, change := range p.overlay.Changes() {
+		// Bookkeeping under .vandor is not part of the generated code.
+		if strings.HasPrefix(filepath.ToSlash(change.Path), ".vandor/") {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("No files would change.")
+		return
+	}
+
+	if previewDryRun {
+		fmt.Println("Files that would change (nothing was written):")
+		for _, change := range changes {
+			action := "modify"
+			if change.Created {
+				action = "create"
+			}
+			fmt.Printf("  %s %s\n", action, change.Path)
+		}
+	}
+
+	if previewDiff {
+		for _, change := range changes {
+			oldName := filepath.ToSlash(change.Path)
+			if change.Created {
+				oldName = "/dev/null"
+			}
+			fmt.Print(textdiff.Unified(oldName, filepath.ToSlash(change.Path), change.Old, change.New))
+		}
+	}
+}
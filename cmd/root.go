@@ -3,8 +3,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
 )
 
 // Version information set by main package
@@ -23,18 +26,45 @@ It provides code generation, package management, and TUI interfaces for streamli
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
+	addComponentCommands()
 	return rootCmd.Execute()
 }
 
+// Flags of every command.
+var (
+	configFile string
+	configSet  []string
+)
+
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Project configuration file (default: vandor-config.yaml found from the current directory up, or $"+config.FileEnv+")")
+	rootCmd.PersistentFlags().StringArrayVar(&configSet, "set", nil, "Override a configuration key, e.g. --set layout.usecase=internal/app/usecase (repeatable)")
 }
 
+// initConfig applies the configuration flags and moves to the project root,
+// so that commands run in a subdirectory of the project find its files.
+// init creates a project where it runs, so it stays.
 func initConfig() {
-	// Config initialization will be handled here
+	config.Setup(configFile, configSet)
+
+	if cmd, _, err := rootCmd.Find(os.Args[1:]); err == nil && cmd == initCmd {
+		return
+	}
+	path, err := config.Find(".")
+	if err != nil || path == "" {
+		return
+	}
+	if root := filepath.Dir(path); root != "." {
+		if err := os.Chdir(root); err != nil {
+			er(fmt.Sprintf("Failed to move to the project root %s: %v", root, err))
+		}
+	}
 }
 
 func er(msg interface{}) {
+	// Journal whatever was written before the failure so it can be undone
+	finishHistory()
 	fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
 	os.Exit(1)
 }
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/regenerate/seed"
+)
+
+var (
+	seedDryRun bool
+	seedEntry  string
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Run database seeds",
+	Long:  `Run database seeds in dependency order as declared by each seed's GetDependencies.`,
+}
+
+var seedRunCmd = &cobra.Command{
+	Use:   "run [names...]",
+	Short: "Run seeds and their dependencies",
+	Long: `Run the given seeds together with the seeds they depend on, in dependency order.
+Without names every seed is run. Names may omit the "_seed" suffix.
+
+The seed order is resolved by the CLI; the seeds themselves are executed by the
+project's seed entrypoint (default ./cmd/seed), which receives the ordered names.
+
+Examples:
+  vandor seed run
+  vandor seed run user role --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		seeds, err := seed.DiscoverSeeds(seed.SeedDir())
+		if err != nil {
+			er(fmt.Sprintf("Failed to discover seeds: %v", err))
+		}
+
+		ordered, err := seed.SortSeeds(seeds)
+		if err != nil {
+			er(err)
+		}
+
+		plan, err := seed.Plan(ordered, args)
+		if err != nil {
+			er(err)
+		}
+
+		if len(plan) == 0 {
+			fmt.Println("No seeds found in", seed.SeedDir())
+			return
+		}
+
+		names := make([]string, 0, len(plan))
+		fmt.Println("Seed run order:")
+		for i, s := range plan {
+			fmt.Printf("  %d. %s\n", i+1, s.SeedName)
+			names = append(names, s.SeedName)
+		}
+
+		if seedDryRun {
+			fmt.Println("(Dry run - no seeds were executed)")
+			return
+		}
+
+		if err := runGoCommand(append([]string{"run", seedEntry}, names...)...); err != nil {
+			er(fmt.Sprintf("Failed to run seeds: %v", err))
+		}
+
+		fmt.Printf("✅ Ran %d seed(s) successfully!\n", len(names))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(seedCmd)
+	seedCmd.AddCommand(seedRunCmd)
+
+	seedRunCmd.Flags().BoolVar(&seedDryRun, "dry-run", false, "Show the resolved run order without executing seeds")
+	seedRunCmd.Flags().StringVar(&seedEntry, "entry", "./cmd/seed", "Project seed entrypoint passed to 'go run'")
+}
@@ -11,13 +11,41 @@ import (
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Sync and regenerate code for various components",
-	Long:  `Sync and regenerate code for domains, usecases, services, handlers, jobs, schedulers, seeds, and database models.`,
+	Long: `Sync and regenerate code for domains, usecases, services, handlers, jobs, schedulers, seeds, and database models.
+
+With --check, generated files are rendered in memory and compared with the
+files on disk. Stale files are shown as a unified diff and the command exits
+non-zero without writing anything, which makes it suitable for CI.
+
+With --watch, the directories each generator reads are polled and only the
+generators affected by a change (and those depending on them) are rerun.
+
+With --dry-run or --diff, generators write to memory and the files that
+would be created or modified are listed or shown as a diff.`,
+}
+
+var (
+	syncCheck bool
+	syncWatch bool
+	syncForce bool
+)
+
+// newSyncContext creates a command context carrying the sync flags.
+func newSyncContext(args []string) *command.CommandContext {
+	ctx := command.NewCommandContext(args)
+	ctx.Flags["check"] = syncCheck
+	ctx.Flags["watch"] = syncWatch
+	ctx.Flags["force"] = syncForce
+	return ctx
 }
 
 var syncAllCmd = &cobra.Command{
 	Use:   "all",
 	Short: "Sync all code",
-	Long:  `Sync all code components including domains, usecases, services, handlers, jobs, schedulers, seeds, and database models.`,
+	Long: `Sync all code components including domains, usecases, services, handlers, jobs, schedulers, seeds, and database models.
+
+Generators whose inputs are unchanged since the last sync are skipped; the
+fingerprints are kept in .vandor/cache. Use --force to regenerate everything.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Use unified command system
 		registry := command.GetGlobalRegistry()
@@ -27,7 +55,7 @@ var syncAllCmd = &cobra.Command{
 		}
 
 		// Create command context
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 
 		// Execute the unified command
 		if err := unifiedCmd.Execute(ctx); err != nil {
@@ -49,7 +77,7 @@ var syncCoreCmd = &cobra.Command{
 		}
 
 		// Create command context
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 
 		// Execute the unified command
 		if err := unifiedCmd.Execute(ctx); err != nil {
@@ -71,7 +99,7 @@ var syncDomainCmd = &cobra.Command{
 		}
 
 		// Create command context
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 
 		// Execute the unified command
 		if err := unifiedCmd.Execute(ctx); err != nil {
@@ -92,7 +120,7 @@ var syncUsecaseCmd = &cobra.Command{
 		}
 
 		// Create command context
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 
 		// Execute the unified command
 		if err := unifiedCmd.Execute(ctx); err != nil {
@@ -110,7 +138,7 @@ var syncServiceCmd = &cobra.Command{
 		if !exists {
 			er("Sync service command not found in registry")
 		}
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 		if err := unifiedCmd.Execute(ctx); err != nil {
 			er(fmt.Sprintf("Failed to execute sync service command: %v", err))
 		}
@@ -126,7 +154,7 @@ var syncJobCmd = &cobra.Command{
 		if !exists {
 			er("Sync job command not found in registry")
 		}
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 		if err := unifiedCmd.Execute(ctx); err != nil {
 			er(fmt.Sprintf("Failed to execute sync job command: %v", err))
 		}
@@ -142,7 +170,7 @@ var syncSchedulerCmd = &cobra.Command{
 		if !exists {
 			er("Sync scheduler command not found in registry")
 		}
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 		if err := unifiedCmd.Execute(ctx); err != nil {
 			er(fmt.Sprintf("Failed to execute sync scheduler command: %v", err))
 		}
@@ -158,7 +186,7 @@ var syncEnumCmd = &cobra.Command{
 		if !exists {
 			er("Sync enum command not found in registry")
 		}
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 		if err := unifiedCmd.Execute(ctx); err != nil {
 			er(fmt.Sprintf("Failed to execute sync enum command: %v", err))
 		}
@@ -174,7 +202,7 @@ var syncSeedCmd = &cobra.Command{
 		if !exists {
 			er("Sync seed command not found in registry")
 		}
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 		if err := unifiedCmd.Execute(ctx); err != nil {
 			er(fmt.Sprintf("Failed to execute sync seed command: %v", err))
 		}
@@ -190,7 +218,7 @@ var syncSeedCmd = &cobra.Command{
 // 		if !exists {
 // 			er("Sync handler command not found in registry")
 // 		}
-// 		ctx := command.NewCommandContext(args)
+// 		ctx := newSyncContext(args)
 // 		if err := unifiedCmd.Execute(ctx); err != nil {
 // 			er(fmt.Sprintf("Failed to execute sync handler command: %v", err))
 // 		}
@@ -206,7 +234,7 @@ var syncDbModelCmd = &cobra.Command{
 		if !exists {
 			er("Sync db-model command not found in registry")
 		}
-		ctx := command.NewCommandContext(args)
+		ctx := newSyncContext(args)
 		if err := unifiedCmd.Execute(ctx); err != nil {
 			er(fmt.Sprintf("Failed to execute sync db-model command: %v", err))
 		}
@@ -230,4 +258,11 @@ func init() {
 	syncCmd.AddCommand(syncSeedCmd)
 	// Note: syncHandlerCmd removed - now managed by http-huma vpkg package
 	syncCmd.AddCommand(syncDbModelCmd)
+
+	syncCmd.PersistentFlags().BoolVar(&syncCheck, "check", false, "Show a diff of out-of-date generated files and exit non-zero instead of writing")
+	syncCmd.PersistentFlags().BoolVar(&syncWatch, "watch", false, "Watch generator inputs and regenerate affected code on change")
+	addGenerationHooks(syncCmd)
+	syncCmd.MarkFlagsMutuallyExclusive("check", "watch", "dry-run")
+	syncCmd.MarkFlagsMutuallyExclusive("check", "watch", "diff")
+	syncAllCmd.Flags().BoolVar(&syncForce, "force", false, "Regenerate everything, ignoring the sync cache")
 }
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alfariiizi/vandor-cli/internal/templates"
+)
+
+// Flags of template eject and template test.
+var (
+	templateEjectGlobal bool
+	templateEjectForce  bool
+	templateTestNames   []string
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage the templates used by add commands",
+	Long: `Manage the templates used by the template-based add commands.
+
+Templates are looked up first in the project, in
+.vandor/templates/<component>/<component>.go.tmpl, then in the user-global
+template directory (~/.config/vandor/templates on Linux), and finally in the
+templates embedded in the CLI. Eject a template to change the boilerplate of
+a component without forking the CLI.
+
+Every other file in a template's directory is rendered next to the main file,
+keeping its place in the directory, so that one add creates a set of files.
+File names may contain template actions and lose their .tmpl extension, e.g.
+{{.NameSnake}}_test.go.tmpl or mocks/mock_{{.NameSnake}}.go.tmpl.
+
+Templates can call Pascal, Camel, Snake, Kebab, Title, Upper, Lower,
+GoPascal and GoCamel (acronym-aware: HTTPServer, userID), GoIdent, Plural,
+Singular, Import (module and path to an import path), PkgName, Default and
+Required, e.g. {{.Extra.table | Default (Plural .NameSnake)}}.`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List templates and the source each one resolves to",
+	Long: `List the component templates and the source each one resolves to: project,
+user or embedded.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		resolved, err := templates.NewTemplateManager().Templates()
+		if err != nil {
+			er(fmt.Sprintf("Failed to list templates: %v", err))
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "COMPONENT\tSOURCE\tFILES\tPATH")
+		for _, tmpl := range resolved {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", tmpl.Component, tmpl.Source, 1+len(tmpl.Files), tmpl.Path)
+		}
+		_ = w.Flush()
+	},
+}
+
+var templateEjectCmd = &cobra.Command{
+	Use:   "eject <component>",
+	Short: "Copy an embedded template out for editing",
+	Long: `Copy the embedded template of a component to
+.vandor/templates/<component>/<component>.go.tmpl, or to the user-global
+template directory with --global, where it overrides the embedded one.
+
+Example:
+  vandor template eject usecase`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := templates.ProjectDir
+		if templateEjectGlobal {
+			var err error
+			if dir, err = templates.UserDir(); err != nil {
+				er(fmt.Sprintf("Failed to locate the user template directory: %v", err))
+			}
+		}
+
+		path, err := templates.NewTemplateManager().Eject(args[0], dir, templateEjectForce)
+		if err != nil {
+			er(fmt.Sprintf("Failed to eject template: %v", err))
+		}
+		fmt.Printf("✅ Ejected %s template to %s\n", args[0], path)
+	},
+}
+
+var templateTestCmd = &cobra.Command{
+	Use:   "test [component...]",
+	Short: "Check that templates produce valid Go",
+	Long: `Render the templates of the given components, or of every component, with
+a matrix of names (acronyms, snake and kebab case, digits) and groups,
+then parse and type-check the Go they produce. Templates resolve as for add,
+so project and user overrides are tested in place of the embedded ones.
+
+Imports outside the standard library are not resolved: uses of them are not
+checked. Names that add rejects, such as Go keywords, are listed as rejected
+and not rendered. Exits non-zero when any rendering fails.
+
+Example:
+  vandor template test
+  vandor template test usecase --name CreateUser --name user_id`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cases, err := templates.NewTemplateManager().Test(args, templateTestNames)
+		if err != nil {
+			er(fmt.Sprintf("Failed to test templates: %v", err))
+		}
+
+		failed, rendered := 0, 0
+		for start := 0; start < len(cases); {
+			end, count := start, 0
+			var failures []templates.TestCase
+			var rejected []string
+			for ; end < len(cases) && cases[end].Component == cases[start].Component; end++ {
+				c := cases[end]
+				if c.Rejected != nil {
+					if !slices.Contains(rejected, c.Name) {
+						rejected = append(rejected, c.Name)
+					}
+					continue
+				}
+				count++
+				if c.Err != nil {
+					failures = append(failures, c)
+				}
+			}
+			failed += len(failures)
+			rendered += count
+
+			c := cases[start]
+			if len(failures) == 0 {
+				fmt.Printf("✅ %s (%s): %d rendering(s)\n", c.Component, c.Source, count)
+			} else {
+				fmt.Printf("❌ %s (%s): %d of %d rendering(s) failed\n", c.Component, c.Source, len(failures), count)
+			}
+			if len(rejected) > 0 {
+				fmt.Printf("  rejected as by add: %s\n", strings.Join(rejected, ", "))
+			}
+			for _, f := range failures {
+				label := f.Name
+				if f.Group != "" {
+					label = f.Group + "/" + f.Name
+				}
+				fmt.Printf("  %s:\n", label)
+				for _, line := range strings.Split(f.Err.Error(), "\n") {
+					fmt.Printf("    %s\n", line)
+				}
+			}
+			start = end
+		}
+
+		if failed > 0 {
+			er(fmt.Sprintf("%d of %d rendering(s) failed", failed, rendered))
+		}
+		fmt.Printf("✅ %d rendering(s) produced valid Go\n", rendered)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateEjectCmd)
+	templateCmd.AddCommand(templateTestCmd)
+	templateEjectCmd.Flags().BoolVar(&templateEjectGlobal, "global", false, "Eject to the user-global template directory")
+	templateEjectCmd.Flags().BoolVar(&templateEjectForce, "force", false, "Overwrite an existing override")
+	templateTestCmd.Flags().StringSliceVar(&templateTestNames, "name", templates.TestNames, "Component name to render with (repeatable)")
+}
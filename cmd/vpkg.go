@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/alfariiizi/vandor-cli/internal/config"
 	"github.com/alfariiizi/vandor-cli/internal/vpkg"
 )
 
@@ -40,7 +41,7 @@ var vpkgListCmd = &cobra.Command{
 	Short: "List available packages from registry",
 	Long:  `List all available packages from the Vandor package registry.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		client := vpkg.NewRegistryClient(vpkgRegistry)
+		client := vpkg.NewRegistryClient(registries()...)
 
 		opts := vpkg.ListOptions{
 			Registry: vpkgRegistry,
@@ -91,7 +92,9 @@ Examples:
   vandor vpkg add vandor/redis-cache
   vandor vpkg add vandor/redis-cache@v0.2.0
   vandor vpkg add acme/migrate-db --dest internal/tools/migrate`,
-	Args: cobra.ExactArgs(1),
+	Args:    cobra.ExactArgs(1),
+	PreRun:  func(cmd *cobra.Command, args []string) { startHistory() },
+	PostRun: func(cmd *cobra.Command, args []string) { finishHistory() },
 	Run: func(cmd *cobra.Command, args []string) {
 		packageName := args[0]
 
@@ -114,7 +117,7 @@ Examples:
 
 		if useProgress {
 			// Use progress installer with enhanced text progress
-			progressInstaller := vpkg.NewProgressInstaller(vpkgRegistry, packageName)
+			progressInstaller := vpkg.NewProgressInstaller(registries(), packageName)
 
 			// Always use simple text progress (no TUI)
 			if err := progressInstaller.InstallWithSimpleProgress(packageName, opts); err != nil {
@@ -122,7 +125,7 @@ Examples:
 			}
 		} else {
 			// Use simple installer without progress
-			installer := vpkg.NewInstaller(vpkgRegistry)
+			installer := vpkg.NewInstaller(registries()...)
 
 			fmt.Printf("Installing package: %s\n", packageName)
 			if vpkgDryRun {
@@ -150,7 +153,7 @@ var vpkgRemoveCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		packageName := args[0]
 
-		installer := vpkg.NewInstaller(vpkgRegistry)
+		installer := vpkg.NewInstaller(registries()...)
 
 		fmt.Printf("Removing package: %s\n", packageName)
 		if vpkgBackup {
@@ -168,7 +171,7 @@ var vpkgListInstalledCmd = &cobra.Command{
 	Short: "List installed packages",
 	Long:  `List all packages currently installed in your project.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		installer := vpkg.NewInstaller(vpkgRegistry)
+		installer := vpkg.NewInstaller(registries()...)
 
 		packages, err := installer.ListInstalled()
 		if err != nil {
@@ -270,7 +273,7 @@ Examples:
 		packageName := args[0]
 		packageArgs := args[1:]
 
-		installer := vpkg.NewInstaller(vpkgRegistry)
+		installer := vpkg.NewInstaller(registries()...)
 
 		// Find installed package
 		packages, err := installer.ListInstalled()
@@ -317,6 +320,12 @@ Examples:
 	},
 }
 
+// registries returns the registries vpkg commands use: the one given by
+// --registry, or the ones of the project configuration.
+func registries() []string {
+	return config.Current().RegistryURLs(vpkgRegistry)
+}
+
 func init() {
 	rootCmd.AddCommand(vpkgCmd)
 
@@ -329,7 +338,7 @@ func init() {
 	vpkgCmd.AddCommand(vpkgExecCmd)
 
 	// Global flags
-	vpkgCmd.PersistentFlags().StringVar(&vpkgRegistry, "registry", "", "Alternative registry URL")
+	vpkgCmd.PersistentFlags().StringVar(&vpkgRegistry, "registry", "", "Alternative registry URL, instead of the registries of vandor-config.yaml")
 
 	// List flags
 	vpkgListCmd.Flags().StringSliceVar(&vpkgTags, "tags", []string{}, "Filter by tags (comma-separated)")
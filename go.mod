@@ -9,6 +9,8 @@ require (
 	github.com/charmbracelet/lipgloss v0.9.1
 	github.com/dave/jennifer v1.7.1
 	github.com/spf13/cobra v1.8.0
+	golang.org/x/term v0.6.0
+	golang.org/x/tools v0.30.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -30,8 +32,8 @@ require (
 	github.com/rivo/uniseg v0.4.6 // indirect
 	github.com/sahilm/fuzzy v0.1.1-0.20230530133925-c48e322e2a8f // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/mod v0.23.0 // indirect
 	golang.org/x/sync v0.11.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
-	golang.org/x/term v0.6.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
 )
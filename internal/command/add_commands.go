@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/alfariiizi/vandor-cli/internal/generators"
+	"github.com/alfariiizi/vandor-cli/internal/naming"
 	"github.com/alfariiizi/vandor-cli/internal/templates"
 )
 
@@ -57,10 +58,8 @@ func (c *AddDomainCommand) Validate(args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("domain name is required")
 	}
-	if args[0] == "" {
-		return fmt.Errorf("domain name cannot be empty")
-	}
-	return nil
+	_, err := naming.Component("domain", args[0])
+	return err
 }
 
 // AddUsecaseCommand implements the add usecase functionality
@@ -113,10 +112,8 @@ func (c *AddUsecaseCommand) Validate(args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("usecase name is required")
 	}
-	if args[0] == "" {
-		return fmt.Errorf("usecase name cannot be empty")
-	}
-	return nil
+	_, err := naming.Component("usecase", args[0])
+	return err
 }
 
 // AddServiceCommand implements the add service functionality
@@ -171,13 +168,11 @@ func (c *AddServiceCommand) Validate(args []string) error {
 	if len(args) < 2 {
 		return fmt.Errorf("service group and name are required")
 	}
-	if args[0] == "" {
-		return fmt.Errorf("service group cannot be empty")
+	if _, err := naming.Package("service group", args[0]); err != nil {
+		return err
 	}
-	if args[1] == "" {
-		return fmt.Errorf("service name cannot be empty")
-	}
-	return nil
+	_, err := naming.Component("service", args[1])
+	return err
 }
 
 // AddJobCommand implements the add job functionality
@@ -224,8 +219,6 @@ func (c *AddJobCommand) Validate(args []string) error {
 	if len(args) < 1 {
 		return fmt.Errorf("job name is required")
 	}
-	if args[0] == "" {
-		return fmt.Errorf("job name cannot be empty")
-	}
-	return nil
+	_, err := naming.Component("job", args[0])
+	return err
 }
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"io"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/generators"
+)
+
+// TestAddThenDiscover checks that what each add command creates is found by
+// the sync generator of its kind.
+func TestAddThenDiscover(t *testing.T) {
+	tests := []struct {
+		kind     string
+		command  Command
+		args     []string
+		registry generators.RegistryGenerator
+		want     string
+	}{
+		{"usecase", NewAddUsecaseCommand(), []string{"CreateUser"}, generators.UsecaseRegistry, "CreateUser"},
+		{"domain", NewAddDomainCommand(), []string{"User"}, generators.DomainRegistry, "User"},
+		{"service", NewAddServiceCommand(), []string{"user", "CreateUser"}, generators.ServiceRegistry, "user.CreateUser"},
+		{"job", NewAddJobCommand(), []string{"SendEmail"}, generators.JobRegistry, "SendEmail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			chdirTemp(t)
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			if err := os.WriteFile("go.mod", []byte("module example.com/app\n\ngo 1.23\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := &CommandContext{Args: tt.args, Stdout: io.Discard, Stderr: io.Discard}
+			if err := tt.command.Execute(ctx); err != nil {
+				t.Fatalf("add %s: %v", tt.kind, err)
+			}
+
+			result, err := tt.registry.Render(generators.Project{Root: ".", Module: "example.com/app"})
+			if err != nil {
+				t.Fatalf("discover: %v", err)
+			}
+			if !slices.Contains(result.Items, tt.want) {
+				t.Errorf("%s registry found %v, want %s", tt.kind, result.Items, tt.want)
+			}
+		})
+	}
+}
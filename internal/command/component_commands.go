@@ -0,0 +1,132 @@
+package command
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/alfariiizi/vandor-cli/internal/naming"
+	"github.com/alfariiizi/vandor-cli/internal/templates"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// AddComponentCommand implements add for a custom component declared in
+// vandor-config.yaml
+type AddComponentCommand struct {
+	component templates.Component
+}
+
+func NewAddComponentCommand(component templates.Component) *AddComponentCommand {
+	return &AddComponentCommand{component: component}
+}
+
+func (c *AddComponentCommand) Execute(ctx *CommandContext) error {
+	if err := c.Validate(ctx.Args); err != nil {
+		return err
+	}
+
+	config, err := c.component.Config(ctx.Args)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(ctx.Stdout, "Creating new %s: %s\n", c.component.Kind, config.Name)
+
+	templateManager := templates.NewTemplateManager()
+	if err := templateManager.Generate(config); err != nil {
+		return fmt.Errorf("failed to create %s: %w", c.component.Kind, err)
+	}
+
+	if c.component.Sync != "" {
+		if err := c.sync(ctx); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintf(ctx.Stdout, "✅ %s '%s' created successfully!\n", c.component.Kind, config.Name)
+	return nil
+}
+
+// sync runs the component's sync step: a vandor sync target such as
+// "usecase" or "all", or else a shell command, which is not run during a
+// preview since it would write to disk directly.
+func (c *AddComponentCommand) sync(ctx *CommandContext) error {
+	target := strings.TrimPrefix(c.component.Sync, "vandor sync ")
+	if cmd, ok := GetGlobalRegistry().Get("sync", target); ok {
+		_, _ = fmt.Fprintf(ctx.Stdout, "Auto-syncing %s...\n", target)
+		syncCtx := NewCommandContext(nil)
+		syncCtx.Stdout, syncCtx.Stderr = ctx.Stdout, ctx.Stderr
+		if err := cmd.Execute(syncCtx); err != nil {
+			return fmt.Errorf("failed to sync %s: %w", target, err)
+		}
+		return nil
+	}
+
+	if vfs.IsVirtual() {
+		_, _ = fmt.Fprintf(ctx.Stderr, "⚠️  Preview: not running %s\n", c.component.Sync)
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(ctx.Stdout, "Running %s...\n", c.component.Sync)
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", c.component.Sync)
+	} else {
+		cmd = exec.Command("sh", "-c", c.component.Sync)
+	}
+	cmd.Stdout = ctx.Stdout
+	cmd.Stderr = ctx.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", c.component.Sync, err)
+	}
+	return nil
+}
+
+func (c *AddComponentCommand) GetMetadata() CommandMetadata {
+	description := c.component.Description
+	if description == "" {
+		description = fmt.Sprintf("Create a new %s", c.component.Kind)
+	}
+
+	usage := "vandor add " + c.component.Kind
+	for _, arg := range c.component.Args {
+		usage += " <" + arg + ">"
+	}
+
+	return CommandMetadata{
+		Name:        c.component.Kind,
+		Category:    "add",
+		Description: description,
+		Usage:       usage,
+		Args:        c.component.Args,
+	}
+}
+
+func (c *AddComponentCommand) Validate(args []string) error {
+	if len(args) < len(c.component.Args) {
+		return fmt.Errorf("%s %s required", strings.Join(c.component.Args, ", "), plural(len(c.component.Args)))
+	}
+	for i, arg := range c.component.Args {
+		if args[i] == "" {
+			return fmt.Errorf("%s %s cannot be empty", c.component.Kind, arg)
+		}
+		switch arg {
+		case "name":
+			if _, err := naming.Component(c.component.Kind, args[i]); err != nil {
+				return err
+			}
+		case "group":
+			if _, err := naming.Package(c.component.Kind+" group", args[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return "is"
+	}
+	return "are"
+}
@@ -1,5 +1,12 @@
 package command
 
+import (
+	"fmt"
+	"os"
+
+	"github.com/alfariiizi/vandor-cli/internal/templates"
+)
+
 // RegisterAllCommands registers all available commands with the global registry
 func RegisterAllCommands() error {
 	registry := GetGlobalRegistry()
@@ -31,6 +38,16 @@ func RegisterAllCommands() error {
 		NewThemeInfoCommand(),
 	}
 
+	// Custom components declared in vandor-config.yaml. A broken declaration
+	// must not take the rest of the CLI down with it.
+	components, err := templates.LoadComponents()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Ignoring custom components: %v\n", err)
+	}
+	for _, component := range components {
+		commands = append(commands, NewAddComponentCommand(component))
+	}
+
 	// Register all commands
 	for _, cmd := range commands {
 		if err := registry.Register(cmd); err != nil {
@@ -4,19 +4,32 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"time"
 
-	"github.com/alfariiizi/vandor-cli/internal/regenerate/domain"
+	"github.com/alfariiizi/vandor-cli/internal/generators"
 	"github.com/alfariiizi/vandor-cli/internal/regenerate/entgo"
 	"github.com/alfariiizi/vandor-cli/internal/regenerate/enum"
-	"github.com/alfariiizi/vandor-cli/internal/regenerate/handler"
-	"github.com/alfariiizi/vandor-cli/internal/regenerate/job"
 	"github.com/alfariiizi/vandor-cli/internal/regenerate/scheduler"
 	"github.com/alfariiizi/vandor-cli/internal/regenerate/seed"
-	"github.com/alfariiizi/vandor-cli/internal/regenerate/service"
-	"github.com/alfariiizi/vandor-cli/internal/regenerate/usecase"
 	"github.com/alfariiizi/vandor-cli/internal/vpkg"
 )
 
+// Modes shared by every sync command.
+var (
+	syncCheckFlag = Flag{
+		Name:        "check",
+		Description: "Show a diff of out-of-date generated files and fail instead of writing",
+		Type:        "bool",
+		Default:     false,
+	}
+	syncWatchFlag = Flag{
+		Name:        "watch",
+		Description: "Watch generator inputs and regenerate on change",
+		Type:        "bool",
+		Default:     false,
+	}
+)
+
 // SyncAllCommand implements the sync all functionality
 type SyncAllCommand struct{}
 
@@ -25,29 +38,17 @@ func NewSyncAllCommand() *SyncAllCommand {
 }
 
 func (c *SyncAllCommand) Execute(ctx *CommandContext) error {
-	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing all code...\n")
-
-	// Run all generation functions in the order specified by taskfile gen:all
-	// Note: handler is now managed by http-huma vpkg package
-	generators := []struct {
-		name string
-		fn   func() error
-	}{
-		{"domain", domain.RegenerateDomain},
-		{"usecase", usecase.RegenerateUsecase},
-		{"service", service.RegenerateService},
-		{"job", job.RegenerateJob},
-		{"scheduler", scheduler.RegenerateScheduler},
-		{"seed", seed.RegenerateSeed},
-		{"entgo", entgo.RegenerateEntgo},
+	if handled, err := runSyncMode(ctx, syncAllSteps()); handled {
+		return err
 	}
 
-	// Run all generators in sequence
-	for _, gen := range generators {
-		_, _ = fmt.Fprintf(ctx.Stdout, "Regenerating %s...\n", gen.name)
-		if err := gen.fn(); err != nil {
-			return fmt.Errorf("failed to regenerate %s: %w", gen.name, err)
-		}
+	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing all code...\n")
+
+	start := time.Now()
+	results, err := runSyncSteps(ctx, syncAllSteps(), ctx.BoolFlag("force"))
+	printSyncTimings(ctx.Stdout, results, time.Since(start))
+	if err != nil {
+		return err
 	}
 
 	// Run vpkg sync capabilities
@@ -57,11 +58,6 @@ func (c *SyncAllCommand) Execute(ctx *CommandContext) error {
 		return fmt.Errorf("failed to execute vpkg sync capabilities: %w", err)
 	}
 
-	// Run goimports to clean up
-	if err := runCommand("goimports", "-w", "."); err != nil {
-		return fmt.Errorf("failed to run goimports: %w", err)
-	}
-
 	_, _ = fmt.Fprintf(ctx.Stdout, "✅ All code synced successfully!\n")
 	return nil
 }
@@ -71,8 +67,13 @@ func (c *SyncAllCommand) GetMetadata() CommandMetadata {
 		Name:        "all",
 		Category:    "sync",
 		Description: "Sync all code components",
-		Usage:       "vandor sync all",
+		Usage:       "vandor sync all [--check | --watch] [--force]",
 		Args:        []string{},
+		Flags: []Flag{
+			syncCheckFlag,
+			syncWatchFlag,
+			{Name: "force", Description: "Regenerate everything, ignoring the sync cache", Type: "bool", Default: false},
+		},
 	}
 }
 
@@ -88,6 +89,10 @@ func NewSyncCoreCommand() *SyncCoreCommand {
 }
 
 func (c *SyncCoreCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, syncCoreSteps()); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing core code...\n")
 
 	// Run core generation functions in the order specified by taskfile gen:core
@@ -95,9 +100,9 @@ func (c *SyncCoreCommand) Execute(ctx *CommandContext) error {
 		name string
 		fn   func() error
 	}{
-		{"usecase", usecase.RegenerateUsecase},
-		{"service", service.RegenerateService},
-		{"domain", domain.RegenerateDomain},
+		{"usecase", generators.GenerateUsecaseRegistry},
+		{"service", generators.GenerateServiceRegistry},
+		{"domain", generators.GenerateDomainRegistry},
 	}
 
 	// Run all generators in sequence
@@ -119,6 +124,7 @@ func (c *SyncCoreCommand) GetMetadata() CommandMetadata {
 		Description: "Sync core code (domains, usecases, services)",
 		Usage:       "vandor sync core",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
@@ -134,8 +140,12 @@ func NewSyncDomainCommand() *SyncDomainCommand {
 }
 
 func (c *SyncDomainCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, []syncStep{registryStep(generators.DomainRegistry)}); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing domain code...\n")
-	if err := domain.RegenerateDomain(); err != nil {
+	if err := generators.GenerateDomainRegistry(); err != nil {
 		return fmt.Errorf("failed to generate domain code: %w", err)
 	}
 	return nil
@@ -148,6 +158,7 @@ func (c *SyncDomainCommand) GetMetadata() CommandMetadata {
 		Description: "Sync domain code",
 		Usage:       "vandor sync domain",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
@@ -163,8 +174,12 @@ func NewSyncUsecaseCommand() *SyncUsecaseCommand {
 }
 
 func (c *SyncUsecaseCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, []syncStep{registryStep(generators.UsecaseRegistry)}); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing usecases...\n")
-	if err := usecase.RegenerateUsecase(); err != nil {
+	if err := generators.GenerateUsecaseRegistry(); err != nil {
 		return fmt.Errorf("failed to generate usecase code: %w", err)
 	}
 	return nil
@@ -177,6 +192,7 @@ func (c *SyncUsecaseCommand) GetMetadata() CommandMetadata {
 		Description: "Sync usecase code",
 		Usage:       "vandor sync usecase",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
@@ -192,8 +208,12 @@ func NewSyncServiceCommand() *SyncServiceCommand {
 }
 
 func (c *SyncServiceCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, []syncStep{registryStep(generators.ServiceRegistry)}); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing services...\n")
-	if err := service.RegenerateService(); err != nil {
+	if err := generators.GenerateServiceRegistry(); err != nil {
 		return fmt.Errorf("failed to generate service code: %w", err)
 	}
 	return nil
@@ -206,6 +226,7 @@ func (c *SyncServiceCommand) GetMetadata() CommandMetadata {
 		Description: "Sync service code",
 		Usage:       "vandor sync service",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
@@ -221,8 +242,12 @@ func NewSyncJobCommand() *SyncJobCommand {
 }
 
 func (c *SyncJobCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, []syncStep{registryStep(generators.JobRegistry)}); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing jobs...\n")
-	if err := job.RegenerateJob(); err != nil {
+	if err := generators.GenerateJobRegistry(); err != nil {
 		return fmt.Errorf("failed to generate job code: %w", err)
 	}
 	return nil
@@ -235,6 +260,7 @@ func (c *SyncJobCommand) GetMetadata() CommandMetadata {
 		Description: "Sync job code",
 		Usage:       "vandor sync job",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
@@ -250,8 +276,12 @@ func NewSyncSchedulerCommand() *SyncSchedulerCommand {
 }
 
 func (c *SyncSchedulerCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, []syncStep{schedulerStep()}); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing schedulers...\n")
-	if err := scheduler.RegenerateScheduler(); err != nil {
+	if err := scheduler.RegenerateScheduler(ctx.Stdout); err != nil {
 		return fmt.Errorf("failed to generate scheduler code: %w", err)
 	}
 	return nil
@@ -264,6 +294,7 @@ func (c *SyncSchedulerCommand) GetMetadata() CommandMetadata {
 		Description: "Sync scheduler code",
 		Usage:       "vandor sync scheduler",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
@@ -279,8 +310,12 @@ func NewSyncEnumCommand() *SyncEnumCommand {
 }
 
 func (c *SyncEnumCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, []syncStep{enumStep()}); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing enums...\n")
-	if err := enum.RegenerateEnum(); err != nil {
+	if err := enum.RegenerateEnum(ctx.Stdout); err != nil {
 		return fmt.Errorf("failed to generate enum code: %w", err)
 	}
 	return nil
@@ -293,6 +328,7 @@ func (c *SyncEnumCommand) GetMetadata() CommandMetadata {
 		Description: "Sync enum code",
 		Usage:       "vandor sync enum",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
@@ -308,8 +344,12 @@ func NewSyncSeedCommand() *SyncSeedCommand {
 }
 
 func (c *SyncSeedCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, []syncStep{seedStep()}); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing seeds...\n")
-	if err := seed.RegenerateSeed(); err != nil {
+	if err := seed.RegenerateSeed(ctx.Stdout); err != nil {
 		return fmt.Errorf("failed to generate seed code: %w", err)
 	}
 	return nil
@@ -322,6 +362,7 @@ func (c *SyncSeedCommand) GetMetadata() CommandMetadata {
 		Description: "Sync seed code",
 		Usage:       "vandor sync seed",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
@@ -337,8 +378,12 @@ func NewSyncHandlerCommand() *SyncHandlerCommand {
 }
 
 func (c *SyncHandlerCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, []syncStep{registryStep(generators.HandlerRegistry)}); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing HTTP handlers...\n")
-	if err := handler.RegenerateHandler(); err != nil {
+	if err := generators.GenerateHandlerRegistry(); err != nil {
 		return fmt.Errorf("failed to generate handler code: %w", err)
 	}
 	return nil
@@ -351,6 +396,7 @@ func (c *SyncHandlerCommand) GetMetadata() CommandMetadata {
 		Description: "Sync HTTP handler code",
 		Usage:       "vandor sync handler",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
@@ -366,8 +412,12 @@ func NewSyncDbModelCommand() *SyncDbModelCommand {
 }
 
 func (c *SyncDbModelCommand) Execute(ctx *CommandContext) error {
+	if handled, err := runSyncMode(ctx, []syncStep{entgoStep()}); handled {
+		return err
+	}
+
 	_, _ = fmt.Fprintf(ctx.Stdout, "Syncing DB Model...\n")
-	if err := entgo.RegenerateEntgo(); err != nil {
+	if err := entgo.RegenerateEntgo(ctx.Stdout); err != nil {
 		return fmt.Errorf("failed to generate DB model: %w", err)
 	}
 	return nil
@@ -380,6 +430,7 @@ func (c *SyncDbModelCommand) GetMetadata() CommandMetadata {
 		Description: "Sync database models using Ent",
 		Usage:       "vandor sync db-model",
 		Args:        []string{},
+		Flags:       []Flag{syncCheckFlag, syncWatchFlag},
 	}
 }
 
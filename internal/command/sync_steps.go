@@ -0,0 +1,372 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alfariiizi/vandor-cli/internal/generators"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+	"github.com/alfariiizi/vandor-cli/internal/regenerate/entgo"
+	"github.com/alfariiizi/vandor-cli/internal/regenerate/enum"
+	"github.com/alfariiizi/vandor-cli/internal/regenerate/scheduler"
+	"github.com/alfariiizi/vandor-cli/internal/regenerate/seed"
+	"github.com/alfariiizi/vandor-cli/internal/synccache"
+	"github.com/alfariiizi/vandor-cli/internal/textdiff"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// syncStep is one generator run by sync. Inputs and outputs are the
+// project-relative paths it reads and writes; a step without inputs always
+// runs. Deps names steps that must finish first. Fn writes its progress to
+// out. Render produces the files in memory for --check; it is nil for
+// generators run as external programs.
+type syncStep struct {
+	name    string
+	inputs  []string
+	outputs []string
+	deps    []string
+	fn      func(out io.Writer) error
+	render  func(p generators.Project) ([]generators.GeneratedFile, error)
+}
+
+// Step statuses reported in the timing table.
+const (
+	stepSynced  = "synced"
+	stepSkipped = "skipped"
+	stepFailed  = "failed"
+	stepBlocked = "blocked"
+)
+
+// syncStepResult records how a step went for the timing table.
+type syncStepResult struct {
+	name     string
+	status   string
+	duration time.Duration
+	err      error
+}
+
+// registryStep adapts a registry generator to a sync step.
+func registryStep(g generators.RegistryGenerator, deps ...string) syncStep {
+	return syncStep{
+		name:    g.Name,
+		inputs:  g.Inputs(),
+		outputs: g.Outputs(),
+		deps:    deps,
+		fn:      g.Generate,
+		render: func(p generators.Project) ([]generators.GeneratedFile, error) {
+			result, err := g.Render(p)
+			return result.Files, err
+		},
+	}
+}
+
+func entgoStep() syncStep {
+	return syncStep{
+		name:    "entgo",
+		inputs:  []string{filepath.Join("database", "schema"), filepath.Join("cmd", "entgo")},
+		outputs: []string{filepath.Join("internal", "infrastructure", "db")},
+		fn:      entgo.RegenerateEntgo,
+	}
+}
+
+func schedulerStep() syncStep {
+	return syncStep{
+		name:   "scheduler",
+		inputs: []string{layout.Current().Scheduler, filepath.Join("cmd", "scheduler")},
+		fn:     scheduler.RegenerateScheduler,
+	}
+}
+
+func enumStep() syncStep {
+	return syncStep{
+		name:   "enum",
+		inputs: []string{layout.Current().Enum, filepath.Join("cmd", "enum")},
+		fn:     enum.RegenerateEnum,
+	}
+}
+
+func seedStep() syncStep {
+	return syncStep{
+		name:   "seed",
+		inputs: []string{seed.SeedDir()},
+		fn:     seed.RegenerateSeed,
+		render: func(p generators.Project) ([]generators.GeneratedFile, error) {
+			content, _, err := seed.RenderSeedRunner(p.Module)
+			if err != nil || content == nil {
+				return nil, err
+			}
+			return []generators.GeneratedFile{{Path: seed.RunnerPath(), Content: content}}, nil
+		},
+	}
+}
+
+// syncAllSteps lists the generators run by sync all. Domains wrap the ent
+// models and services wrap usecases; everything else is independent.
+// Note: handler is now managed by http-huma vpkg package
+func syncAllSteps() []syncStep {
+	return []syncStep{
+		entgoStep(),
+		registryStep(generators.DomainRegistry, "entgo"),
+		registryStep(generators.UsecaseRegistry),
+		registryStep(generators.ServiceRegistry, "usecase"),
+		registryStep(generators.JobRegistry),
+		schedulerStep(),
+		seedStep(),
+	}
+}
+
+// syncCoreSteps lists the generators run by sync core.
+func syncCoreSteps() []syncStep {
+	return []syncStep{
+		registryStep(generators.UsecaseRegistry),
+		registryStep(generators.ServiceRegistry, "usecase"),
+		registryStep(generators.DomainRegistry, "entgo"),
+	}
+}
+
+// orderSyncSteps returns steps in dependency order, rejecting duplicate names
+// and dependency cycles. Dependencies on steps outside the list are ignored
+// so subsets can be run on their own.
+func orderSyncSteps(steps []syncStep) ([]syncStep, error) {
+	byName := map[string]syncStep{}
+	for _, step := range steps {
+		if _, ok := byName[step.name]; ok {
+			return nil, fmt.Errorf("duplicate sync step %q", step.name)
+		}
+		byName[step.name] = step
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var path []string
+	var ordered []syncStep
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("sync step dependency cycle: %s -> %s", strings.Join(path, " -> "), name)
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range byName[name].deps {
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		ordered = append(ordered, byName[name])
+		return nil
+	}
+	for _, step := range steps {
+		if err := visit(step.name); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// runSyncSteps runs steps concurrently, each one as soon as its dependencies
+// have finished. A step is skipped when its inputs and outputs match the
+// fingerprint cached after its last successful run, unless force is set or a
+// dependency regenerated. Steps depending on a failed step are not run. All
+// failures are returned together; results follow the order of steps.
+func runSyncSteps(ctx *CommandContext, steps []syncStep, force bool) ([]syncStepResult, error) {
+	if _, err := orderSyncSteps(steps); err != nil {
+		return nil, err
+	}
+
+	cache := synccache.Load(".")
+	results := make([]syncStepResult, len(steps))
+	finished := map[string]chan struct{}{}
+	for _, step := range steps {
+		finished[step.name] = make(chan struct{})
+	}
+	index := map[string]int{}
+	for i, step := range steps {
+		index[step.name] = i
+	}
+
+	var outMu sync.Mutex
+	printf := func(format string, args ...interface{}) {
+		outMu.Lock()
+		defer outMu.Unlock()
+		_, _ = fmt.Fprintf(ctx.Stdout, format, args...)
+	}
+
+	var wg sync.WaitGroup
+	for i, step := range steps {
+		wg.Add(1)
+		go func(i int, step syncStep) {
+			defer wg.Done()
+			defer close(finished[step.name])
+
+			depChanged := false
+			for _, dep := range step.deps {
+				ch, ok := finished[dep]
+				if !ok {
+					continue
+				}
+				<-ch
+				switch results[index[dep]].status {
+				case stepFailed, stepBlocked:
+					printf("Skipping %s (%s did not sync)\n", step.name, dep)
+					results[i] = syncStepResult{name: step.name, status: stepBlocked}
+					return
+				case stepSynced:
+					depChanged = true
+				}
+			}
+			results[i] = runSyncStep(step, cache, force || depChanged, printf)
+		}(i, step)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+		}
+	}
+	if err := cache.Save(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return results, fmt.Errorf("%d sync step(s) failed:\n%w", len(errs), errors.Join(errs...))
+	}
+	return results, nil
+}
+
+// runSyncStep runs a single step, consulting and updating the cache.
+func runSyncStep(step syncStep, cache *synccache.Cache, force bool, printf func(string, ...interface{})) syncStepResult {
+	start := time.Now()
+	result := func(status string, err error) syncStepResult {
+		return syncStepResult{name: step.name, status: status, duration: time.Since(start), err: err}
+	}
+	paths := append(append([]string{}, step.inputs...), step.outputs...)
+
+	if len(step.inputs) > 0 && !force {
+		fingerprint, err := synccache.Fingerprint(".", paths)
+		if err != nil {
+			return result(stepFailed, err)
+		}
+		if cache.Fresh(step.name, fingerprint) {
+			printf("Skipping %s (unchanged)\n", step.name)
+			return result(stepSkipped, nil)
+		}
+	}
+
+	// Steps run concurrently, so each one's output is printed in one piece
+	// when it finishes rather than interleaved with the others.
+	var out bytes.Buffer
+	err := step.fn(&out)
+	printf("Regenerating %s...\n%s", step.name, out.String())
+	if err != nil {
+		cache.Forget(step.name)
+		return result(stepFailed, fmt.Errorf("failed to regenerate %s: %w", step.name, err))
+	}
+
+	// Record the state after generation so the step's own output does not
+	// invalidate the cache on the next run.
+	if len(step.inputs) > 0 {
+		fingerprint, err := synccache.Fingerprint(".", paths)
+		if err != nil {
+			return result(stepFailed, err)
+		}
+		cache.Set(step.name, fingerprint)
+	}
+	return result(stepSynced, nil)
+}
+
+// printSyncTimings writes a per-generator timing table. Steps run
+// concurrently, so the total is wall-clock time from the first step.
+func printSyncTimings(out io.Writer, results []syncStepResult, total time.Duration) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "GENERATOR\tSTATUS\tTIME")
+	for _, r := range results {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.name, r.status, r.duration.Round(time.Millisecond))
+	}
+	_, _ = fmt.Fprintf(w, "total\t\t%s\n", total.Round(time.Millisecond))
+	_ = w.Flush()
+}
+
+// runSyncMode handles the --check and --watch modes shared by every sync
+// command. It reports whether a mode was run.
+func runSyncMode(ctx *CommandContext, steps []syncStep) (bool, error) {
+	switch {
+	case ctx.BoolFlag("check"):
+		return true, checkSyncSteps(ctx, steps)
+	case ctx.BoolFlag("watch"):
+		return true, watchSyncSteps(ctx, steps)
+	}
+	return false, nil
+}
+
+// checkSyncSteps renders every step in memory and compares the result with
+// the files on disk, printing a unified diff for each stale file. Nothing is
+// written. Steps run by an external program cannot be rendered in memory:
+// they are skipped with a warning. It returns an error when any file is out
+// of date or cannot be rendered.
+func checkSyncSteps(ctx *CommandContext, steps []syncStep) error {
+	p, err := generators.CurrentProject()
+	if err != nil {
+		return err
+	}
+
+	stale := 0
+	var errs []error
+	for _, step := range steps {
+		if step.render == nil {
+			_, _ = fmt.Fprintf(ctx.Stderr, "⚠️  Not checking %s: it is generated by an external program\n", step.name)
+			continue
+		}
+
+		files, err := step.render(p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to render %s: %w", step.name, err))
+			continue
+		}
+		for _, file := range files {
+			current, err := vfs.ReadFile(p.Path(file.Path))
+			oldName := filepath.ToSlash(file.Path)
+			if errors.Is(err, fs.ErrNotExist) {
+				oldName = "/dev/null"
+			} else if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			diff := textdiff.Unified(oldName, filepath.ToSlash(file.Path), current, file.Content)
+			if diff == "" {
+				continue
+			}
+			stale++
+			_, _ = fmt.Fprint(ctx.Stdout, diff)
+		}
+	}
+
+	if stale > 0 {
+		errs = append(errs, fmt.Errorf("%d generated file(s) are out of date; run vandor sync", stale))
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	_, _ = fmt.Fprintf(ctx.Stdout, "✅ Generated code is up to date\n")
+	return nil
+}
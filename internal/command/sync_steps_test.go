@@ -0,0 +1,286 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alfariiizi/vandor-cli/internal/generators"
+)
+
+func step(name string, deps ...string) syncStep {
+	return syncStep{name: name, deps: deps, fn: func(io.Writer) error { return nil }}
+}
+
+func TestOrderSyncSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []syncStep
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "independent steps keep their order",
+			steps: []syncStep{step("usecase"), step("job"), step("seed")},
+			want:  "usecase job seed",
+		},
+		{
+			name:  "dependencies come first",
+			steps: []syncStep{step("domain", "entgo"), step("service", "usecase"), step("usecase"), step("entgo")},
+			want:  "entgo domain usecase service",
+		},
+		{
+			name:  "dependencies outside the list are ignored",
+			steps: []syncStep{step("domain", "entgo"), step("service", "usecase")},
+			want:  "domain service",
+		},
+		{
+			name:    "duplicate names",
+			steps:   []syncStep{step("usecase"), step("usecase")},
+			wantErr: `duplicate sync step "usecase"`,
+		},
+		{
+			name:    "self dependency",
+			steps:   []syncStep{step("usecase", "usecase")},
+			wantErr: "sync step dependency cycle: usecase -> usecase",
+		},
+		{
+			name:    "cycle",
+			steps:   []syncStep{step("a", "c"), step("b", "a"), step("c", "b")},
+			wantErr: "sync step dependency cycle: a -> c -> b -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, err := orderSyncSteps(tt.steps)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			names := make([]string, len(ordered))
+			for i, s := range ordered {
+				names[i] = s.name
+			}
+			if got := strings.Join(names, " "); got != tt.want {
+				t.Errorf("order = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultSyncStepsAreOrdered(t *testing.T) {
+	for name, steps := range map[string][]syncStep{"all": syncAllSteps(), "core": syncCoreSteps()} {
+		if _, err := orderSyncSteps(steps); err != nil {
+			t.Errorf("sync %s: %v", name, err)
+		}
+	}
+}
+
+// chdirTemp runs the test from an empty directory, where runSyncSteps keeps
+// its cache.
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func TestRunSyncSteps(t *testing.T) {
+	chdirTemp(t)
+
+	var mu sync.Mutex
+	ran := map[string]bool{}
+	record := func(name string, err error) func(io.Writer) error {
+		return func(out io.Writer) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ran[name] = true
+			return err
+		}
+	}
+	steps := []syncStep{
+		{name: "service", deps: []string{"usecase"}, fn: record("service", nil)},
+		{name: "usecase", fn: record("usecase", nil)},
+		{name: "domain", deps: []string{"entgo"}, fn: record("domain", nil)},
+		{name: "entgo", fn: record("entgo", errors.New("ent failed"))},
+	}
+
+	var out bytes.Buffer
+	results, err := runSyncSteps(&CommandContext{Stdout: &out}, steps, false)
+	if err == nil || !strings.Contains(err.Error(), "ent failed") {
+		t.Fatalf("error = %v, want the entgo failure", err)
+	}
+
+	want := map[string]string{
+		"service": stepSynced,
+		"usecase": stepSynced,
+		"domain":  stepBlocked,
+		"entgo":   stepFailed,
+	}
+	for i, r := range results {
+		if r.name != steps[i].name {
+			t.Errorf("result %d is %s, want %s", i, r.name, steps[i].name)
+		}
+		if r.status != want[r.name] {
+			t.Errorf("%s: status = %s, want %s", r.name, r.status, want[r.name])
+		}
+	}
+	if ran["domain"] {
+		t.Error("domain ran although entgo failed")
+	}
+}
+
+func TestRunSyncStepsOutputIsNotInterleaved(t *testing.T) {
+	chdirTemp(t)
+
+	// Each step writes its lines slowly, so concurrent steps would interleave
+	// if their output were not kept together.
+	writer := func(name string) func(io.Writer) error {
+		return func(out io.Writer) error {
+			for i := 0; i < 3; i++ {
+				fmt.Fprintf(out, "%s %d\n", name, i)
+				time.Sleep(time.Millisecond)
+			}
+			return nil
+		}
+	}
+	steps := []syncStep{
+		{name: "a", fn: writer("a")},
+		{name: "b", fn: writer("b")},
+		{name: "c", fn: writer("c")},
+	}
+
+	var out bytes.Buffer
+	if _, err := runSyncSteps(&CommandContext{Stdout: &out}, steps, false); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a", "b", "c"} {
+		block := fmt.Sprintf("Regenerating %[1]s...\n%[1]s 0\n%[1]s 1\n%[1]s 2\n", name)
+		if !strings.Contains(out.String(), block) {
+			t.Errorf("output of %s is not in one piece:\n%s", name, out.String())
+		}
+	}
+}
+
+func TestCheckSyncSteps(t *testing.T) {
+	chdirTemp(t)
+	if err := os.WriteFile("go.mod", []byte("module example.com/app\n\ngo 1.23\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("current.go", []byte("package app\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rendered := func(content string) func(generators.Project) ([]generators.GeneratedFile, error) {
+		return func(generators.Project) ([]generators.GeneratedFile, error) {
+			return []generators.GeneratedFile{{Path: "current.go", Content: []byte(content)}}, nil
+		}
+	}
+	upToDate := syncStep{name: "usecase", render: rendered("package app\n")}
+	stale := syncStep{name: "service", render: rendered("package app\n\nvar x int\n")}
+	external := syncStep{name: "scheduler"}
+
+	tests := []struct {
+		name    string
+		steps   []syncStep
+		wantErr []string
+	}{
+		{name: "up to date", steps: []syncStep{upToDate}},
+		{name: "stale", steps: []syncStep{upToDate, stale}, wantErr: []string{"1 generated file(s) are out of date"}},
+		{name: "external is skipped", steps: []syncStep{upToDate, external}},
+		{name: "stale and external", steps: []syncStep{stale, external}, wantErr: []string{"1 generated file(s) are out of date"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			err := checkSyncSteps(&CommandContext{Stdout: &out, Stderr: &errOut}, tt.steps)
+
+			for _, step := range tt.steps {
+				warned := strings.Contains(errOut.String(), "Not checking "+step.name)
+				if want := step.render == nil; warned != want {
+					t.Errorf("warned about %s = %v, want %v:\n%s", step.name, warned, want, errOut.String())
+				}
+			}
+
+			upToDate := strings.Contains(out.String(), "up to date")
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !upToDate {
+					t.Errorf("output does not report the code up to date:\n%s", out.String())
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+			if upToDate {
+				t.Errorf("output reports the code up to date:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestSyncAllCheckUpToDate(t *testing.T) {
+	chdirTemp(t)
+	if err := os.WriteFile("go.mod", []byte("module example.com/app\n\ngo 1.23\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := generators.CurrentProject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range syncAllSteps() {
+		if step.render == nil {
+			continue
+		}
+		files, err := step.render(p)
+		if err != nil {
+			t.Fatalf("render %s: %v", step.name, err)
+		}
+		if _, err := generators.WriteFiles(p, files); err != nil {
+			t.Fatalf("write %s: %v", step.name, err)
+		}
+	}
+
+	var out, errOut bytes.Buffer
+	ctx := &CommandContext{Stdout: &out, Stderr: &errOut, Flags: map[string]interface{}{"check": true}}
+	if err := NewSyncAllCommand().Execute(ctx); err != nil {
+		t.Fatalf("sync all --check on an up-to-date project: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "up to date") {
+		t.Errorf("output does not report the code up to date:\n%s", out.String())
+	}
+	for _, name := range []string{"entgo", "scheduler"} {
+		if !strings.Contains(errOut.String(), "Not checking "+name) {
+			t.Errorf("no warning that %s is not checked:\n%s", name, errOut.String())
+		}
+	}
+}
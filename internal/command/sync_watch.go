@@ -0,0 +1,206 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alfariiizi/vandor-cli/internal/generators"
+	"github.com/alfariiizi/vandor-cli/internal/synccache"
+)
+
+// Watch timings. Inputs are polled, and a burst of changes (an editor saving
+// several files, a git checkout) is handled once it has been quiet for
+// watchDebounce.
+const (
+	watchPollInterval = 300 * time.Millisecond
+	watchDebounce     = 500 * time.Millisecond
+)
+
+// watchSyncSteps polls the inputs of each step and reruns the steps whose
+// inputs changed, together with the steps that depend on them, until
+// interrupted.
+func watchSyncSteps(ctx *CommandContext, steps []syncStep) error {
+	ordered, err := orderSyncSteps(steps)
+	if err != nil {
+		return err
+	}
+
+	p, err := generators.CurrentProject()
+	if err != nil {
+		return err
+	}
+
+	w, err := newSyncWatcher(p, ordered)
+	if err != nil {
+		return err
+	}
+
+	var watched []string
+	for _, step := range ordered {
+		watched = append(watched, step.inputs...)
+	}
+	_, _ = fmt.Fprintf(ctx.Stdout, "👀 Watching %s (Ctrl+C to stop)\n", strings.Join(watched, ", "))
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Ctx.Done():
+			return nil
+		case <-interrupt:
+			_, _ = fmt.Fprintf(ctx.Stdout, "Stopped watching\n")
+			return nil
+		case <-ticker.C:
+		}
+
+		steps, err := w.poll()
+		if err != nil {
+			_, _ = fmt.Fprintf(ctx.Stderr, "❌ %v\n", err)
+			continue
+		}
+		if len(steps) == 0 {
+			continue
+		}
+
+		for _, step := range steps {
+			runWatchedStep(ctx, p, step)
+		}
+
+		// Generators may write into the directories they read from; take
+		// their output as the new baseline so it does not retrigger them.
+		if err := w.settle(); err != nil {
+			_, _ = fmt.Fprintf(ctx.Stderr, "❌ %v\n", err)
+		}
+	}
+}
+
+// syncWatcher tracks the inputs of the watched steps between polls.
+type syncWatcher struct {
+	p            generators.Project
+	ordered      []syncStep
+	fingerprints map[string]string
+	pending      map[string]bool // Steps whose inputs changed since they last ran
+	lastChange   time.Time
+	now          func() time.Time
+}
+
+// newSyncWatcher returns a watcher of the inputs of ordered steps as they
+// are now.
+func newSyncWatcher(p generators.Project, ordered []syncStep) (*syncWatcher, error) {
+	w := &syncWatcher{p: p, ordered: ordered, pending: map[string]bool{}, now: time.Now}
+	if err := w.settle(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// poll notes the steps whose inputs changed since the last poll. Once no
+// input has changed for watchDebounce, it returns those steps and the steps
+// depending on them, in order, and forgets them.
+func (w *syncWatcher) poll() ([]syncStep, error) {
+	current, err := inputFingerprints(w.p, w.ordered)
+	if err != nil {
+		return nil, err
+	}
+	for name, fingerprint := range current {
+		if w.fingerprints[name] != fingerprint {
+			w.pending[name] = true
+			w.lastChange = w.now()
+		}
+	}
+	w.fingerprints = current
+
+	if len(w.pending) == 0 || w.now().Sub(w.lastChange) < watchDebounce {
+		return nil, nil
+	}
+	steps := affectedSyncSteps(w.ordered, w.pending)
+	w.pending = map[string]bool{}
+	return steps, nil
+}
+
+// settle takes the inputs as they are now as the baseline of the next poll.
+func (w *syncWatcher) settle() error {
+	fingerprints, err := inputFingerprints(w.p, w.ordered)
+	if err != nil {
+		return err
+	}
+	w.fingerprints = fingerprints
+	return nil
+}
+
+// inputFingerprints fingerprints the inputs of each step by name.
+func inputFingerprints(p generators.Project, steps []syncStep) (map[string]string, error) {
+	fingerprints := map[string]string{}
+	for _, step := range steps {
+		fingerprint, err := synccache.Fingerprint(p.Root, step.inputs)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints[step.name] = fingerprint
+	}
+	return fingerprints, nil
+}
+
+// affectedSyncSteps returns the changed steps and everything depending on
+// them, keeping the dependency order of ordered.
+func affectedSyncSteps(ordered []syncStep, changed map[string]bool) []syncStep {
+	affected := map[string]bool{}
+	var result []syncStep
+	for _, step := range ordered {
+		hit := changed[step.name]
+		for _, dep := range step.deps {
+			hit = hit || affected[dep]
+		}
+		if hit {
+			affected[step.name] = true
+			result = append(result, step)
+		}
+	}
+	return result
+}
+
+// runWatchedStep regenerates a single step and prints one status line.
+// Native generators are rendered and written directly to keep the output
+// short; external ones run as usual.
+func runWatchedStep(ctx *CommandContext, p generators.Project, step syncStep) {
+	start := time.Now()
+	clock := start.Format("15:04:05")
+
+	if step.render == nil {
+		if err := step.fn(ctx.Stdout); err != nil {
+			_, _ = fmt.Fprintf(ctx.Stdout, "❌ %s %s: %v\n", clock, step.name, err)
+			return
+		}
+		_, _ = fmt.Fprintf(ctx.Stdout, "✅ %s %s regenerated (%s)\n", clock, step.name, time.Since(start).Round(time.Millisecond))
+		return
+	}
+
+	files, err := step.render(p)
+	if err == nil {
+		var written map[string]bool
+		if written, err = generators.WriteFiles(p, files); err == nil {
+			var changed []string
+			for _, file := range files {
+				if written[file.Path] {
+					changed = append(changed, file.Path)
+				}
+			}
+			status := "unchanged"
+			if len(changed) > 0 {
+				status = "wrote " + strings.Join(changed, ", ")
+			}
+			_, _ = fmt.Fprintf(ctx.Stdout, "✅ %s %s %s (%s)\n", clock, step.name, status, time.Since(start).Round(time.Millisecond))
+			return
+		}
+	}
+	_, _ = fmt.Fprintf(ctx.Stdout, "❌ %s %s: %v\n", clock, step.name, err)
+}
@@ -0,0 +1,215 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alfariiizi/vandor-cli/internal/generators"
+)
+
+func stepNames(steps []syncStep) string {
+	names := make([]string, len(steps))
+	for i, s := range steps {
+		names[i] = s.name
+	}
+	return strings.Join(names, " ")
+}
+
+func TestAffectedSyncSteps(t *testing.T) {
+	ordered := []syncStep{
+		step("entgo"), step("domain", "entgo"), step("usecase"),
+		step("service", "usecase"), step("handler", "service", "domain"), step("job"),
+	}
+
+	tests := []struct {
+		name    string
+		changed []string
+		want    string
+	}{
+		{name: "nothing", want: ""},
+		{name: "no dependents", changed: []string{"job"}, want: "job"},
+		{name: "dependents in order", changed: []string{"usecase"}, want: "usecase service handler"},
+		{name: "transitive dependents", changed: []string{"entgo"}, want: "entgo domain handler"},
+		{name: "last step", changed: []string{"handler"}, want: "handler"},
+		{name: "shared dependent once", changed: []string{"service", "domain"}, want: "domain service handler"},
+		{name: "unknown step", changed: []string{"seed"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := map[string]bool{}
+			for _, name := range tt.changed {
+				changed[name] = true
+			}
+			if got := stepNames(affectedSyncSteps(ordered, changed)); got != tt.want {
+				t.Errorf("affected = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeClock is a clock that only moves when told to.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// watchedProject returns a project with a file in each input of steps and
+// a watcher of them on a fake clock.
+func watchedProject(t *testing.T, steps []syncStep) (string, *syncWatcher, *fakeClock) {
+	t.Helper()
+	root := t.TempDir()
+	for _, s := range steps {
+		for _, input := range s.inputs {
+			writeWatched(t, filepath.Join(root, input, "a.go"), "package a\n")
+		}
+	}
+	w, err := newSyncWatcher(generators.Project{Root: root}, steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	w.now = clock.Now
+	return root, w, clock
+}
+
+func writeWatched(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// poll polls w and returns the names of the steps to run.
+func poll(t *testing.T, w *syncWatcher) string {
+	t.Helper()
+	steps, err := w.poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stepNames(steps)
+}
+
+func watchedSteps() []syncStep {
+	entgo := step("entgo")
+	entgo.inputs = []string{"database/schema"}
+	domain := step("domain", "entgo")
+	domain.inputs = []string{"internal/core/domain"}
+	usecase := step("usecase")
+	usecase.inputs = []string{"internal/core/usecase"}
+	service := step("service", "usecase")
+	service.inputs = []string{"internal/core/service"}
+	return []syncStep{entgo, domain, usecase, service}
+}
+
+func TestSyncWatcherChangedPaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(t *testing.T, root string)
+		want   string
+	}{
+		{
+			name: "modified schema",
+			change: func(t *testing.T, root string) {
+				writeWatched(t, filepath.Join(root, "database/schema/a.go"), "package b\n")
+			},
+			want: "entgo domain",
+		},
+		{
+			name: "new usecase",
+			change: func(t *testing.T, root string) {
+				writeWatched(t, filepath.Join(root, "internal/core/usecase/b.go"), "package a\n")
+			},
+			want: "usecase service",
+		},
+		{
+			name: "removed service",
+			change: func(t *testing.T, root string) {
+				if err := os.Remove(filepath.Join(root, "internal/core/service/a.go")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "service",
+		},
+		{
+			name: "two inputs",
+			change: func(t *testing.T, root string) {
+				writeWatched(t, filepath.Join(root, "internal/core/domain/b.go"), "package a\n")
+				writeWatched(t, filepath.Join(root, "internal/core/usecase/a.go"), "package b\n")
+			},
+			want: "domain usecase service",
+		},
+		{
+			name:   "file outside the inputs",
+			change: func(t *testing.T, root string) { writeWatched(t, filepath.Join(root, "README.md"), "# App\n") },
+			want:   "",
+		},
+		{
+			name: "same content",
+			change: func(t *testing.T, root string) {
+				writeWatched(t, filepath.Join(root, "database/schema/a.go"), "package a\n")
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, w, clock := watchedProject(t, watchedSteps())
+			tt.change(t, root)
+
+			if got := poll(t, w); got != "" {
+				t.Errorf("steps right after the change = %q, want none before the debounce", got)
+			}
+			clock.Advance(watchDebounce)
+			if got := poll(t, w); got != tt.want {
+				t.Errorf("steps = %q, want %q", got, tt.want)
+			}
+			clock.Advance(watchDebounce)
+			if got := poll(t, w); got != "" {
+				t.Errorf("steps on the next poll = %q, want none", got)
+			}
+		})
+	}
+}
+
+func TestSyncWatcherDebounce(t *testing.T) {
+	root, w, clock := watchedProject(t, watchedSteps())
+	schema := filepath.Join(root, "database/schema/a.go")
+	usecase := filepath.Join(root, "internal/core/usecase/a.go")
+
+	// A burst of changes waits until it has been quiet for watchDebounce
+	writeWatched(t, schema, "package b\n")
+	if got := poll(t, w); got != "" {
+		t.Errorf("steps after the first change = %q, want none", got)
+	}
+	clock.Advance(watchDebounce - watchPollInterval)
+	writeWatched(t, usecase, "package b\n")
+	if got := poll(t, w); got != "" {
+		t.Errorf("steps after the second change = %q, want none", got)
+	}
+	clock.Advance(watchDebounce - time.Millisecond)
+	if got := poll(t, w); got != "" {
+		t.Errorf("steps before the burst is quiet = %q, want none", got)
+	}
+	clock.Advance(time.Millisecond)
+	if got := poll(t, w); got != "entgo domain usecase service" {
+		t.Errorf("steps after the burst = %q, want every changed step and its dependents", got)
+	}
+
+	// Output written into an input by the steps is taken as the baseline
+	writeWatched(t, filepath.Join(root, "internal/core/domain/generated.go"), "package a\n")
+	if err := w.settle(); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(watchDebounce)
+	if got := poll(t, w); got != "" {
+		t.Errorf("steps after their own output = %q, want none", got)
+	}
+}
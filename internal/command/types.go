@@ -13,6 +13,7 @@ type CommandContext struct {
 	Stdout io.Writer
 	Stderr io.Writer
 	Stdin  io.Reader
+	Flags  map[string]interface{} // Parsed flag values keyed by flag name
 }
 
 // NewCommandContext creates a new command context with default values
@@ -23,9 +24,16 @@ func NewCommandContext(args []string) *CommandContext {
 		Stdout: os.Stdout,
 		Stderr: os.Stderr,
 		Stdin:  os.Stdin,
+		Flags:  map[string]interface{}{},
 	}
 }
 
+// BoolFlag returns the value of a boolean flag, or false when it is unset.
+func (c *CommandContext) BoolFlag(name string) bool {
+	value, _ := c.Flags[name].(bool)
+	return value
+}
+
 // Command represents a unified command that can be executed by both CLI and TUI
 type Command interface {
 	// Execute runs the command with the given context
@@ -0,0 +1,241 @@
+// Package config loads the project configuration, vandor-config.yaml, for
+// every command, and edits it for vandor config. The file is discovered from
+// any subdirectory of the project and applies on top of the user-level
+// configuration. Both are validated against the Config schema, and their
+// values can be overridden by VANDOR_* environment variables and --set flags.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the project configuration file.
+const FileName = "vandor-config.yaml"
+
+// FileEnv is the environment variable naming the configuration file to use
+// instead of discovering one.
+const FileEnv = "VANDOR_CONFIG"
+
+// Architectures are the project architectures init creates.
+var Architectures = []string{"full-backend", "eda", "minimal"}
+
+// Config is the project configuration.
+type Config struct {
+	Project    Project              `yaml:"project"`
+	Vandor     Vandor               `yaml:"vandor"`
+	Layout     map[string]string    `yaml:"layout,omitempty"`     // Directory by component kind, see package layout
+	Components map[string]Component `yaml:"components,omitempty"` // Custom component kinds by kind
+	Registries []string             `yaml:"registries,omitempty"` // vpkg registry URLs, the default one when empty
+	Vpkg       []Vpkg               `yaml:"vpkg,omitempty"`       // Installed vpkg packages
+
+	// Path is the file the configuration was loaded from; "" when there is
+	// none and the configuration is the default one.
+	Path string `yaml:"-"`
+}
+
+// Project describes the Go project.
+type Project struct {
+	Name    string `yaml:"name"`
+	Module  string `yaml:"module"`
+	Version string `yaml:"version"`
+}
+
+// Vandor describes how the project was created.
+type Vandor struct {
+	CLI          string `yaml:"cli"`
+	Architecture string `yaml:"architecture"` // One of Architectures
+	Language     string `yaml:"language"`
+}
+
+// Component is a custom component kind, added with vandor add <kind>.
+type Component struct {
+	Description string   `yaml:"description,omitempty"`
+	Template    string   `yaml:"template,omitempty"` // Template file or directory; .vandor/templates/<kind> when empty
+	Output      string   `yaml:"output"`             // Output path pattern
+	Args        []string `yaml:"args,omitempty"`     // Positional arguments; [name] when empty
+	Sync        string   `yaml:"sync,omitempty"`     // Sync target or shell command run after generation
+}
+
+// Vpkg is an installed vpkg package.
+type Vpkg struct {
+	Name    string   `yaml:"name"`
+	Version string   `yaml:"version"`
+	Tags    []string `yaml:"tags,omitempty"`
+}
+
+// Root returns the project root: the directory of the configuration file,
+// or the current directory when there is none.
+func (c Config) Root() string {
+	if c.Path == "" {
+		return "."
+	}
+	return filepath.Dir(c.Path)
+}
+
+// RegistryURLs returns the vpkg registry URLs to use: url when it is not
+// empty, e.g. from a --registry flag, and the configured ones otherwise.
+func (c Config) RegistryURLs(url string) []string {
+	if url != "" {
+		return []string{url}
+	}
+	return c.Registries
+}
+
+var (
+	file string   // Configuration file given by flag
+	set  []string // key=value overrides given by flag
+)
+
+// Setup sets the configuration file and key=value overrides given on the
+// command line. It must be called before the configuration is loaded.
+func Setup(configFile string, overrides []string) {
+	file, set = configFile, overrides
+}
+
+// GlobalPath returns the user-level configuration file, whose settings
+// apply to every project, ~/.config/vandor/config.yaml on Linux.
+func GlobalPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vandor", "config.yaml"), nil
+}
+
+// Find returns the path of the configuration file: the one given to Setup
+// or by VANDOR_CONFIG, or the first vandor-config.yaml found walking up
+// from directory from; "" when there is none.
+func Find(from string) (string, error) {
+	if file != "" {
+		return filepath.Abs(file)
+	}
+	if env := os.Getenv(FileEnv); env != "" {
+		return filepath.Abs(env)
+	}
+
+	start, err := filepath.Abs(from)
+	if err != nil {
+		return "", err
+	}
+	for dir := start; ; {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			if dir == start {
+				// Keep messages about a file in from short
+				path = filepath.Join(from, FileName)
+			}
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load returns the configuration of the project in the current directory,
+// on top of the user-level one, with its overrides applied, validated.
+// Without configuration files it is the default, empty configuration.
+func Load() (Config, error) {
+	path, err := Find(".")
+	if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	global, err := GlobalPath()
+	if err == nil {
+		if _, statErr := os.Stat(global); statErr == nil {
+			if err := decode(global, &c); err != nil {
+				return Config{}, err
+			}
+		}
+	}
+	if path != "" {
+		if err := decode(path, &c); err != nil {
+			return Config{}, err
+		}
+		c.Path = path
+	}
+	if err := c.override(os.Environ(), set); err != nil {
+		return Config{}, err
+	}
+	if err := c.Validate(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", c.Source(), err)
+	}
+	return c, nil
+}
+
+// Parse reads and decodes the configuration file at path, checking it
+// against the schema.
+func Parse(path string) (Config, error) {
+	c := Config{Path: path}
+	if err := decode(path, &c); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+// decode reads the configuration file at path, checks it against the schema
+// and decodes it into c: the keys it sets replace those of c, and the
+// entries of its maps are added to those of c.
+func decode(path string, c *Config) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s does not exist", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := Check(&doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := doc.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// Source returns how errors refer to the configuration: its file, if any.
+func (c Config) Source() string {
+	if c.Path == "" {
+		return "configuration"
+	}
+	return c.Path
+}
+
+var (
+	once    sync.Once
+	current Config
+)
+
+// Current returns the configuration of the project, loaded once. An invalid
+// configuration is reported and the default one used, so that commands that
+// do not depend on it keep working.
+func Current() Config {
+	once.Do(func() {
+		var err error
+		if current, err = Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Ignoring project configuration: %v\n", err)
+			current = Config{}
+		}
+	})
+	return current
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// inProject runs the test from an empty project directory, with its own
+// user-level configuration directory and no flags given. It returns the
+// user-level configuration file.
+func inProject(t *testing.T) string {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv(FileEnv, "")
+	Setup("", nil)
+	t.Cleanup(func() { Setup("", nil) })
+	return filepath.Join(home, "vandor", "config.yaml")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	tests := []struct {
+		name   string
+		global bool
+		file   bool
+		env    bool
+		set    bool
+		want   string
+	}{
+		{name: "default", want: ""},
+		{name: "global", global: true, want: "internal/global"},
+		{name: "project file over global", global: true, file: true, want: "internal/file"},
+		{name: "env over project file", global: true, file: true, env: true, want: "internal/env"},
+		{name: "set over env", global: true, file: true, env: true, set: true, want: "internal/set"},
+		{name: "set alone", set: true, want: "internal/set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			global := inProject(t)
+			if tt.global {
+				writeFile(t, global, "layout:\n  usecase: internal/global\n")
+			}
+			if tt.file {
+				writeFile(t, FileName, "layout:\n  usecase: internal/file\n")
+			}
+			if tt.env {
+				t.Setenv(EnvName("layout.usecase"), "internal/env")
+			}
+			if tt.set {
+				Setup("", []string{"layout.usecase=internal/set"})
+			}
+
+			c, err := Load()
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if got := c.Layout["usecase"]; got != tt.want {
+				t.Errorf("layout.usecase = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMergesGlobalAndProjectFiles(t *testing.T) {
+	global := inProject(t)
+	writeFile(t, global, "vandor:\n  architecture: eda\nlayout:\n  domain: internal/entity\n  usecase: internal/global\n")
+	writeFile(t, FileName, "project:\n  name: app\nlayout:\n  usecase: internal/app/usecase\n")
+
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	tests := []struct {
+		key, got, want string
+	}{
+		{"project.name", c.Project.Name, "app"},
+		{"vandor.architecture", c.Vandor.Architecture, "eda"},
+		{"layout.domain", c.Layout["domain"], "internal/entity"},
+		{"layout.usecase", c.Layout["usecase"], "internal/app/usecase"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.key, tt.got, tt.want)
+		}
+	}
+	if c.Path != FileName {
+		t.Errorf("path = %q, want %q", c.Path, FileName)
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	tests := []struct {
+		name string
+		flag bool
+		env  bool
+		want string
+	}{
+		{name: "discovered", want: "discovered"},
+		{name: "VANDOR_CONFIG", env: true, want: "env"},
+		{name: "--config over VANDOR_CONFIG", flag: true, env: true, want: "flag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inProject(t)
+			writeFile(t, FileName, "project:\n  name: discovered\n")
+			writeFile(t, filepath.Join("configs", "env.yaml"), "project:\n  name: env\n")
+			writeFile(t, filepath.Join("configs", "flag.yaml"), "project:\n  name: flag\n")
+			if tt.env {
+				t.Setenv(FileEnv, filepath.Join("configs", "env.yaml"))
+			}
+			if tt.flag {
+				Setup(filepath.Join("configs", "flag.yaml"), nil)
+			}
+
+			c, err := Load()
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if c.Project.Name != tt.want {
+				t.Errorf("project.name = %q, want %q", c.Project.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadInvalidOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		set     []string
+		wantErr string
+	}{
+		{name: "unknown --set key", set: []string{"layout.usecase.x=a"}, wantErr: "--set layout.usecase.x=a"},
+		{name: "--set without value", set: []string{"layout.usecase"}, wantErr: "expected key=value"},
+		{name: "invalid value", set: []string{"vandor.architecture=monolith"}, wantErr: `vandor.architecture "monolith" must be one of`},
+		{name: "invalid env value", env: map[string]string{"VANDOR_VANDOR_ARCHITECTURE": "monolith"}, wantErr: "must be one of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inProject(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			Setup("", tt.set)
+
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a configuration file edited in place: its comments and the
+// order of its keys are kept.
+type Document struct {
+	Path   string
+	root   yaml.Node
+	indent int
+}
+
+// Open reads the configuration file at path for editing. A missing file is
+// an empty document, created when saved.
+func Open(path string) (*Document, error) {
+	d := &Document{Path: path, indent: 2}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &d.root); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	d.indent = indentOf(data)
+	return d, nil
+}
+
+// Config checks the document against the schema, then decodes and
+// validates it.
+func (d *Document) Config() (Config, error) {
+	c := Config{Path: d.Path}
+	if d.root.Kind == 0 {
+		return c, nil
+	}
+	if err := Check(&d.root); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", d.Path, err)
+	}
+	if err := d.root.Decode(&c); err != nil {
+		return Config{}, fmt.Errorf("failed to parse %s: %w", d.Path, err)
+	}
+	if err := c.Validate(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", d.Path, err)
+	}
+	return c, nil
+}
+
+// Get returns the value of key, e.g. layout.usecase or vandor; nil when it
+// is not set.
+func (d *Document) Get(key string) *yaml.Node {
+	node := d.mapping()
+	for _, part := range strings.Split(key, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		if node = value(node, part); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Set sets key, one of Keys, to value, replacing the value it had but
+// keeping its comments. List values are comma-separated.
+func (d *Document) Set(key, value string) error {
+	// Reject unknown keys and keys that cannot be set from a string
+	var scratch Config
+	if err := scratch.Set(key, value); err != nil {
+		return err
+	}
+
+	parts := strings.Split(key, ".")
+	m := d.mapping()
+	for _, part := range parts[:len(parts)-1] {
+		child := valueOf(m, part)
+		if child.Kind != yaml.MappingNode {
+			*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: child.HeadComment, LineComment: child.LineComment}
+		}
+		m = child
+	}
+
+	node := valueOf(m, parts[len(parts)-1])
+	replacement := yaml.Node{HeadComment: node.HeadComment, LineComment: node.LineComment, FootComment: node.FootComment}
+	if isList(key) {
+		replacement.Kind, replacement.Tag = yaml.SequenceNode, "!!seq"
+		if node.Kind == yaml.SequenceNode {
+			replacement.Style = node.Style
+		}
+		for _, item := range scratchValue(scratch, key) {
+			n := &yaml.Node{}
+			n.SetString(item)
+			replacement.Content = append(replacement.Content, n)
+		}
+	} else {
+		replacement.SetString(value)
+	}
+	*node = replacement
+	return nil
+}
+
+// Unset removes key, and the mappings it leaves empty; false when it is not
+// set.
+func (d *Document) Unset(key string) bool {
+	if d.root.Kind == 0 {
+		return false
+	}
+	return unset(d.mapping(), strings.Split(key, "."))
+}
+
+// Entry is a value of a document, by key.
+type Entry struct {
+	Key   string // e.g. layout.usecase or vpkg[0].name
+	Value string // Lists of strings are comma-separated
+}
+
+// Entries returns the values set in the document, in its order.
+func (d *Document) Entries() []Entry {
+	var entries []Entry
+	var walk func(node *yaml.Node, key string)
+	walk = func(node *yaml.Node, key string) {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				walk(node.Content[i+1], join(key, node.Content[i].Value))
+			}
+		case yaml.SequenceNode:
+			if scalars(node) {
+				entries = append(entries, Entry{Key: key, Value: Format(node)})
+				return
+			}
+			for i, item := range node.Content {
+				walk(item, fmt.Sprintf("%s[%d]", key, i))
+			}
+		case yaml.AliasNode:
+			walk(node.Alias, key)
+		default:
+			entries = append(entries, Entry{Key: key, Value: node.Value})
+		}
+	}
+	if d.root.Kind != 0 {
+		walk(d.mapping(), "")
+	}
+	return entries
+}
+
+// Save writes the document to its file, creating its directory.
+func (d *Document) Save() error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(d.indent)
+	if err := enc.Encode(&d.root); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", d.Path, err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", d.Path, err)
+	}
+
+	data := buf.Bytes()
+	if len(d.mapping().Content) == 0 {
+		data = nil
+	}
+	if err := os.MkdirAll(filepath.Dir(d.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(d.Path), err)
+	}
+	if err := os.WriteFile(d.Path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", d.Path, err)
+	}
+	return nil
+}
+
+// Format returns a value as Get returns it, as it is written on the command
+// line: scalars as they are, lists of strings comma-separated, and anything
+// else as YAML.
+func Format(node *yaml.Node) string {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch {
+	case node.Kind == yaml.ScalarNode:
+		return node.Value
+	case node.Kind == yaml.SequenceNode && scalars(node):
+		items := make([]string, len(node.Content))
+		for i, item := range node.Content {
+			items[i] = item.Value
+		}
+		return strings.Join(items, ",")
+	}
+
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(data), "\n")
+}
+
+// mapping returns the top-level mapping of the document, creating it.
+func (d *Document) mapping() *yaml.Node {
+	if d.root.Kind == 0 {
+		d.root = yaml.Node{Kind: yaml.DocumentNode}
+	}
+	if len(d.root.Content) == 0 || d.root.Content[0].Kind != yaml.MappingNode {
+		d.root.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	return d.root.Content[0]
+}
+
+// value returns the value of key name in mapping m; nil when there is none.
+func value(m *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == name {
+			if m.Content[i+1].Kind == yaml.AliasNode {
+				return m.Content[i+1].Alias
+			}
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// valueOf returns the value of key name in mapping m, adding the key with a
+// null value when there is none.
+func valueOf(m *yaml.Node, name string) *yaml.Node {
+	if v := value(m, name); v != nil {
+		return v
+	}
+	k, v := &yaml.Node{}, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+	k.SetString(name)
+	m.Content = append(m.Content, k, v)
+	return v
+}
+
+// unset removes the key at path parts from mapping m, and the mappings it
+// leaves empty.
+func unset(m *yaml.Node, parts []string) bool {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != parts[0] {
+			continue
+		}
+		if len(parts) > 1 {
+			child := m.Content[i+1]
+			if child.Kind != yaml.MappingNode || !unset(child, parts[1:]) {
+				return false
+			}
+			if len(child.Content) > 0 {
+				return true
+			}
+		}
+		m.Content = append(m.Content[:i], m.Content[i+2:]...)
+		return true
+	}
+	return false
+}
+
+// isList reports whether key is a list of strings.
+func isList(key string) bool {
+	t := reflect.TypeOf(Config{})
+	for _, part := range strings.Split(key, ".") {
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := Fields(t)[part]
+			if !ok {
+				return false
+			}
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return false
+		}
+	}
+	return t.Kind() == reflect.Slice
+}
+
+// scratchValue returns the list key was set to in c.
+func scratchValue(c Config, key string) []string {
+	v := reflect.ValueOf(c)
+	for _, part := range strings.Split(key, ".") {
+		v = v.FieldByIndex(Fields(v.Type())[part].Index)
+	}
+	return v.Interface().([]string)
+}
+
+// scalars reports whether node is a list of scalars.
+func scalars(node *yaml.Node) bool {
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+// indentOf returns the indentation of the first indented line of a YAML
+// file, 2 when there is none.
+func indentOf(data []byte) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if n := len(line) - len(trimmed); n > 0 && trimmed != "" && !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "- ") {
+			return n
+		}
+	}
+	return 2
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const editedConfig = `# Project configuration
+project:
+  name: app # The binary name
+  module: example.com/app
+vandor:
+  # How the project was created
+  architecture: full-backend
+  cli: v1.0.0
+registries: ['https://a.example.com']
+`
+
+// edit opens content as a configuration file, applies edit and returns the
+// file saved.
+func edit(t *testing.T, content string, edit func(d *Document) error) (string, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), FileName)
+	if content != "" {
+		writeFile(t, path, content)
+	}
+	d, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := edit(d); err != nil {
+		return "", err
+	}
+	if err := d.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), nil
+}
+
+func TestDocumentSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		key     string
+		value   string
+		want    string
+	}{
+		{
+			name:    "replace a value keeping its comments",
+			content: editedConfig,
+			key:     "project.name",
+			value:   "api",
+			want:    strings.Replace(editedConfig, "name: app #", "name: api #", 1),
+		},
+		{
+			name:    "replace a commented value",
+			content: editedConfig,
+			key:     "vandor.architecture",
+			value:   "eda",
+			want:    strings.Replace(editedConfig, "architecture: full-backend", "architecture: eda", 1),
+		},
+		{
+			name:    "add a key after the others",
+			content: editedConfig,
+			key:     "vandor.language",
+			value:   "go",
+			want:    strings.Replace(editedConfig, "cli: v1.0.0\n", "cli: v1.0.0\n  language: go\n", 1),
+		},
+		{
+			name:    "create nested keys",
+			content: editedConfig,
+			key:     "layout.usecase",
+			value:   "internal/app/usecase",
+			want:    editedConfig + "layout:\n  usecase: internal/app/usecase\n",
+		},
+		{
+			name:    "replace a null mapping",
+			content: "layout: # Directories\n",
+			key:     "layout.usecase",
+			value:   "internal/app/usecase",
+			want:    "layout: # Directories\n  usecase: internal/app/usecase\n",
+		},
+		{
+			name:    "replace a list keeping its style",
+			content: editedConfig,
+			key:     "registries",
+			value:   "https://b.example.com, https://c.example.com",
+			want:    strings.Replace(editedConfig, "['https://a.example.com']", "['https://b.example.com', 'https://c.example.com']", 1),
+		},
+		{
+			name:    "keep the indentation",
+			content: "vandor:\n    cli: v1.0.0\n",
+			key:     "vandor.language",
+			value:   "go",
+			want:    "vandor:\n    cli: v1.0.0\n    language: go\n",
+		},
+		{
+			name:  "missing file",
+			key:   "layout.usecase",
+			value: "usecase",
+			want:  "layout:\n  usecase: usecase\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := edit(t, tt.content, func(d *Document) error { return d.Set(tt.key, tt.value) })
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("saved\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentSetRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{name: "unknown key", key: "vandor.architecure", value: "eda", wantErr: `unknown key vandor.architecure (did you mean "architecture"?)`},
+		{name: "unknown section", key: "layouts.usecase", value: "usecase", wantErr: `unknown key layouts.usecase (did you mean "layout"?)`},
+		{name: "mapping", key: "vandor", value: "eda", wantErr: "vandor cannot be set from a string"},
+		{name: "custom component", key: "components.repository", value: "x", wantErr: "components.repository cannot be set from a string"},
+		{name: "invalid architecture", key: "vandor.architecture", value: "monolith", wantErr: `vandor.architecture "monolith" must be one of full-backend, eda, minimal`},
+		{name: "invalid registry", key: "registries", value: "https://a.example.com,ftp://b.example.com", wantErr: `registries[1] "ftp://b.example.com" must be an http or https URL`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), FileName)
+			writeFile(t, path, editedConfig)
+			d, err := Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Values of known keys are checked with the whole configuration
+			err = d.Set(tt.key, tt.value)
+			if err == nil {
+				_, err = d.Config()
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if data, _ := os.ReadFile(path); string(data) != editedConfig {
+				t.Errorf("file changed:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestDocumentUnset(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		key     string
+		want    string
+		wantOK  bool
+	}{
+		{
+			name:    "remove a key keeping the others",
+			content: editedConfig,
+			key:     "vandor.cli",
+			want:    strings.Replace(editedConfig, "  cli: v1.0.0\n", "", 1),
+			wantOK:  true,
+		},
+		{
+			name:    "remove a commented key",
+			content: editedConfig,
+			key:     "project.name",
+			want:    strings.Replace(editedConfig, "  name: app # The binary name\n", "", 1),
+			wantOK:  true,
+		},
+		{
+			name:    "remove the mappings left empty and their comments",
+			content: "# Project configuration\n\n# Directories\nlayout:\n  # Usecases\n  usecase: usecase\nregistries: ['https://a.example.com']\n",
+			key:     "layout.usecase",
+			want:    "# Project configuration\n\nregistries: ['https://a.example.com']\n",
+			wantOK:  true,
+		},
+		{
+			name:    "remove a section",
+			content: editedConfig,
+			key:     "vandor",
+			want:    strings.Replace(editedConfig, "vandor:\n  # How the project was created\n  architecture: full-backend\n  cli: v1.0.0\n", "", 1),
+			wantOK:  true,
+		},
+		{name: "not set", content: editedConfig, key: "vandor.language", want: editedConfig},
+		{name: "under a value", content: editedConfig, key: "project.name.first", want: editedConfig},
+		{name: "empty file", key: "vandor.cli", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ok bool
+			got, err := edit(t, tt.content, func(d *Document) error {
+				ok = d.Unset(tt.key)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("unset = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("saved\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
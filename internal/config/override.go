@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// EnvPrefix prefixes the environment variables overriding configuration
+// keys: VANDOR_PROJECT_NAME overrides project.name and VANDOR_LAYOUT_USECASE
+// layout.usecase.
+const EnvPrefix = "VANDOR_"
+
+// Keys returns the keys that can be overridden: the string and string list
+// keys, with "*" standing for any key of a map, e.g. layout.*.
+func Keys() []string {
+	var keys []string
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for name, field := range Fields(t) {
+			key := join(prefix, name)
+			switch ft := field.Type; {
+			case ft.Kind() == reflect.Struct:
+				walk(ft, key)
+			case ft.Kind() == reflect.String, ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String:
+				keys = append(keys, key)
+			case ft.Kind() == reflect.Map && ft.Elem().Kind() == reflect.String:
+				keys = append(keys, key+".*")
+			}
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+	sort.Strings(keys)
+	return keys
+}
+
+// EnvName returns the environment variable overriding key.
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// Set sets key, e.g. layout.usecase, to value. List values are
+// comma-separated.
+func (c *Config) Set(key, value string) error {
+	v := reflect.ValueOf(c).Elem()
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		switch v.Kind() {
+		case reflect.Struct:
+			fields := Fields(v.Type())
+			field, ok := fields[part]
+			if !ok {
+				return fmt.Errorf("unknown key %s%s", key, Suggest(part, keysOf(fields)))
+			}
+			v = v.FieldByIndex(field.Index)
+			continue
+		case reflect.Map:
+			if v.Type().Elem().Kind() != reflect.String || i != len(parts)-1 {
+				return fmt.Errorf("%s cannot be set from a string", key)
+			}
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+			v.SetMapIndex(reflect.ValueOf(part), reflect.ValueOf(value))
+			return nil
+		}
+		return fmt.Errorf("unknown key %s", key)
+	}
+
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(value)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("%s cannot be set from a string", key)
+	}
+	return nil
+}
+
+// override applies the overrides of the environment, then the key=value
+// overrides of the command line.
+func (c *Config) override(environ, overrides []string) error {
+	env := map[string]string{}
+	for _, kv := range environ {
+		if name, value, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(name, EnvPrefix) {
+			env[name] = value
+		}
+	}
+
+	for _, key := range Keys() {
+		if prefix, ok := strings.CutSuffix(key, "*"); ok {
+			// Any key of a map, e.g. VANDOR_LAYOUT_USECASE
+			envPrefix := EnvName(prefix)
+			for name, value := range env {
+				if sub, ok := strings.CutPrefix(name, envPrefix); ok && sub != "" {
+					if err := c.Set(prefix+strings.ToLower(sub), value); err != nil {
+						return fmt.Errorf("%s: %w", name, err)
+					}
+				}
+			}
+			continue
+		}
+		if value, ok := env[EnvName(key)]; ok {
+			if err := c.Set(key, value); err != nil {
+				return fmt.Errorf("%s: %w", EnvName(key), err)
+			}
+		}
+	}
+
+	for _, kv := range overrides {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return fmt.Errorf("--set %s: expected key=value", kv)
+		}
+		if err := c.Set(strings.TrimSpace(key), value); err != nil {
+			return fmt.Errorf("--set %s: %w", kv, err)
+		}
+	}
+	return nil
+}
+
+func keysOf(fields map[string]reflect.StructField) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Check checks a decoded configuration document against the Config schema,
+// reporting every unknown key and value of the wrong type with its line.
+func Check(doc *yaml.Node) error {
+	node := doc
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+
+	var problems []string
+	checkNode(node, reflect.TypeOf(Config{}), "", &problems)
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// checkNode checks node against type t at key path key.
+func checkNode(node *yaml.Node, t reflect.Type, key string, problems *[]string) {
+	report := func(format string, args ...interface{}) {
+		*problems = append(*problems, fmt.Sprintf("line %d: %s", node.Line, fmt.Sprintf(format, args...)))
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			report("%s must be a mapping", describe(key))
+			return
+		}
+		fields := Fields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, value := node.Content[i].Value, node.Content[i+1]
+			field, ok := fields[name]
+			if !ok {
+				*problems = append(*problems, fmt.Sprintf("line %d: unknown key %s%s", node.Content[i].Line, join(key, name), Suggest(name, keysOf(fields))))
+				continue
+			}
+			checkNode(value, field.Type, join(key, name), problems)
+		}
+
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			report("%s must be a mapping", describe(key))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			checkNode(node.Content[i+1], t.Elem(), join(key, node.Content[i].Value), problems)
+		}
+
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			report("%s must be a list", describe(key))
+			return
+		}
+		for i, item := range node.Content {
+			checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", key, i), problems)
+		}
+
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			report("%s must be a string", describe(key))
+		}
+
+	case reflect.Bool:
+		if _, err := strconv.ParseBool(node.Value); node.Kind != yaml.ScalarNode || err != nil {
+			report("%s must be true or false", describe(key))
+		}
+	}
+}
+
+// Fields returns the fields of a struct type by YAML key.
+func Fields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// Validate checks the values of the configuration. The layout and the
+// custom components are checked by the packages that use them.
+func (c Config) Validate() error {
+	var problems []string
+	if a := c.Vandor.Architecture; a != "" && !contains(Architectures, a) {
+		problems = append(problems, fmt.Sprintf("vandor.architecture %q must be one of %s%s", a, strings.Join(Architectures, ", "), Suggest(a, Architectures)))
+	}
+	for i, registry := range c.Registries {
+		if u, err := url.Parse(registry); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("registries[%d] %q must be an http or https URL", i, registry))
+		}
+	}
+	seen := map[string]bool{}
+	for i, pkg := range c.Vpkg {
+		switch {
+		case pkg.Name == "":
+			problems = append(problems, fmt.Sprintf("vpkg[%d] needs a name", i))
+		case seen[pkg.Name]:
+			problems = append(problems, fmt.Sprintf("vpkg[%d] %s is listed more than once", i, pkg.Name))
+		}
+		seen[pkg.Name] = true
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// Suggest returns ` (did you mean "x"?)` for the candidate closest to s,
+// when one is close enough to be a typo; "" otherwise. Ties go to the first
+// candidate in sorted order; candidates itself is left as it is.
+func Suggest(s string, candidates []string) string {
+	candidates = append([]string(nil), candidates...)
+	sort.Strings(candidates)
+	best, bestDistance := "", len(s)/2+1
+	for _, candidate := range candidates {
+		if d := distance(strings.ToLower(s), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// describe returns how a problem refers to a key path.
+func describe(key string) string {
+	if key == "" {
+		return "the configuration"
+	}
+	return key
+}
+
+func join(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "." + name
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,444 @@
+// Package entschema reads ent schema definitions from Go source without
+// compiling them, so generators can derive code from a project's models.
+package entschema
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Dir is the project-relative directory ent schemas are declared in.
+var Dir = filepath.Join("database", "schema")
+
+// Schema is an ent entity.
+type Schema struct {
+	Name   string // Type name e.g. "User"
+	IDType string // Go type of the ID e.g. "int", "uuid.UUID"
+	Fields []Field
+	Edges  []Edge
+}
+
+// Field is a field of a schema, excluding the ID.
+type Field struct {
+	Name       string   // Schema name e.g. "first_name"
+	Kind       string   // ent field builder e.g. "String", "Enum"
+	GoType     string   // Go type of the value e.g. "string", "time.Time"
+	EnumValues []string // Values of an Enum field
+	Optional   bool
+	Nillable   bool
+	Unique     bool
+	Immutable  bool
+	Sensitive  bool
+	Default    bool // Has Default or UpdateDefault
+	Auto       bool // Maintained by ent, e.g. mixin timestamps and UpdateDefault
+}
+
+// Edge is a relation of a schema.
+type Edge struct {
+	Name     string // Edge name e.g. "owner"
+	Type     string // Target schema e.g. "User"
+	Inverse  bool   // Declared with edge.From
+	Unique   bool
+	Required bool
+	Field    string // Foreign key field bound with .Field, if any
+}
+
+// goTypes maps ent field builders to the Go type of their values. Builders
+// missing here (JSON, Other) are not supported and their fields are skipped.
+var goTypes = map[string]string{
+	"String":  "string",
+	"Text":    "string",
+	"Enum":    "string",
+	"Bool":    "bool",
+	"Int":     "int",
+	"Int8":    "int8",
+	"Int16":   "int16",
+	"Int32":   "int32",
+	"Int64":   "int64",
+	"Uint":    "uint",
+	"Uint8":   "uint8",
+	"Uint16":  "uint16",
+	"Uint32":  "uint32",
+	"Uint64":  "uint64",
+	"Float":   "float64",
+	"Float32": "float32",
+	"Time":    "time.Time",
+	"UUID":    "uuid.UUID",
+	"Bytes":   "[]byte",
+	"Strings": "[]string",
+	"Ints":    "[]int",
+	"Floats":  "[]float64",
+}
+
+// mixinFields are the fields added by ent's built-in mixins.
+var mixinFields = map[string][]Field{
+	"Time": {
+		{Name: "create_time", Kind: "Time", GoType: "time.Time", Immutable: true, Default: true},
+		{Name: "update_time", Kind: "Time", GoType: "time.Time", Default: true},
+	},
+	"CreateTime": {
+		{Name: "create_time", Kind: "Time", GoType: "time.Time", Immutable: true, Default: true},
+	},
+	"UpdateTime": {
+		{Name: "update_time", Kind: "Time", GoType: "time.Time", Default: true},
+	},
+}
+
+// Load parses the schemas declared in dir, sorted by name.
+func Load(dir string) ([]*Schema, error) {
+	entries, err := vfs.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("schema directory %s not found", dir)
+		}
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := gosrc.ParseFile(fset, filepath.Join(dir, entry.Name()), 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", entry.Name(), err)
+		}
+		files = append(files, file)
+	}
+
+	schemas := map[string]*Schema{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if embedsEntSchema(typeSpec) {
+					schemas[typeSpec.Name.Name] = &Schema{Name: typeSpec.Name.Name, IDType: "int"}
+				}
+			}
+		}
+	}
+
+	// Local mixins are declared like schemas; their fields are collected in
+	// the same pass and merged into the schemas using them afterwards.
+	mixins := map[string]*Schema{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, name := method(decl)
+			if fn == nil {
+				continue
+			}
+			schema := schemas[name]
+			if schema == nil {
+				if mixins[name] == nil {
+					mixins[name] = &Schema{Name: name}
+				}
+				schema = mixins[name]
+			}
+
+			switch fn.Name.Name {
+			case "Fields":
+				for _, call := range returnedElements(fn) {
+					parseField(schema, call)
+				}
+			case "Edges":
+				for _, call := range returnedElements(fn) {
+					if edge, ok := parseEdge(call); ok {
+						schema.Edges = append(schema.Edges, edge)
+					}
+				}
+			}
+		}
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, name := method(decl)
+			if fn == nil || fn.Name.Name != "Mixin" || schemas[name] == nil {
+				continue
+			}
+			var fields []Field
+			for _, elt := range returnedElements(fn) {
+				fields = append(fields, mixedInFields(elt, mixins)...)
+			}
+			// ent places mixin fields before the schema's own fields
+			schemas[name].Fields = append(fields, schemas[name].Fields...)
+		}
+	}
+
+	var result []*Schema
+	for _, schema := range schemas {
+		result = append(result, schema)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// Find returns the schema with the given type name.
+func Find(schemas []*Schema, name string) (*Schema, bool) {
+	for _, schema := range schemas {
+		if schema.Name == name {
+			return schema, true
+		}
+	}
+	return nil, false
+}
+
+// Field returns the field with the given schema name.
+func (s *Schema) Field(name string) (Field, bool) {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// Package is the name of the package ent generates for the schema.
+func (s *Schema) Package() string {
+	return strings.ToLower(s.Name)
+}
+
+// embedsEntSchema reports whether a type declaration is a struct embedding
+// ent.Schema.
+func embedsEntSchema(spec *ast.TypeSpec) bool {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return false
+	}
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 && isSelector(field.Type, "ent", "Schema") {
+			return true
+		}
+	}
+	return false
+}
+
+// returnedElements returns the elements of the slice literal a method like
+// Fields returns.
+func returnedElements(fn *ast.FuncDecl) []ast.Expr {
+	for _, stmt := range fn.Body.List {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		if lit, ok := ret.Results[0].(*ast.CompositeLit); ok {
+			return lit.Elts
+		}
+	}
+	return nil
+}
+
+// chainCall is one call of a builder chain e.g. Optional() or String("name").
+type chainCall struct {
+	name string
+	args []ast.Expr
+}
+
+// flattenChain turns field.String("name").Optional() into its calls, in
+// order, and the package the chain starts from.
+func flattenChain(expr ast.Expr) (string, []chainCall) {
+	var calls []chainCall
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return "", nil
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return "", nil
+		}
+		calls = append([]chainCall{{name: sel.Sel.Name, args: call.Args}}, calls...)
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			return ident.Name, calls
+		}
+		expr = sel.X
+	}
+}
+
+func parseField(schema *Schema, expr ast.Expr) {
+	pkg, calls := flattenChain(expr)
+	if pkg != "field" || len(calls) == 0 || len(calls[0].args) == 0 {
+		return
+	}
+
+	kind := calls[0].name
+	name, ok := stringLit(calls[0].args[0])
+	if !ok {
+		return
+	}
+
+	if name == "id" {
+		if goType, ok := goTypes[kind]; ok && kind != "Enum" {
+			schema.IDType = goType
+		}
+		return
+	}
+
+	goType, ok := goTypes[kind]
+	if !ok {
+		return
+	}
+
+	field := Field{Name: name, Kind: kind, GoType: goType}
+	for _, call := range calls[1:] {
+		switch call.name {
+		case "Optional":
+			field.Optional = true
+		case "Nillable":
+			field.Nillable = true
+		case "Unique":
+			field.Unique = true
+		case "Immutable":
+			field.Immutable = true
+		case "Sensitive":
+			field.Sensitive = true
+		case "Default", "DefaultFunc":
+			field.Default = true
+		case "UpdateDefault":
+			field.Default = true
+			field.Auto = true
+		case "Values":
+			for _, arg := range call.args {
+				if value, ok := stringLit(arg); ok {
+					field.EnumValues = append(field.EnumValues, value)
+				}
+			}
+		}
+	}
+	schema.Fields = append(schema.Fields, field)
+}
+
+func parseEdge(expr ast.Expr) (Edge, bool) {
+	pkg, calls := flattenChain(expr)
+	if pkg != "edge" || len(calls) == 0 || len(calls[0].args) != 2 {
+		return Edge{}, false
+	}
+
+	name, ok := stringLit(calls[0].args[0])
+	if !ok {
+		return Edge{}, false
+	}
+	target, ok := calls[0].args[1].(*ast.SelectorExpr)
+	if !ok || target.Sel.Name != "Type" {
+		return Edge{}, false
+	}
+	typeName, ok := target.X.(*ast.Ident)
+	if !ok {
+		return Edge{}, false
+	}
+
+	edge := Edge{Name: name, Type: typeName.Name, Inverse: calls[0].name == "From"}
+	for _, call := range calls[1:] {
+		switch call.name {
+		case "Unique":
+			edge.Unique = true
+		case "Required":
+			edge.Required = true
+		case "Field":
+			if len(call.args) == 1 {
+				edge.Field, _ = stringLit(call.args[0])
+			}
+		}
+	}
+	return edge, true
+}
+
+// method returns a method declaration with a body and its receiver type
+// name.
+func method(decl ast.Decl) (*ast.FuncDecl, string) {
+	fn, ok := decl.(*ast.FuncDecl)
+	if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 || fn.Body == nil {
+		return nil, ""
+	}
+	recv, ok := fn.Recv.List[0].Type.(*ast.Ident)
+	if !ok {
+		return nil, ""
+	}
+	return fn, recv.Name
+}
+
+// mixedInFields returns the fields added by a mixin literal: one of ent's
+// built-in mixins like mixin.Time{} or a mixin declared next to the schemas.
+// They are maintained by the mixin, so they are marked Auto.
+func mixedInFields(expr ast.Expr, local map[string]*Schema) []Field {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	var fields []Field
+	switch typ := lit.Type.(type) {
+	case *ast.SelectorExpr:
+		if isSelector(typ, "mixin", typ.Sel.Name) {
+			fields = mixinFields[typ.Sel.Name]
+		}
+	case *ast.Ident:
+		if mixin := local[typ.Name]; mixin != nil {
+			fields = mixin.Fields
+		}
+	}
+
+	result := make([]Field, len(fields))
+	for i, field := range fields {
+		field.Auto = true
+		result[i] = field
+	}
+	return result
+}
+
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
+
+// acronyms are the words ent upper-cases in generated identifiers.
+var acronyms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "AWS": true, "CPU": true, "CSS": true,
+	"DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IP": true, "JSON": true, "QPS": true, "RAM": true, "RPC": true,
+	"SLA": true, "SMTP": true, "SQL": true, "SSH": true, "SSO": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "UID": true, "URI": true,
+	"URL": true, "UTF8": true, "UUID": true, "VM": true, "XML": true, "XSRF": true,
+	"XSS": true,
+}
+
+// GoName returns the identifier ent generates for a field or edge name, e.g.
+// "owner_id" becomes "OwnerID".
+func GoName(name string) string {
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if acronyms[strings.ToUpper(word)] {
+			words[i] = strings.ToUpper(word)
+		} else if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, "")
+}
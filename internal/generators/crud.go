@@ -0,0 +1,902 @@
+package generators
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+
+	"github.com/alfariiizi/vandor-cli/internal/entschema"
+	"github.com/alfariiizi/vandor-cli/internal/naming"
+	"github.com/alfariiizi/vandor-cli/internal/overwrite"
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// crudModel is an ent schema and the names CRUD code is generated under.
+type crudModel struct {
+	schema  *entschema.Schema
+	schemas []*entschema.Schema
+	module  string
+	Name    string // Entity e.g. "BlogPost"
+	Plural  string // e.g. "BlogPosts"
+	Group   string // Service and route group e.g. "blog_post"
+	Lower   string // Human readable e.g. "blog post"
+	Lowers  string // Human readable plural e.g. "blog posts"
+}
+
+// crudEdge is a unique edge set by ID on create and update.
+type crudEdge struct {
+	Field  string // Input field e.g. "OwnerID"
+	JSON   string // Request body field e.g. "owner_id"
+	IDType string // Go type of the target's ID
+	Create bool   // Required on create
+}
+
+func (m crudModel) listOp() string   { return "List" + m.Plural }
+func (m crudModel) getOp() string    { return "Get" + m.Name }
+func (m crudModel) createOp() string { return "Create" + m.Name }
+func (m crudModel) updateOp() string { return "Update" + m.Name }
+func (m crudModel) deleteOp() string { return "Delete" + m.Name }
+
+func (m crudModel) ops() []string {
+	return []string{m.listOp(), m.getOp(), m.createOp(), m.updateOp(), m.deleteOp()}
+}
+
+func (m crudModel) dbPath() string      { return m.module + "/internal/infrastructure/db" }
+func (m crudModel) entPath() string     { return m.dbPath() + "/" + m.schema.Package() }
+func (m crudModel) modelPath() string   { return m.module + "/internal/core/model" }
+func (m crudModel) usecasePath() string { return importPath(m.module, usecaseDir()) }
+func (m crudModel) servicePath() string { return importPath(m.module, serviceRootDir(), m.Group) }
+
+// GenerateCRUD generates the domain, usecases, services and HTTP handlers to
+// list, get, create, update and delete an ent entity, deriving request and
+// response types from its schema in database/schema.
+func GenerateCRUD(model string) error {
+	p, err := CurrentProject()
+	if err != nil {
+		return err
+	}
+
+	schemas, err := entschema.Load(p.Path(entschema.Dir))
+	if err != nil {
+		return err
+	}
+
+	name := utils.ToPascalCase(model)
+	schema, ok := entschema.Find(schemas, name)
+	if !ok {
+		return fmt.Errorf("no ent schema named %s in %s", name, entschema.Dir)
+	}
+
+	files, err := RenderCRUD(p, schema, schemas)
+	if err != nil {
+		return err
+	}
+
+	// Settle every existing file before writing any
+	var create []GeneratedFile
+	for _, file := range files {
+		if err := naming.Collisions(p.Path(file.Path), file.Content); err != nil {
+			return err
+		}
+		ok, err := overwrite.Check(p.Path(file.Path), file.Content)
+		if err != nil {
+			return err
+		}
+		if ok {
+			create = append(create, file)
+		}
+	}
+
+	if !vfs.Exists(p.Path(domainModelDir(), strings.ToLower(name)+".go")) {
+		if err := GenerateDomain(name); err != nil {
+			return err
+		}
+	}
+
+	if _, err := WriteFiles(p, create); err != nil {
+		return err
+	}
+	for _, file := range create {
+		fmt.Printf("Created %s\n", file.Path)
+	}
+	return nil
+}
+
+// RenderCRUD renders the CRUD usecases, services and handlers of a schema.
+// schemas is every schema of the project, used to type edge IDs.
+func RenderCRUD(p Project, schema *entschema.Schema, schemas []*entschema.Schema) ([]GeneratedFile, error) {
+	m := crudModel{
+		schema:  schema,
+		schemas: schemas,
+		module:  p.Module,
+		Name:    schema.Name,
+		Plural:  utils.ToPlural(schema.Name),
+		Group:   utils.ToSnakeCase(schema.Name),
+		Lower:   strings.ReplaceAll(utils.ToSnakeCase(schema.Name), "_", " "),
+		Lowers:  strings.ReplaceAll(utils.ToSnakeCase(utils.ToPlural(schema.Name)), "_", " "),
+	}
+
+	type crudFile struct {
+		path string
+		file *jen.File
+	}
+	var files []crudFile
+
+	usecases := map[string]*jen.File{
+		m.listOp():   generateListUsecase(m),
+		m.getOp():    generateGetUsecase(m),
+		m.createOp(): generateCreateUsecase(m),
+		m.updateOp(): generateUpdateUsecase(m),
+		m.deleteOp(): generateDeleteUsecase(m),
+	}
+	handlers := map[string]*jen.File{
+		m.listOp():   generateListHandler(m),
+		m.getOp():    generateGetHandler(m),
+		m.createOp(): generateCreateHandler(m),
+		m.updateOp(): generateUpdateHandler(m),
+		m.deleteOp(): generateDeleteHandler(m),
+	}
+
+	for _, op := range m.ops() {
+		files = append(files, crudFile{filepath.Join(usecaseDir(), op+".go"), usecases[op]})
+	}
+	for _, op := range m.ops() {
+		files = append(files, crudFile{filepath.Join(serviceRootDir(), m.Group, op+".go"), generateCRUDService(m, op)})
+	}
+	files = append(files, crudFile{filepath.Join(routeDir(), m.Group, m.Group+"_response.go"), generateCRUDResponse(m)})
+	for _, op := range m.ops() {
+		files = append(files, crudFile{filepath.Join(routeDir(), m.Group, utils.ToSnakeCase(op)+".go"), handlers[op]})
+	}
+
+	var result []GeneratedFile
+	for _, f := range files {
+		file, err := renderFile(p, f.file, f.path)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
+
+// goType returns the Go type of a schema type name such as "time.Time".
+func goType(name string) jen.Code {
+	switch name {
+	case "time.Time":
+		return jen.Qual("time", "Time")
+	case "uuid.UUID":
+		return jen.Qual("github.com/google/uuid", "UUID")
+	}
+	if elem, ok := strings.CutPrefix(name, "[]"); ok {
+		return jen.Index().Id(elem)
+	}
+	return jen.Id(name)
+}
+
+// isEnum reports whether a field holds an ent enum, whose Go type is
+// generated into the entity package.
+func isEnum(field entschema.Field) bool {
+	return field.Kind == "Enum"
+}
+
+// fieldValue converts an input value to the type the ent setter takes.
+func (m crudModel) fieldValue(field entschema.Field, value *jen.Statement) jen.Code {
+	if isEnum(field) {
+		return jen.Qual(m.entPath(), entschema.GoName(field.Name)).Call(value)
+	}
+	return value
+}
+
+// idType returns the Go type of the entity's ID.
+func (m crudModel) idType() jen.Code {
+	return goType(m.schema.IDType)
+}
+
+// edges returns the unique edges without a foreign key field, which are set
+// through the builder's Set<Edge>ID.
+func (m crudModel) edges() []crudEdge {
+	var edges []crudEdge
+	for _, edge := range m.schema.Edges {
+		if !edge.Unique || edge.Field != "" {
+			continue
+		}
+		idType := "int"
+		if target, ok := entschema.Find(m.schemas, edge.Type); ok {
+			idType = target.IDType
+		}
+		edges = append(edges, crudEdge{
+			Field:  entschema.GoName(edge.Name) + "ID",
+			JSON:   edge.Name + "_id",
+			IDType: idType,
+			Create: edge.Required,
+		})
+	}
+	return edges
+}
+
+// requiredOnCreate reports whether a field must be given on create.
+func requiredOnCreate(field entschema.Field) bool {
+	return !field.Optional && !field.Default
+}
+
+// createFields returns the fields that can be given on create.
+func (m crudModel) createFields() []entschema.Field {
+	var fields []entschema.Field
+	for _, field := range m.schema.Fields {
+		if !field.Auto {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// updatableFields returns the fields that can be changed after create.
+func (m crudModel) updatableFields() []entschema.Field {
+	var fields []entschema.Field
+	for _, field := range m.createFields() {
+		if !field.Immutable {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// responseFields returns the fields exposed over HTTP.
+func (m crudModel) responseFields() []entschema.Field {
+	var fields []entschema.Field
+	for _, field := range m.schema.Fields {
+		if !field.Sensitive {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// filterFields returns the fields the list operation filters on: text
+// fields match by substring and enums by value.
+func (m crudModel) filterFields() []entschema.Field {
+	var fields []entschema.Field
+	for _, field := range m.responseFields() {
+		if field.Kind == "String" || field.Kind == "Text" || isEnum(field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// sortFields returns the columns the list operation sorts by, besides the ID.
+func (m crudModel) sortFields() []entschema.Field {
+	var fields []entschema.Field
+	for _, field := range m.responseFields() {
+		if !strings.HasPrefix(field.GoType, "[]") {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// fieldInputs returns the struct fields of create or update input, where
+// pointers mark values that may be omitted.
+func (m crudModel) fieldInputs(fields []entschema.Field, edges []crudEdge, update bool, tags func(name string, optional bool, field *entschema.Field) map[string]string) []jen.Code {
+	var code []jen.Code
+	for i, field := range fields {
+		optional := update || !requiredOnCreate(field)
+		typ := jen.Add(goType(field.GoType))
+		if optional {
+			typ = jen.Op("*").Add(goType(field.GoType))
+		}
+		code = append(code, jen.Id(entschema.GoName(field.Name)).Add(typ).Tag(tags(field.Name, optional, &fields[i])))
+	}
+	for _, edge := range edges {
+		optional := update || !edge.Create
+		typ := jen.Add(goType(edge.IDType))
+		if optional {
+			typ = jen.Op("*").Add(goType(edge.IDType))
+		}
+		code = append(code, jen.Id(edge.Field).Add(typ).Tag(tags(edge.JSON, optional, nil)))
+	}
+	return code
+}
+
+// usecaseInputTags tags usecase input fields for the validator.
+func usecaseInputTags(_ string, optional bool, field *entschema.Field) map[string]string {
+	if !optional && field != nil && field.GoType == "string" {
+		return map[string]string{"validate": "required"}
+	}
+	return nil
+}
+
+// setters emits builder calls applying create or update input to the
+// builder variable.
+func (m crudModel) setters(builder string, fields []entschema.Field, edges []crudEdge, update bool) []jen.Code {
+	var code []jen.Code
+	for _, field := range fields {
+		name := entschema.GoName(field.Name)
+		if update || !requiredOnCreate(field) {
+			code = append(code, jen.If(jen.Id("input").Dot(name).Op("!=").Nil()).Block(
+				jen.Id(builder).Dot("Set"+name).Call(m.fieldValue(field, jen.Op("*").Id("input").Dot(name))),
+			))
+			continue
+		}
+		code = append(code, jen.Id(builder).Dot("Set"+name).Call(m.fieldValue(field, jen.Id("input").Dot(name))))
+	}
+	for _, edge := range edges {
+		if update || !edge.Create {
+			code = append(code, jen.If(jen.Id("input").Dot(edge.Field).Op("!=").Nil()).Block(
+				jen.Id(builder).Dot("Set"+edge.Field).Call(jen.Op("*").Id("input").Dot(edge.Field)),
+			))
+			continue
+		}
+		code = append(code, jen.Id(builder).Dot("Set"+edge.Field).Call(jen.Id("input").Dot(edge.Field)))
+	}
+	return code
+}
+
+// crudUsecaseFile lays out a CRUD usecase like the usecase scaffold, with the
+// given input and output fields and Process body.
+func crudUsecaseFile(m crudModel, name string, input, output, process []jen.Code) *jen.File {
+	f := jen.NewFile("usecase")
+	f.ImportAlias(m.module+"/internal/core/domain", "domain_entries")
+	receiver := utils.ToCamelCase(name)
+	validatorPath := m.module + "/internal/pkg/validator"
+	ssePath := m.module + "/internal/infrastructure/sse"
+	domainPath := m.module + "/internal/core/domain"
+
+	f.Type().Id(name + "Input").Struct(input...)
+	f.Type().Id(name + "Output").Struct(output...)
+
+	f.Type().Id(name).Qual(m.modelPath(), "Usecase").Types(jen.Id(name+"Input"), jen.Id(name+"Output"))
+
+	f.Type().Id(receiver).Struct(
+		jen.Id("client").Op("*").Qual(m.dbPath(), "Client"),
+		jen.Id("domain").Op("*").Qual(domainPath, "Domain"),
+		jen.Id("validator").Qual(validatorPath, "Validator"),
+		jen.Id("sse").Op("*").Qual(ssePath, "Manager"),
+	)
+
+	f.Func().Id("New"+name).Params(
+		jen.Id("client").Op("*").Qual(m.dbPath(), "Client"),
+		jen.Id("domain").Op("*").Qual(domainPath, "Domain"),
+		jen.Id("validator").Qual(validatorPath, "Validator"),
+		jen.Id("sse").Op("*").Qual(ssePath, "Manager"),
+	).Id(name).Block(
+		jen.Return(jen.Op("&").Id(receiver).Values(jen.Dict{
+			jen.Id("client"):    jen.Id("client"),
+			jen.Id("domain"):    jen.Id("domain"),
+			jen.Id("validator"): jen.Id("validator"),
+			jen.Id("sse"):       jen.Id("sse"),
+		})),
+	)
+
+	f.Func().Params(jen.Id("uc").Op("*").Id(receiver)).Id("Validate").Params(
+		jen.Id("input").Id(name + "Input"),
+	).Error().Block(
+		jen.Return(jen.Id("uc").Dot("validator").Dot("Validate").Call(jen.Id("input"))),
+	)
+
+	f.Func().Params(jen.Id("uc").Op("*").Id(receiver)).Id("Execute").Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("input").Id(name+"Input"),
+	).Params(jen.Op("*").Id(name+"Output"), jen.Error()).Block(
+		jen.Id("log").Op(":=").Qual(m.module+"/internal/pkg/logger", "Get").Call(),
+		jen.Line(),
+		jen.If(jen.Id("err").Op(":=").Id("uc").Dot("Validate").Call(jen.Id("input")), jen.Id("err").Op("!=").Nil()).Block(
+			jen.Return(jen.Nil(), jen.Id("err")),
+		),
+		jen.Line(),
+		jen.List(jen.Id("res"), jen.Id("err")).Op(":=").Id("uc").Dot("Process").Call(jen.Id("ctx"), jen.Id("input")),
+		jen.If(jen.Id("err").Op("!=").Nil()).Block(
+			jen.Id("log").Dot("Error").Call().
+				Dot("Str").Call(jen.Lit("usecase"), jen.Lit(name)).
+				Dot("Str").Call(jen.Lit("error"), jen.Id("err").Dot("Error").Call()).
+				Dot("Msg").Call(jen.Lit("Failed to process "+name)),
+			jen.Return(jen.Nil(), jen.Id("err")),
+		),
+		jen.Line(),
+		jen.If(jen.Id("err").Op(":=").Id("uc").Dot("Observer").Call(jen.Id("ctx"), jen.Id("input")), jen.Id("err").Op("!=").Nil()).Block(
+			jen.Id("log").Dot("Printf").Call(jen.Lit("Observer usecase '"+name+"' error: %s"), jen.Id("err").Dot("Error").Call()),
+		),
+		jen.Line(),
+		jen.Return(jen.Id("res"), jen.Nil()),
+	)
+
+	f.Func().Params(jen.Id("uc").Op("*").Id(receiver)).Id("Observer").Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("input").Id(name+"Input"),
+	).Error().Block(
+		jen.Return(jen.Nil()),
+	)
+
+	f.Func().Params(jen.Id("uc").Op("*").Id(receiver)).Id("SendEvent").Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("input").Id(name+"Input"),
+		jen.Id("output").Id(name+"Output"),
+	).Error().Block(
+		jen.Return(jen.Nil()),
+	)
+
+	f.Func().Params(jen.Id("uc").Op("*").Id(receiver)).Id("Process").Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("input").Id(name+"Input"),
+	).Params(jen.Op("*").Id(name+"Output"), jen.Error()).Block(process...)
+
+	return f
+}
+
+// entityOutput is the output of usecases returning a single entity.
+func (m crudModel) entityOutput() []jen.Code {
+	return []jen.Code{jen.Id(m.Name).Op("*").Qual(m.dbPath(), m.Name)}
+}
+
+// returnEntity emits the tail of a Process body that saved entity, err.
+func (m crudModel) returnEntity(op string) []jen.Code {
+	return []jen.Code{
+		jen.If(jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
+		jen.Return(jen.Op("&").Id(op+"Output").Values(jen.Dict{jen.Id(m.Name): jen.Id("entity")}), jen.Nil()),
+	}
+}
+
+func generateListUsecase(m crudModel) *jen.File {
+	op := m.listOp()
+	sortColumns := utils.ToCamelCase(op) + "SortColumns"
+
+	input := []jen.Code{
+		jen.Id("Page").Int().Comment("1-based, defaults to 1"),
+		jen.Id("PerPage").Int().Comment("Defaults to 20"),
+		jen.Id("Sort").String().Comment("Column to sort by, defaults to id"),
+		jen.Id("Order").String().Comment("\"asc\" (default) or \"desc\""),
+	}
+	for _, field := range m.filterFields() {
+		input = append(input, jen.Id(entschema.GoName(field.Name)).String())
+	}
+
+	process := []jen.Code{
+		jen.If(jen.Id("input").Dot("Page").Op("<").Lit(1)).Block(jen.Id("input").Dot("Page").Op("=").Lit(1)),
+		jen.If(jen.Id("input").Dot("PerPage").Op("<").Lit(1)).Block(jen.Id("input").Dot("PerPage").Op("=").Lit(20)),
+		jen.If(jen.Id("input").Dot("Sort").Op("==").Lit("")).Block(jen.Id("input").Dot("Sort").Op("=").Qual(m.entPath(), "FieldID")),
+		jen.If(jen.Op("!").Qual("slices", "Contains").Call(jen.Id(sortColumns), jen.Id("input").Dot("Sort"))).Block(
+			jen.Return(jen.Nil(), jen.Qual("fmt", "Errorf").Call(jen.Lit("cannot sort "+m.Lowers+" by %q"), jen.Id("input").Dot("Sort"))),
+		),
+		jen.Line(),
+		jen.Id("query").Op(":=").Id("uc").Dot("client").Dot(m.Name).Dot("Query").Call(),
+	}
+	for _, field := range m.filterFields() {
+		name := entschema.GoName(field.Name)
+		predicate := jen.Qual(m.entPath(), name+"Contains").Call(jen.Id("input").Dot(name))
+		if isEnum(field) {
+			predicate = jen.Qual(m.entPath(), name+"EQ").Call(m.fieldValue(field, jen.Id("input").Dot(name)))
+		}
+		process = append(process, jen.If(jen.Id("input").Dot(name).Op("!=").Lit("")).Block(
+			jen.Id("query").Op("=").Id("query").Dot("Where").Call(predicate),
+		))
+	}
+	process = append(process,
+		jen.Line(),
+		jen.List(jen.Id("total"), jen.Id("err")).Op(":=").Id("query").Dot("Clone").Call().Dot("Count").Call(jen.Id("ctx")),
+		jen.If(jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
+		jen.Line(),
+		jen.Id("order").Op(":=").Qual(m.dbPath(), "Asc").Call(jen.Id("input").Dot("Sort")),
+		jen.If(jen.Id("input").Dot("Order").Op("==").Lit("desc")).Block(
+			jen.Id("order").Op("=").Qual(m.dbPath(), "Desc").Call(jen.Id("input").Dot("Sort")),
+		),
+		jen.List(jen.Id("items"), jen.Id("err")).Op(":=").Id("query").
+			Dot("Order").Call(jen.Id("order")).
+			Dot("Limit").Call(jen.Id("input").Dot("PerPage")).
+			Dot("Offset").Call(jen.Parens(jen.Id("input").Dot("Page").Op("-").Lit(1)).Op("*").Id("input").Dot("PerPage")).
+			Dot("All").Call(jen.Id("ctx")),
+		jen.If(jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
+		jen.Line(),
+		jen.Return(jen.Op("&").Id(op+"Output").Values(jen.Dict{
+			jen.Id("Items"):   jen.Id("items"),
+			jen.Id("Total"):   jen.Id("total"),
+			jen.Id("Page"):    jen.Id("input").Dot("Page"),
+			jen.Id("PerPage"): jen.Id("input").Dot("PerPage"),
+		}), jen.Nil()),
+	)
+
+	output := []jen.Code{
+		jen.Id("Items").Index().Op("*").Qual(m.dbPath(), m.Name),
+		jen.Id("Total").Int(),
+		jen.Id("Page").Int(),
+		jen.Id("PerPage").Int(),
+	}
+
+	f := crudUsecaseFile(m, op, input, output, process)
+
+	columns := []jen.Code{jen.Qual(m.entPath(), "FieldID")}
+	for _, field := range m.sortFields() {
+		columns = append(columns, jen.Qual(m.entPath(), "Field"+entschema.GoName(field.Name)))
+	}
+	f.Comment(fmt.Sprintf("%s are the columns %s can sort by.", sortColumns, op))
+	f.Var().Id(sortColumns).Op("=").Index().String().Values(columns...)
+	return f
+}
+
+func generateGetUsecase(m crudModel) *jen.File {
+	op := m.getOp()
+	process := append([]jen.Code{
+		jen.List(jen.Id("entity"), jen.Id("err")).Op(":=").Id("uc").Dot("client").Dot(m.Name).Dot("Get").Call(jen.Id("ctx"), jen.Id("input").Dot("ID")),
+	}, m.returnEntity(op)...)
+	return crudUsecaseFile(m, op, []jen.Code{jen.Id("ID").Add(m.idType())}, m.entityOutput(), process)
+}
+
+func generateCreateUsecase(m crudModel) *jen.File {
+	op := m.createOp()
+	edges := m.edges()
+	input := m.fieldInputs(m.createFields(), edges, false, usecaseInputTags)
+
+	process := []jen.Code{jen.Id("create").Op(":=").Id("uc").Dot("client").Dot(m.Name).Dot("Create").Call()}
+	process = append(process, m.setters("create", m.createFields(), edges, false)...)
+	process = append(process,
+		jen.Line(),
+		jen.List(jen.Id("entity"), jen.Id("err")).Op(":=").Id("create").Dot("Save").Call(jen.Id("ctx")),
+	)
+	process = append(process, m.returnEntity(op)...)
+	return crudUsecaseFile(m, op, input, m.entityOutput(), process)
+}
+
+func generateUpdateUsecase(m crudModel) *jen.File {
+	op := m.updateOp()
+	fields := m.updatableFields()
+	edges := m.edges()
+	input := append([]jen.Code{jen.Id("ID").Add(m.idType())}, m.fieldInputs(fields, edges, true, usecaseInputTags)...)
+
+	process := []jen.Code{jen.Id("update").Op(":=").Id("uc").Dot("client").Dot(m.Name).Dot("UpdateOneID").Call(jen.Id("input").Dot("ID"))}
+	process = append(process, m.setters("update", fields, edges, true)...)
+	process = append(process,
+		jen.Line(),
+		jen.List(jen.Id("entity"), jen.Id("err")).Op(":=").Id("update").Dot("Save").Call(jen.Id("ctx")),
+	)
+	process = append(process, m.returnEntity(op)...)
+	return crudUsecaseFile(m, op, input, m.entityOutput(), process)
+}
+
+func generateDeleteUsecase(m crudModel) *jen.File {
+	op := m.deleteOp()
+	process := []jen.Code{
+		jen.If(
+			jen.Id("err").Op(":=").Id("uc").Dot("client").Dot(m.Name).Dot("DeleteOneID").Call(jen.Id("input").Dot("ID")).Dot("Exec").Call(jen.Id("ctx")),
+			jen.Id("err").Op("!=").Nil(),
+		).Block(jen.Return(jen.Nil(), jen.Id("err"))),
+		jen.Return(jen.Op("&").Id(op+"Output").Values(), jen.Nil()),
+	}
+	return crudUsecaseFile(m, op, []jen.Code{jen.Id("ID").Add(m.idType())}, nil, process)
+}
+
+// generateCRUDService renders a service that exposes a CRUD usecase, sharing
+// its input and output types.
+func generateCRUDService(m crudModel, name string) *jen.File {
+	f := jen.NewFile(m.Group + "_service")
+	f.ImportAlias(m.module+"/internal/core/domain", "domain_entries")
+
+	f.Type().Id(name+"Input").Op("=").Qual(m.usecasePath(), name+"Input")
+	f.Type().Id(name+"Output").Op("=").Qual(m.usecasePath(), name+"Output")
+
+	writeService(f, m.module, name, []jen.Code{
+		jen.Return(jen.Id("s").Dot("usecase").Dot(name).Dot("Execute").Call(jen.Id("ctx"), jen.Id("input"))),
+	})
+
+	return f
+}
+
+// generateCRUDResponse renders the response body shared by the CRUD handlers
+// and the mapping of ent errors to HTTP errors. Sensitive fields are left out.
+func generateCRUDResponse(m crudModel) *jen.File {
+	f := jen.NewFile(m.Group + "_handler")
+	f.ImportName(humaPath, "huma")
+	response := m.Name + "Response"
+
+	fields := []jen.Code{jen.Id("ID").Add(m.idType()).Tag(map[string]string{"json": "id"})}
+	values := jen.Dict{jen.Id("ID"): jen.Id("e").Dot("ID")}
+	for _, field := range m.responseFields() {
+		name := entschema.GoName(field.Name)
+		typ := jen.Add(goType(field.GoType))
+		tag := field.Name
+		if field.Nillable {
+			typ = jen.Op("*").Add(goType(field.GoType))
+			tag += ",omitempty"
+		}
+		fields = append(fields, jen.Id(name).Add(typ).Tag(map[string]string{"json": tag}))
+
+		value := jen.Id("e").Dot(name)
+		switch {
+		case isEnum(field) && field.Nillable:
+			value = jen.Parens(jen.Op("*").String()).Call(value)
+		case isEnum(field):
+			value = jen.String().Call(value)
+		}
+		values[jen.Id(name)] = value
+	}
+
+	f.Commentf("%s is the %s returned by the %s handlers.", response, m.Lower, m.Lower)
+	f.Type().Id(response).Struct(fields...)
+
+	f.Func().Id("new" + response).Params(jen.Id("e").Op("*").Qual(m.dbPath(), m.Name)).Id(response).Block(
+		jen.Return(jen.Id(response).Values(values)),
+	)
+
+	f.Commentf("%sError maps ent errors to HTTP errors.", utils.ToCamelCase(m.Name))
+	f.Func().Id(utils.ToCamelCase(m.Name)+"Error").Params(jen.Id("err").Error()).Error().Block(
+		jen.Switch().Block(
+			jen.Case(jen.Qual(m.dbPath(), "IsNotFound").Call(jen.Id("err"))).Block(
+				jen.Return(jen.Qual(humaPath, "Error404NotFound").Call(jen.Lit(m.Lower+" not found"))),
+			),
+			jen.Case(jen.Qual(m.dbPath(), "IsConstraintError").Call(jen.Id("err"))).Block(
+				jen.Return(jen.Qual(humaPath, "Error409Conflict").Call(jen.Id("err").Dot("Error").Call())),
+			),
+			jen.Case(jen.Qual(m.dbPath(), "IsValidationError").Call(jen.Id("err"))).Block(
+				jen.Return(jen.Qual(humaPath, "Error422UnprocessableEntity").Call(jen.Id("err").Dot("Error").Call())),
+			),
+		),
+		jen.Return(jen.Id("err")),
+	)
+
+	return f
+}
+
+const humaPath = "github.com/danielgtaylor/huma/v2"
+
+// crudHandler describes one CRUD HTTP handler.
+type crudHandler struct {
+	Name    string // Operation e.g. "GetUser"
+	Method  string // e.g. "GET"
+	Path    string // e.g. "/users/{id}"
+	Summary string
+	Input   []jen.Code
+	Data    jen.Code // Response data type
+	Body    []jen.Code
+}
+
+// crudHandlerFile lays out a CRUD handler like the handler scaffold.
+func crudHandlerFile(m crudModel, h crudHandler) *jen.File {
+	f := jen.NewFile(m.Group + "_handler")
+	f.ImportName(humaPath, "huma")
+	f.ImportAlias(m.servicePath(), m.Group+"_service")
+
+	f.Type().Id(h.Name + "Input").Struct(h.Input...)
+	writeHandler(f, m.module, handlerRoute{
+		Name:        h.Name,
+		Method:      h.Method,
+		Path:        h.Path,
+		Summary:     h.Summary,
+		Description: h.Summary + ".",
+		Tag:         m.Plural,
+		Data:        h.Data,
+		Body:        h.Body,
+	})
+
+	return f
+}
+
+// resourcePath is the collection path of the model e.g. "/blog-posts".
+func (m crudModel) resourcePath() string {
+	return "/" + utils.ToKebabCase(m.Plural)
+}
+
+// idParam returns the path parameter field of the entity ID. UUIDs are taken
+// as strings and parsed by idStatements.
+func (m crudModel) idParam() jen.Code {
+	tags := map[string]string{"path": "id", "doc": "ID of the " + m.Lower}
+	if m.schema.IDType == "uuid.UUID" {
+		tags["format"] = "uuid"
+		return jen.Id("ID").String().Tag(tags)
+	}
+	return jen.Id("ID").Add(m.idType()).Tag(tags)
+}
+
+// idStatements declares id from the path parameter.
+func (m crudModel) idStatements() []jen.Code {
+	if m.schema.IDType != "uuid.UUID" {
+		return []jen.Code{jen.Id("id").Op(":=").Id("input").Dot("ID")}
+	}
+	return []jen.Code{
+		jen.List(jen.Id("id"), jen.Id("err")).Op(":=").Qual("github.com/google/uuid", "Parse").Call(jen.Id("input").Dot("ID")),
+		jen.If(jen.Id("err").Op("!=").Nil()).Block(
+			jen.Return(jen.Nil(), jen.Qual(humaPath, "Error422UnprocessableEntity").Call(jen.Lit("invalid "+m.Lower+" id"))),
+		),
+	}
+}
+
+// serviceExecute calls a CRUD service through the handler's Services.
+func (m crudModel) serviceExecute(op string, input jen.Code) *jen.Statement {
+	return jen.Id("h").Dot("service").Dot(utils.ToPascalCase(m.Group)).Dot(op).Dot("Execute").Call(jen.Id("ctx"), input)
+}
+
+// serviceCall calls a CRUD service into res and maps its error.
+func (m crudModel) serviceCall(op string, input jen.Code) []jen.Code {
+	return []jen.Code{
+		jen.List(jen.Id("res"), jen.Id("err")).Op(":=").Add(m.serviceExecute(op, input)),
+		jen.If(jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id(utils.ToCamelCase(m.Name)+"Error").Call(jen.Id("err")))),
+	}
+}
+
+// payloadTags tags request body fields for huma.
+func payloadTags(name string, optional bool, field *entschema.Field) map[string]string {
+	tags := map[string]string{"json": name}
+	if optional {
+		tags["json"] += ",omitempty"
+	}
+	if field != nil && len(field.EnumValues) > 0 {
+		tags["enum"] = strings.Join(field.EnumValues, ",")
+	}
+	return tags
+}
+
+// payloadValues copies the fields of a request body into a service input.
+func payloadValues(fields []entschema.Field, edges []crudEdge, values jen.Dict) jen.Dict {
+	for _, field := range fields {
+		name := entschema.GoName(field.Name)
+		values[jen.Id(name)] = jen.Id("input").Dot("Body").Dot(name)
+	}
+	for _, edge := range edges {
+		values[jen.Id(edge.Field)] = jen.Id("input").Dot("Body").Dot(edge.Field)
+	}
+	return values
+}
+
+func generateListHandler(m crudModel) *jen.File {
+	op := m.listOp()
+	data := op + "Data"
+
+	var sortValues []string
+	sortValues = append(sortValues, "id")
+	for _, field := range m.sortFields() {
+		sortValues = append(sortValues, field.Name)
+	}
+
+	input := []jen.Code{
+		jen.Id("Page").Int().Tag(map[string]string{"query": "page", "default": "1", "minimum": "1", "doc": "Page number"}),
+		jen.Id("PerPage").Int().Tag(map[string]string{"query": "per_page", "default": "20", "minimum": "1", "maximum": "100", "doc": "Items per page"}),
+		jen.Id("Sort").String().Tag(map[string]string{"query": "sort", "default": "id", "enum": strings.Join(sortValues, ","), "doc": "Field to sort by"}),
+		jen.Id("Order").String().Tag(map[string]string{"query": "order", "default": "asc", "enum": "asc,desc", "doc": "Sort order"}),
+	}
+	values := jen.Dict{
+		jen.Id("Page"):    jen.Id("input").Dot("Page"),
+		jen.Id("PerPage"): jen.Id("input").Dot("PerPage"),
+		jen.Id("Sort"):    jen.Id("input").Dot("Sort"),
+		jen.Id("Order"):   jen.Id("input").Dot("Order"),
+	}
+	for _, field := range m.filterFields() {
+		name := entschema.GoName(field.Name)
+		tags := map[string]string{"query": field.Name, "doc": "Filter by " + strings.ReplaceAll(field.Name, "_", " ") + " containing the value"}
+		if isEnum(field) {
+			tags["doc"] = "Filter by " + strings.ReplaceAll(field.Name, "_", " ")
+			tags["enum"] = strings.Join(field.EnumValues, ",")
+		}
+		input = append(input, jen.Id(name).String().Tag(tags))
+		values[jen.Id(name)] = jen.Id("input").Dot(name)
+	}
+
+	body := m.serviceCall(op, jen.Qual(m.servicePath(), op+"Input").Values(values))
+	body = append(body,
+		jen.Line(),
+		jen.Id("items").Op(":=").Make(jen.Index().Id(m.Name+"Response"), jen.Lit(0), jen.Len(jen.Id("res").Dot("Items"))),
+		jen.For(jen.List(jen.Id("_"), jen.Id("item")).Op(":=").Range().Id("res").Dot("Items")).Block(
+			jen.Id("items").Op("=").Append(jen.Id("items"), jen.Id("new"+m.Name+"Response").Call(jen.Id("item"))),
+		),
+		jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id(data).Values(jen.Dict{
+			jen.Id("Items"):   jen.Id("items"),
+			jen.Id("Total"):   jen.Id("res").Dot("Total"),
+			jen.Id("Page"):    jen.Id("res").Dot("Page"),
+			jen.Id("PerPage"): jen.Id("res").Dot("PerPage"),
+		})), jen.Nil()),
+	)
+
+	f := crudHandlerFile(m, crudHandler{
+		Name:    op,
+		Method:  "GET",
+		Path:    m.resourcePath(),
+		Summary: "List " + m.Lowers,
+		Input:   input,
+		Data:    jen.Id(data),
+		Body:    body,
+	})
+	f.Type().Id(data).Struct(
+		jen.Id("Items").Index().Id(m.Name+"Response").Tag(map[string]string{"json": "items"}),
+		jen.Id("Total").Int().Tag(map[string]string{"json": "total"}),
+		jen.Id("Page").Int().Tag(map[string]string{"json": "page"}),
+		jen.Id("PerPage").Int().Tag(map[string]string{"json": "per_page"}),
+	)
+	return f
+}
+
+func generateGetHandler(m crudModel) *jen.File {
+	op := m.getOp()
+	body := m.idStatements()
+	body = append(body, m.serviceCall(op, jen.Qual(m.servicePath(), op+"Input").Values(jen.Dict{jen.Id("ID"): jen.Id("id")}))...)
+	body = append(body,
+		jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id("new"+m.Name+"Response").Call(jen.Id("res").Dot(m.Name))), jen.Nil()),
+	)
+
+	return crudHandlerFile(m, crudHandler{
+		Name:    op,
+		Method:  "GET",
+		Path:    m.resourcePath() + "/{id}",
+		Summary: "Get a " + m.Lower,
+		Input:   []jen.Code{m.idParam()},
+		Data:    jen.Id(m.Name + "Response"),
+		Body:    body,
+	})
+}
+
+func generateCreateHandler(m crudModel) *jen.File {
+	op := m.createOp()
+	payload := op + "Payload"
+	edges := m.edges()
+
+	body := m.serviceCall(op, jen.Qual(m.servicePath(), op+"Input").Values(payloadValues(m.createFields(), edges, jen.Dict{})))
+	body = append(body,
+		jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id("new"+m.Name+"Response").Call(jen.Id("res").Dot(m.Name))), jen.Nil()),
+	)
+
+	f := crudHandlerFile(m, crudHandler{
+		Name:    op,
+		Method:  "POST",
+		Path:    m.resourcePath(),
+		Summary: "Create a " + m.Lower,
+		Input: []jen.Code{
+			jen.Id("Body").Id(payload).Tag(map[string]string{"json": "body", "contentType": "application/json"}),
+		},
+		Data: jen.Id(m.Name + "Response"),
+		Body: body,
+	})
+	f.Type().Id(payload).Struct(m.fieldInputs(m.createFields(), edges, false, payloadTags)...)
+	return f
+}
+
+func generateUpdateHandler(m crudModel) *jen.File {
+	op := m.updateOp()
+	payload := op + "Payload"
+	fields := m.updatableFields()
+	edges := m.edges()
+
+	body := m.idStatements()
+	body = append(body, m.serviceCall(op, jen.Qual(m.servicePath(), op+"Input").Values(payloadValues(fields, edges, jen.Dict{jen.Id("ID"): jen.Id("id")})))...)
+	body = append(body,
+		jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id("new"+m.Name+"Response").Call(jen.Id("res").Dot(m.Name))), jen.Nil()),
+	)
+
+	f := crudHandlerFile(m, crudHandler{
+		Name:    op,
+		Method:  "PATCH",
+		Path:    m.resourcePath() + "/{id}",
+		Summary: "Update a " + m.Lower,
+		Input: []jen.Code{
+			m.idParam(),
+			jen.Id("Body").Id(payload).Tag(map[string]string{"json": "body", "contentType": "application/json"}),
+		},
+		Data: jen.Id(m.Name + "Response"),
+		Body: body,
+	})
+	f.Type().Id(payload).Struct(m.fieldInputs(fields, edges, true, payloadTags)...)
+	return f
+}
+
+func generateDeleteHandler(m crudModel) *jen.File {
+	op := m.deleteOp()
+	data := op + "Data"
+
+	body := m.idStatements()
+	body = append(body,
+		jen.If(
+			jen.List(jen.Id("_"), jen.Id("err")).Op(":=").Add(m.serviceExecute(op, jen.Qual(m.servicePath(), op+"Input").Values(jen.Dict{jen.Id("ID"): jen.Id("id")}))),
+			jen.Id("err").Op("!=").Nil(),
+		).Block(jen.Return(jen.Nil(), jen.Id(utils.ToCamelCase(m.Name)+"Error").Call(jen.Id("err")))),
+		jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id(data).Values(jen.Dict{jen.Id("ID"): jen.Id("id")})), jen.Nil()),
+	)
+
+	f := crudHandlerFile(m, crudHandler{
+		Name:    op,
+		Method:  "DELETE",
+		Path:    m.resourcePath() + "/{id}",
+		Summary: "Delete a " + m.Lower,
+		Input:   []jen.Code{m.idParam()},
+		Data:    jen.Id(data),
+		Body:    body,
+	})
+	f.Type().Id(data).Struct(jen.Id("ID").Add(m.idType()).Tag(map[string]string{"json": "id"}))
+	return f
+}
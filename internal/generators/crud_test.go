@@ -0,0 +1,80 @@
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+	"github.com/alfariiizi/vandor-cli/internal/entschema"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// appProject is a project with ent schemas, the ent code generated from them
+// and the framework packages generated code builds against.
+var appProject = Project{Root: filepath.Join("testdata", "app"), Module: "example.com/app"}
+
+// inApp runs the test from appProject, for generators working on the current
+// project, and returns the overlay keeping what they write off the disk.
+func inApp(t *testing.T) *vfs.Overlay {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(appProject.Root); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(config.FileEnv, "")
+	config.Setup("", nil)
+	layout.ResetCurrent()
+	overlay := vfs.NewOverlay(vfs.OS{})
+	restore := vfs.Use(overlay)
+	t.Cleanup(func() {
+		restore()
+		_ = os.Chdir(wd)
+		config.Setup("", nil)
+		layout.ResetCurrent()
+	})
+	return overlay
+}
+
+// written returns the files an overlay holds as generated files of the
+// current project.
+func written(overlay *vfs.Overlay) []GeneratedFile {
+	var files []GeneratedFile
+	for _, change := range overlay.Changes() {
+		files = append(files, GeneratedFile{Path: change.Path, Content: change.New})
+	}
+	return files
+}
+
+func TestRenderCRUD(t *testing.T) {
+	schemas, err := entschema.Load(appProject.Path(entschema.Dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Post has an int ID, mixin timestamps, an enum, optional, nillable,
+	// sensitive and list fields and a required edge; User has a UUID ID
+	for _, name := range []string{"Post", "User"} {
+		t.Run(name, func(t *testing.T) {
+			schema, ok := entschema.Find(schemas, name)
+			if !ok {
+				t.Fatalf("no %s schema", name)
+			}
+			files, err := RenderCRUD(appProject, schema, schemas)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			if len(files) != 16 {
+				t.Errorf("rendered %d files, want 5 usecases, 5 services, 5 handlers and the response", len(files))
+			}
+
+			compareGolden(t, "crud", files)
+			typeCheckSynced(t, appProject, files)
+		})
+	}
+}
@@ -2,13 +2,14 @@ package generators
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
 
+	"github.com/alfariiizi/vandor-cli/internal/naming"
 	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
 type DomainData struct {
@@ -19,12 +20,11 @@ type DomainData struct {
 }
 
 func GenerateDomain(domainName string) error {
-	if domainName == "" {
-		return fmt.Errorf("domain name cannot be empty")
+	name, err := naming.Component("domain", domainName)
+	if err != nil {
+		return err
 	}
-
-	// Capitalize first letter
-	domainName = strings.ToUpper(domainName[:1]) + domainName[1:]
+	domainName = name.Pascal
 
 	data := DomainData{
 		ModuleName:  utils.GetModuleName(),
@@ -33,24 +33,20 @@ func GenerateDomain(domainName string) error {
 		PackageName: "domain",
 	}
 
-	// Check if domain already exists
-	domainPath := filepath.Join("internal", "core", "domain", "model", strings.ToLower(domainName)+".go")
-	if _, err := os.Stat(domainPath); err == nil {
-		return fmt.Errorf("domain %s already exists at %s", domainName, domainPath)
-	}
+	domainPath := filepath.Join(domainModelDir(), strings.ToLower(domainName)+".go")
 
 	// Generate domain file
 	f := generateDomainFile(data)
 
 	// Ensure directory exists
 	dir := filepath.Dir(domainPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := vfs.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	// Write file
-	if err := f.Save(domainPath); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+	if written, err := createFile(f, domainPath, data.ModuleName); !written || err != nil {
+		return err
 	}
 
 	fmt.Printf("Domain %s created successfully at %s\n", domainName, domainPath)
@@ -61,28 +57,28 @@ func generateDomainFile(data DomainData) *jen.File {
 	f := jen.NewFile(data.PackageName)
 
 	// Add imports
-	f.ImportName("fmt", "fmt")
-	f.ImportName(data.ModuleName+"/internal/core/domain/builder", "domain_builder")
-	f.ImportName(data.ModuleName+"/internal/infrastructure/db", "db")
+	builderPath := data.ModuleName + "/internal/core/domain/builder"
+	dbPath := data.ModuleName + "/internal/infrastructure/db"
+	f.ImportAlias(builderPath, "domain_builder")
 
 	// Generate struct
 	f.Type().Id(data.Name).Struct(
-		jen.Op("*").Qual("db", data.Name),
-		jen.Id("client").Op("*").Qual("db", "Client"),
+		jen.Op("*").Qual(dbPath, data.Name),
+		jen.Id("client").Op("*").Qual(dbPath, "Client"),
 	)
 
 	// Generate constructor function
 	f.Func().Id("New"+data.Name+"Domain").
-		Params(jen.Id("client").Op("*").Qual("db", "Client")).
-		Qual("domain_builder", "Domain").Types(
-		jen.Op("*").Qual("db", data.Name),
+		Params(jen.Id("client").Op("*").Qual(dbPath, "Client")).
+		Qual(builderPath, "Domain").Types(
+		jen.Op("*").Qual(dbPath, data.Name),
 		jen.Op("*").Id(data.Name),
 	).Block(
 		jen.Return(
-			jen.Qual("domain_builder", "NewDomain").Call(
+			jen.Qual(builderPath, "NewDomain").Call(
 				jen.Func().Params(
-					jen.Id("e").Op("*").Qual("db", data.Name),
-					jen.Id("c").Op("*").Qual("db", "Client"),
+					jen.Id("e").Op("*").Qual(dbPath, data.Name),
+					jen.Id("c").Op("*").Qual(dbPath, "Client"),
 				).Op("*").Id(data.Name).Block(
 					jen.Return(jen.Op("&").Id(data.Name).Values(
 						jen.Dict{
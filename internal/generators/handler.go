@@ -2,130 +2,319 @@ package generators
 
 import (
 	"fmt"
-	"os"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
 
+	"github.com/alfariiizi/vandor-cli/internal/entschema"
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/naming"
+	"github.com/alfariiizi/vandor-cli/internal/overwrite"
 	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
-type HandlerData struct {
-	ModuleName string // e.g., "github.com/alfariiizi/vandor-cli"
-	Name       string // e.g., "GetUser"
-	Group      string // e.g., "user"
-	GroupTitle string // e.g., "User"
-	Path       string // e.g., "users/{id}"
-	Method     string // e.g., "GET"
-	Receiver   string // e.g., "getUser"
+// HandlerSpec describes an HTTP handler: its route, the path, query and body
+// fields of its request, the fields of its response data and the service it
+// calls.
+type HandlerSpec struct {
+	Name     string // e.g., "ListUserOrders"
+	Group    string // e.g., "user"
+	Method   string // e.g., "GET"
+	Route    string // e.g., "/users/{id}/orders"; defaults to "/" + lowercased name
+	Path     []HandlerFieldSpec
+	Query    []HandlerFieldSpec
+	Body     []HandlerFieldSpec
+	Response []HandlerFieldSpec
+	Service  string // Service called as group.Name, e.g. "user.ListOrders"
 }
 
+// HandlerFieldSpec is a request or response field given as
+// name:type[:required][:default=value], e.g. "page:int:default=1".
+type HandlerFieldSpec struct {
+	Name     string
+	Type     string // Key of handlerFieldTypes
+	Required bool
+	Default  string
+}
+
+// handlerFieldTypes maps field spec types to Go types. Parameters (path and
+// query) only take the scalar ones.
+var handlerFieldTypes = map[string]string{
+	"string":  "string",
+	"int":     "int",
+	"int32":   "int32",
+	"int64":   "int64",
+	"float":   "float64",
+	"bool":    "bool",
+	"time":    "time.Time",
+	"uuid":    "uuid.UUID",
+	"strings": "[]string",
+	"ints":    "[]int",
+}
+
+// routeParam matches the {param} segments of a route pattern.
+var routeParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// ParseHandlerField parses a field spec like "page:int:default=1". The type
+// defaults to string.
+func ParseHandlerField(spec string) (HandlerFieldSpec, error) {
+	parts := strings.Split(spec, ":")
+	if parts[0] == "" {
+		return HandlerFieldSpec{}, fmt.Errorf("invalid field %q: expected name[:type][:modifier...]", spec)
+	}
+
+	field := HandlerFieldSpec{Name: parts[0], Type: "string"}
+	if len(parts) > 1 {
+		field.Type = strings.ToLower(parts[1])
+	}
+	if _, ok := handlerFieldTypes[field.Type]; !ok {
+		return HandlerFieldSpec{}, fmt.Errorf("invalid field %q: unknown type %q", spec, field.Type)
+	}
+
+	for _, modifier := range parts[min(len(parts), 2):] {
+		key, value, _ := strings.Cut(modifier, "=")
+		switch key {
+		case "required":
+			field.Required = true
+		case "default":
+			field.Default = value
+		default:
+			return HandlerFieldSpec{}, fmt.Errorf("invalid field %q: unknown modifier %q", spec, modifier)
+		}
+	}
+	return field, nil
+}
+
+// GenerateHandler creates an HTTP handler with an empty request and response
+// at "/" + the lowercased name.
 func GenerateHandler(name, group, method string) error {
-	if name == "" || group == "" || method == "" {
+	return GenerateHandlerFromSpec(HandlerSpec{Name: name, Group: group, Method: method})
+}
+
+// GenerateHandlerFromSpec creates the HTTP handler a spec describes in the
+// route group directory the handler registry discovers.
+func GenerateHandlerFromSpec(spec HandlerSpec) error {
+	if spec.Name == "" || spec.Group == "" || spec.Method == "" {
 		return fmt.Errorf("all parameters (name, group, method) are required")
 	}
 
-	// Generate path from name (convert to lowercase)
-	path := strings.ToLower(name)
+	name, err := naming.Component("handler", spec.Name)
+	if err != nil {
+		return err
+	}
+	if spec.Group, err = naming.Package("handler group", spec.Group); err != nil {
+		return err
+	}
+	spec.Name = name.Pascal
+	spec.Method = strings.ToUpper(spec.Method)
+	if spec.Route == "" {
+		spec.Route = "/" + strings.ToLower(spec.Name)
+	}
+
+	p, err := CurrentProject()
+	if err != nil {
+		return err
+	}
 
-	// Normalize inputs
-	name = strings.ToUpper(name[:1]) + name[1:]
-	method = strings.ToUpper(method)
-	group = strings.ToLower(group)
+	handlerPath := filepath.Join(routeDir(), spec.Group, utils.ToSnakeCase(spec.Name)+".go")
 
-	data := HandlerData{
-		ModuleName: utils.GetModuleName(),
-		Name:       name,
-		Group:      group,
-		GroupTitle: strings.ToUpper(group[:1]) + group[1:],
-		Path:       path,
-		Method:     method,
-		Receiver:   strings.ToLower(name[:1]) + name[1:],
+	f, err := generateHandlerFile(p, spec)
+	if err != nil {
+		return err
 	}
 
-	// Check if handler already exists
-	handlerPath := filepath.Join("internal", "delivery", "http", "handler", group, strings.ToLower(name)+".go")
-	if _, err := os.Stat(handlerPath); err == nil {
-		return fmt.Errorf("handler %s already exists at %s", name, handlerPath)
+	file, err := renderFile(p, f, handlerPath)
+	if err != nil {
+		return err
+	}
+	if err := naming.Collisions(p.Path(handlerPath), file.Content); err != nil {
+		return err
+	}
+	if ok, err := overwrite.Check(p.Path(handlerPath), file.Content); !ok || err != nil {
+		return err
+	}
+	if _, err := WriteFiles(p, []GeneratedFile{file}); err != nil {
+		return err
 	}
 
-	// Generate handler file
-	f := generateHandlerFile(data)
+	fmt.Printf("Handler %s created successfully at %s\n", spec.Name, handlerPath)
+	return nil
+}
+
+// handlerField is a field of the generated input or response struct.
+type handlerField struct {
+	HandlerFieldSpec
+	GoName string
+	Source jen.Code // Expression reading the field from the handler input
+}
 
-	// Ensure directory exists
-	dir := filepath.Dir(handlerPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+// goTypeOf returns the Go type of a field, a pointer for optional body
+// fields.
+func (f handlerField) goTypeOf(pointer bool) jen.Code {
+	typ := goType(handlerFieldTypes[f.Type])
+	if pointer {
+		return jen.Op("*").Add(typ)
 	}
+	return typ
+}
 
-	// Write file
-	if err := f.Save(handlerPath); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+func generateHandlerFile(p Project, spec HandlerSpec) (*jen.File, error) {
+	params, err := routeParams(spec)
+	if err != nil {
+		return nil, err
 	}
 
-	fmt.Printf("Handler %s created successfully at %s\n", name, handlerPath)
-	return nil
+	f := jen.NewFile(spec.Group + "_handler")
+	f.ImportName(humaPath, "huma")
+
+	// Request fields, in the order huma documents them
+	var inputs []handlerField
+	var inputFields []jen.Code
+	for _, field := range params {
+		name := entschema.GoName(field.Name)
+		tags := map[string]string{"path": field.Name}
+		inputs = append(inputs, handlerField{field, name, jen.Id("input").Dot(name)})
+		inputFields = append(inputFields, jen.Id(name).Add(goType(handlerFieldTypes[field.Type])).Tag(tags))
+	}
+	for _, field := range spec.Query {
+		name := entschema.GoName(field.Name)
+		tags := map[string]string{"query": field.Name}
+		if field.Required {
+			tags["required"] = "true"
+		}
+		if field.Default != "" {
+			tags["default"] = field.Default
+		}
+		inputs = append(inputs, handlerField{field, name, jen.Id("input").Dot(name)})
+		inputFields = append(inputFields, jen.Id(name).Add(goType(handlerFieldTypes[field.Type])).Tag(tags))
+	}
+	if len(spec.Body) > 0 {
+		var payloadFields []jen.Code
+		for _, field := range spec.Body {
+			name := entschema.GoName(field.Name)
+			hf := handlerField{field, name, jen.Id("input").Dot("Body").Dot(name)}
+			tags := map[string]string{"json": field.Name}
+			if !field.Required {
+				tags["json"] += ",omitempty"
+			}
+			if field.Default != "" {
+				tags["default"] = field.Default
+			}
+			inputs = append(inputs, hf)
+			payloadFields = append(payloadFields, jen.Id(name).Add(hf.goTypeOf(!field.Required)).Tag(tags))
+		}
+		f.Type().Id(spec.Name + "Payload").Struct(payloadFields...)
+		inputFields = append(inputFields, jen.Id("Body").Id(spec.Name+"Payload").Tag(map[string]string{
+			"json":        "body",
+			"contentType": "application/json",
+		}))
+	}
+
+	f.Comment("NOTE:")
+	f.Comment("Hint Tags for input parameters")
+	f.Comment("@ref: https://huma.rocks/features/request-inputs")
+	f.Comment("")
+	f.Comment("Tag       | Description                           | Example")
+	f.Comment("-------------------------------------------------------------------")
+	f.Comment("path      | Name of the path parameter            | path:\"thing-id\"")
+	f.Comment("query     | Name of the query string parameter    | query:\"q\"")
+	f.Comment("header    | Name of the header parameter          | header:\"Authorization\"")
+	f.Comment("cookie    | Name of the cookie parameter          | cookie:\"session\"")
+	f.Comment("required  | Mark a query/header param as required | required:\"true\"")
+	f.Line()
+
+	f.Type().Id(spec.Name + "Input").Struct(inputFields...)
+
+	var responses []handlerField
+	var dataFields []jen.Code
+	for _, field := range spec.Response {
+		name := entschema.GoName(field.Name)
+		hf := handlerField{HandlerFieldSpec: field, GoName: name}
+		tags := map[string]string{"json": field.Name}
+		if !field.Required {
+			tags["json"] += ",omitempty"
+		}
+		responses = append(responses, hf)
+		dataFields = append(dataFields, jen.Id(name).Add(hf.goTypeOf(false)).Tag(tags))
+	}
+	f.Type().Id(spec.Name + "Data").Struct(dataFields...)
+
+	body := []jen.Code{
+		jen.Comment("TODO: Implement handler logic here"),
+		jen.Line(),
+		jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id(spec.Name+"Data").Values()), jen.Nil()),
+	}
+	if spec.Service != "" {
+		body, err = serviceCallBody(p, f, spec, inputs, responses)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writeHandler(f, p.Module, handlerRoute{
+		Name:        spec.Name,
+		Method:      spec.Method,
+		Path:        spec.Route,
+		Summary:     spec.Name,
+		Description: spec.Name + " handler",
+		Tag:         utils.ToPascalCase(spec.Group),
+		Data:        jen.Id(spec.Name + "Data"),
+		Body:        body,
+	})
+	return f, nil
 }
 
-func generateHandlerFile(data HandlerData) *jen.File {
-	f := jen.NewFile(data.Group + "_handler")
-
-	// Add imports
-	f.ImportName("context", "context")
-	f.ImportName(data.ModuleName+"/internal/core/model", "model")
-	f.ImportName(data.ModuleName+"/internal/core/service", "service")
-	f.ImportName(data.ModuleName+"/internal/infrastructure/db", "db")
-	f.ImportName(data.ModuleName+"/internal/delivery/http/api", "api")
-	f.ImportName(data.ModuleName+"/internal/delivery/http/method", "method")
-	f.ImportName(data.ModuleName+"/internal/types", "types")
-	f.ImportName("github.com/danielgtaylor/huma/v2", "huma")
-
-	// Generate payload struct based on method
-	generatePayloadStruct(f, data)
-
-	// Generate input struct
-	generateInputStruct(f, data)
-
-	// Generate output types
-	f.Type().Id(data.Name+"Output").Qual("types", "OutputResponseData").Types(jen.Id(data.Name + "Data"))
-
-	f.Type().Id(data.Name+"Data").Struct(
-		jen.Comment("Example response data"),
-		jen.Id("ID").String().Tag(map[string]string{
-			"json":    "id",
-			"example": "123",
-		}),
-		jen.Id("Name").String().Tag(map[string]string{
-			"json":    "name",
-			"example": "Book",
-		}),
-		jen.Id("Description").String().Tag(map[string]string{
-			"json":    "description",
-			"example": "A great book",
-		}),
-	)
+// handlerRoute is the operation a handler registers and the body of its
+// Handler method.
+type handlerRoute struct {
+	Name        string // e.g. "GetUser"
+	Method      string // e.g. "GET"
+	Path        string // e.g. "/users/{id}"
+	Summary     string
+	Description string
+	Tag         string
+	Data        jen.Code // Response data type
+	Body        []jen.Code
+}
 
-	// Generate handler type
-	f.Type().Id(data.Name+"Handler").Qual("model", "HTTPHandler").Types(
-		jen.Id(data.Name+"Input"),
-		jen.Id(data.Name+"Output"),
+// writeHandler lays out the handler scaffold around a route: the Output and
+// Handler types, the implementation and its constructor, RegisterRoutes,
+// GenerateResponse and Handler. The caller declares the Input type.
+func writeHandler(f *jen.File, module string, r handlerRoute) {
+	receiver := utils.ToCamelCase(r.Name)
+	servicePath := importPath(module, serviceRootDir())
+	apiPath := module + "/internal/delivery/http/api"
+	methodPath := module + "/internal/delivery/http/method"
+	typesPath := module + "/internal/types"
+	modelPath := module + "/internal/core/model"
+	dbPath := module + "/internal/infrastructure/db"
+
+	f.Type().Id(r.Name+"Output").Qual(typesPath, "OutputResponseData").Types(r.Data)
+
+	f.Type().Id(r.Name+"Handler").Qual(modelPath, "HTTPHandler").Types(
+		jen.Id(r.Name+"Input"),
+		jen.Id(r.Name+"Output"),
 	)
 
-	// Generate implementation struct
-	f.Type().Id(data.Receiver).Struct(
-		jen.Id("api").Qual("huma", "API"),
-		jen.Id("service").Op("*").Qual("service", "Services"),
-		jen.Id("client").Op("*").Qual("db", "Client"),
+	f.Type().Id(receiver).Struct(
+		jen.Id("api").Qual(humaPath, "API"),
+		jen.Id("service").Op("*").Qual(servicePath, "Services"),
+		jen.Id("client").Op("*").Qual(dbPath, "Client"),
 	)
 
-	// Generate constructor
-	f.Func().Id("New"+data.Name).Params(
-		jen.Id("api").Op("*").Qual("api", "HttpApi"),
-		jen.Id("service").Op("*").Qual("service", "Services"),
-		jen.Id("client").Op("*").Qual("db", "Client"),
-	).Id(data.Name+"Handler").Block(
-		jen.Id("h").Op(":=").Op("&").Id(data.Receiver).Values(jen.Dict{
+	f.Func().Id("New"+r.Name).Params(
+		jen.Id("api").Op("*").Qual(apiPath, "HttpApi"),
+		jen.Id("service").Op("*").Qual(servicePath, "Services"),
+		jen.Id("client").Op("*").Qual(dbPath, "Client"),
+	).Id(r.Name+"Handler").Block(
+		jen.Id("h").Op(":=").Op("&").Id(receiver).Values(jen.Dict{
 			jen.Id("api"):     jen.Id("api").Dot("BaseAPI"),
 			jen.Id("service"): jen.Id("service"),
 			jen.Id("client"):  jen.Id("client"),
@@ -134,140 +323,237 @@ func generateHandlerFile(data HandlerData) *jen.File {
 		jen.Return(jen.Id("h")),
 	)
 
-	// Generate RegisterRoutes method
-	generateRegisterRoutesMethod(f, data)
+	f.Func().Params(jen.Id("h").Op("*").Id(receiver)).Id("RegisterRoutes").Params().Block(
+		jen.Id("api").Op(":=").Id("h").Dot("api"),
+		jen.Qual(methodPath, r.Method).Call(
+			jen.Id("api"),
+			jen.Lit(r.Path),
+			jen.Qual(methodPath, "Operation").Values(jen.Dict{
+				jen.Id("Summary"):     jen.Lit(r.Summary),
+				jen.Id("Description"): jen.Lit(r.Description),
+				jen.Id("Tags"):        jen.Index().String().Values(jen.Lit(r.Tag)),
+				jen.Id("BearerAuth"):  jen.Lit(false),
+			}),
+			jen.Id("h").Dot("Handler"),
+		),
+	)
 
-	// Generate GenerateResponse method
-	f.Func().Params(jen.Id("h").Op("*").Id(data.Receiver)).Id("GenerateResponse").Params(
-		jen.Id("data").Id(data.Name + "Data"),
-	).Op("*").Id(data.Name + "Output").Block(
-		jen.Return(jen.Params(jen.Op("*").Id(data.Name + "Output")).Call(
-			jen.Qual("types", "GenerateOutputResponseData").Call(jen.Id("data")),
+	f.Func().Params(jen.Id("h").Op("*").Id(receiver)).Id("GenerateResponse").Params(
+		jen.Id("data").Add(r.Data),
+	).Op("*").Id(r.Name + "Output").Block(
+		jen.Return(jen.Params(jen.Op("*").Id(r.Name + "Output")).Call(
+			jen.Qual(typesPath, "GenerateOutputResponseData").Call(jen.Id("data")),
 		)),
 	)
 
-	// Generate Handler method
-	f.Func().Params(jen.Id("h").Op("*").Id(data.Receiver)).Id("Handler").Params(
+	f.Func().Params(jen.Id("h").Op("*").Id(receiver)).Id("Handler").Params(
 		jen.Id("ctx").Qual("context", "Context"),
-		jen.Id("input").Op("*").Id(data.Name+"Input"),
-	).Params(jen.Op("*").Id(data.Name+"Output"), jen.Error()).Block(
-		jen.Comment("TODO: Implement handler logic here"),
+		jen.Id("input").Op("*").Id(r.Name+"Input"),
+	).Params(jen.Op("*").Id(r.Name+"Output"), jen.Error()).Block(r.Body...)
+}
+
+// routeParams returns the path fields of a route pattern in order, typed by
+// the spec's path fields and defaulting to string.
+func routeParams(spec HandlerSpec) ([]HandlerFieldSpec, error) {
+	if !strings.HasPrefix(spec.Route, "/") {
+		return nil, fmt.Errorf("route %q must start with /", spec.Route)
+	}
+
+	declared := map[string]HandlerFieldSpec{}
+	for _, field := range spec.Path {
+		declared[field.Name] = field
+	}
+
+	var params []HandlerFieldSpec
+	for _, match := range routeParam.FindAllStringSubmatch(spec.Route, -1) {
+		field, ok := declared[match[1]]
+		if !ok {
+			field = HandlerFieldSpec{Name: match[1], Type: "string"}
+		}
+		delete(declared, match[1])
+		params = append(params, field)
+	}
+
+	for _, field := range spec.Path {
+		if _, ok := declared[field.Name]; ok {
+			return nil, fmt.Errorf("path field %s does not appear in route %s", field.Name, spec.Route)
+		}
+	}
+	for _, field := range params {
+		if !isParamType(field.Type) {
+			return nil, fmt.Errorf("path field %s cannot be of type %s", field.Name, field.Type)
+		}
+	}
+	for _, field := range spec.Query {
+		if !isParamType(field.Type) {
+			return nil, fmt.Errorf("query field %s cannot be of type %s", field.Name, field.Type)
+		}
+	}
+	return params, nil
+}
+
+// isParamType reports whether huma can parse a field type from a path or
+// query parameter.
+func isParamType(typ string) bool {
+	switch typ {
+	case "time", "uuid", "strings", "ints":
+		return false
+	}
+	return true
+}
+
+// serviceCallBody returns a Handler body that passes the request fields to
+// the target service and its output to the response. Fields are matched by
+// name and type; the ones that do not match are listed in TODO comments.
+func serviceCallBody(p Project, f *jen.File, spec HandlerSpec, inputs, responses []handlerField) ([]jen.Code, error) {
+	group, name, ok := strings.Cut(spec.Service, ".")
+	if !ok || group == "" || name == "" {
+		return nil, fmt.Errorf("invalid service %q: expected group.Name", spec.Service)
+	}
+	group = utils.ToSnakeCase(group)
+	name = utils.ToPascalCase(name)
+
+	serviceInput, serviceOutput, err := serviceStructs(p, group, name)
+	if err != nil {
+		return nil, err
+	}
+
+	groupPath := importPath(p.Module, serviceRootDir(), group)
+	f.ImportAlias(groupPath, group+"_service")
+
+	var body []jen.Code
+	values := jen.Dict{}
+	var unmapped []string
+	for _, field := range inputs {
+		pointer := !field.Required && isBodyField(spec, field.Name)
+		if typ, ok := serviceInput[field.GoName]; ok && typ == typeString(field, pointer) {
+			values[jen.Id(field.GoName)] = field.Source
+		} else {
+			unmapped = append(unmapped, field.Name)
+		}
+	}
+	if len(unmapped) > 0 {
+		body = append(body, jen.Commentf("TODO: pass %s to %sInput", strings.Join(unmapped, ", "), name))
+	}
+
+	body = append(body,
+		jen.List(jen.Id("res"), jen.Id("err")).Op(":=").Id("h").Dot("service").Dot(utils.ToPascalCase(group)).Dot(name).Dot("Execute").Call(
+			jen.Id("ctx"),
+			jen.Qual(groupPath, name+"Input").Values(values),
+		),
+		jen.If(jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
 		jen.Line(),
-		jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id(data.Name+"Data").Values()), jen.Nil()),
 	)
 
-	return f
+	data := jen.Dict{}
+	unmapped = nil
+	for _, field := range responses {
+		if typ, ok := serviceOutput[field.GoName]; ok && typ == typeString(field, false) {
+			data[jen.Id(field.GoName)] = jen.Id("res").Dot(field.GoName)
+		} else {
+			unmapped = append(unmapped, field.Name)
+		}
+	}
+	if len(unmapped) > 0 {
+		body = append(body, jen.Commentf("TODO: set %s from %sOutput", strings.Join(unmapped, ", "), name))
+	}
+	if len(data) == 0 {
+		body = append(body, jen.Id("_").Op("=").Id("res"))
+	}
+	body = append(body, jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id(spec.Name+"Data").Values(data)), jen.Nil()))
+	return body, nil
 }
 
-func generatePayloadStruct(f *jen.File, data HandlerData) {
-	switch data.Method {
-	case "POST":
-		f.Type().Id(data.Name+"Payload").Struct(
-			jen.Comment("Example POST input"),
-			jen.Id("Name").String().Tag(map[string]string{
-				"json":     "name",
-				"doc":      "Name of the item",
-				"example":  "Book",
-				"required": "true",
-			}),
-			jen.Id("Description").String().Tag(map[string]string{
-				"json": "description",
-			}),
-		)
-	case "PUT", "PATCH":
-		f.Type().Id(data.Name+"Payload").Struct(
-			jen.Comment("Example "+data.Method+" input"),
-			jen.Id("Name").Op("*").String().Tag(map[string]string{
-				"json":    "name",
-				"doc":     "Name of the item",
-				"example": "Book",
-			}),
-			jen.Id("Description").Op("*").String().Tag(map[string]string{
-				"json": "description",
-			}),
-		)
+func isBodyField(spec HandlerSpec, name string) bool {
+	for _, field := range spec.Body {
+		if field.Name == name {
+			return true
+		}
 	}
+	return false
 }
 
-func generateInputStruct(f *jen.File, data HandlerData) {
-	structFields := []jen.Code{}
-
-	switch data.Method {
-	case "GET":
-		structFields = append(structFields,
-			jen.Comment("Example GET input"),
-			jen.Id("ID").String().Tag(map[string]string{
-				"path":    "id",
-				"doc":     "ID of the item",
-				"example": "123",
-			}),
-			jen.Id("Query").String().Tag(map[string]string{
-				"query":   "q",
-				"doc":     "Query parameter for filtering",
-				"example": "search term",
-			}),
-		)
-	case "DELETE":
-		structFields = append(structFields,
-			jen.Id("ID").String().Tag(map[string]string{
-				"path":    "id",
-				"doc":     "ID of the item to delete",
-				"example": "123",
-			}),
-		)
-	case "PUT", "PATCH":
-		structFields = append(structFields,
-			jen.Id("ID").String().Tag(map[string]string{
-				"path":    "id",
-				"doc":     "ID of the item to update",
-				"example": "123",
-			}),
-			jen.Id("Body").Id(data.Name+"Payload").Tag(map[string]string{
-				"json":        "body",
-				"contentType": "application/json",
-			}),
-		)
-	default: // POST and others
-		structFields = append(structFields,
-			jen.Comment("JSON body for "+data.Method),
-			jen.Id("Body").Id(data.Name+"Payload").Tag(map[string]string{
-				"json":        "body",
-				"contentType": "application/json",
-			}),
-		)
+// typeString renders a field's Go type as it appears in source.
+func typeString(field handlerField, pointer bool) string {
+	typ := handlerFieldTypes[field.Type]
+	if pointer {
+		return "*" + typ
 	}
+	return typ
+}
 
-	f.Comment("NOTE:")
-	f.Comment("Hint Tags for input parameters")
-	f.Comment("@ref: https://huma.rocks/features/request-inputs")
-	f.Comment("")
-	f.Comment("Tag       | Description                           | Example")
-	f.Comment("-------------------------------------------------------------------")
-	f.Comment("path      | Name of the path parameter            | path:\"thing-id\"")
-	f.Comment("query     | Name of the query string parameter    | query:\"q\"")
-	f.Comment("header    | Name of the header parameter          | header:\"Authorization\"")
-	f.Comment("cookie    | Name of the cookie parameter          | cookie:\"session\"")
-	f.Comment("required  | Mark a query/header param as required | required:\"true\"")
-	f.Line()
-
-	f.Type().Id(data.Name + "Input").Struct(structFields...)
+// serviceStructs returns the fields of a service's Input and Output structs
+// as field name to type source, e.g. "UserID": "int".
+func serviceStructs(p Project, group, name string) (map[string]string, map[string]string, error) {
+	dir := filepath.Join(serviceRootDir(), group)
+	input, err := findStruct(p, dir, name+"Input")
+	if err != nil {
+		return nil, nil, err
+	}
+	output, err := findStruct(p, dir, name+"Output")
+	if err != nil {
+		return nil, nil, err
+	}
+	if input == nil || output == nil {
+		return nil, nil, fmt.Errorf("service %s.%s not found in %s", group, name, dir)
+	}
+	return input, output, nil
 }
 
-func generateRegisterRoutesMethod(f *jen.File, data HandlerData) {
-	methodCall := jen.Id("method").Dot(data.Method).Call(
-		jen.Id("api"),
-		jen.Lit("/"+data.Path),
-		jen.Qual("method", "Operation").Values(jen.Dict{
-			jen.Id("Summary"):     jen.Lit(data.Name),
-			jen.Id("Description"): jen.Lit(data.Name + " handler"),
-			jen.Id("Tags"):        jen.Index().String().Values(jen.Lit(data.GroupTitle)),
-			jen.Id("BearerAuth"):  jen.Lit(false),
-		}),
-		jen.Id("h").Dot("Handler"),
-	)
+// findStruct returns the fields of a struct type declared in a package
+// directory of the project, following aliases such as
+// "type XInput = usecase.XInput" into other project packages. It returns nil
+// when the package does not declare the type.
+func findStruct(p Project, dir, name string) (map[string]string, error) {
+	entries, err := vfs.ReadDir(p.Path(dir))
+	if err != nil {
+		return nil, nil
+	}
 
-	f.Func().Params(jen.Id("h").Op("*").Id(data.Receiver)).Id("RegisterRoutes").Params().Block(
-		jen.Id("api").Op(":=").Id("h").Dot("api"),
-		methodCall,
-	)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := gosrc.ParseFile(token.NewFileSet(), p.Path(dir, entry.Name()), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		obj := file.Scope.Lookup(name)
+		if obj == nil || obj.Kind != ast.Typ {
+			continue
+		}
+		spec, ok := obj.Decl.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		switch typ := spec.Type.(type) {
+		case *ast.StructType:
+			fields := map[string]string{}
+			for _, field := range typ.Fields.List {
+				for _, ident := range field.Names {
+					fields[ident.Name] = types.ExprString(field.Type)
+				}
+			}
+			return fields, nil
+		case *ast.SelectorExpr:
+			pkg, ok := typ.X.(*ast.Ident)
+			if !ok {
+				return nil, nil
+			}
+			for _, imp := range file.Imports {
+				importPath := strings.Trim(imp.Path.Value, `"`)
+				local := path.Base(importPath)
+				if imp.Name != nil {
+					local = imp.Name.Name
+				}
+				if rel, ok := strings.CutPrefix(importPath, p.Module+"/"); ok && local == pkg.Name {
+					return findStruct(p, filepath.FromSlash(rel), typ.Sel.Name)
+				}
+			}
+		}
+		return nil, nil
+	}
+	return nil, nil
 }
@@ -0,0 +1,145 @@
+package generators
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mustHandlerFields parses field specs of a handler.
+func mustHandlerFields(t *testing.T, specs ...string) []HandlerFieldSpec {
+	t.Helper()
+	var fields []HandlerFieldSpec
+	for _, spec := range specs {
+		field, err := ParseHandlerField(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func TestGenerateHandlerFromSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec func(t *testing.T) HandlerSpec
+	}{
+		{
+			name: "default route",
+			spec: func(t *testing.T) HandlerSpec {
+				return HandlerSpec{Name: "ping", Group: "health", Method: "get"}
+			},
+		},
+		{
+			// id, name and posts match the service; verbose is optional
+			// there and avatar missing, so they are left as TODOs
+			name: "service call",
+			spec: func(t *testing.T) HandlerSpec {
+				return HandlerSpec{
+					Name:     "GetUserProfile",
+					Group:    "user",
+					Method:   "GET",
+					Route:    "/users/{id}/profile",
+					Path:     mustHandlerFields(t, "id:int"),
+					Query:    mustHandlerFields(t, "verbose:bool", "lang:string:default=en"),
+					Response: mustHandlerFields(t, "name:string:required", "email", "posts:int", "avatar"),
+					Service:  "user.GetProfile",
+				}
+			},
+		},
+		{
+			name: "request body",
+			spec: func(t *testing.T) HandlerSpec {
+				return HandlerSpec{
+					Name:     "UpdateProfile",
+					Group:    "user",
+					Method:   "patch",
+					Route:    "/users/{id}",
+					Body:     mustHandlerFields(t, "name:string:required", "bio", "tags:strings", "born:time"),
+					Response: mustHandlerFields(t, "updated_at:time:required"),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlay := inApp(t)
+
+			if err := GenerateHandlerFromSpec(tt.spec(t)); err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+			files := written(overlay)
+			if len(files) != 1 {
+				t.Fatalf("wrote %d files, want the handler", len(files))
+			}
+
+			compareGolden(t, filepath.Join("handler", strings.ReplaceAll(tt.name, " ", "_")), files)
+			typeCheckSynced(t, Project{Root: ".", Module: appProject.Module}, files)
+		})
+	}
+}
+
+func TestGenerateHandlerFromSpecErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    func(t *testing.T) HandlerSpec
+		wantErr string
+	}{
+		{
+			name: "route without a slash",
+			spec: func(t *testing.T) HandlerSpec {
+				return HandlerSpec{Name: "GetUser", Group: "user", Method: "GET", Route: "users"}
+			},
+			wantErr: `route "users" must start with /`,
+		},
+		{
+			name: "path field missing from the route",
+			spec: func(t *testing.T) HandlerSpec {
+				return HandlerSpec{Name: "GetUser", Group: "user", Method: "GET", Route: "/users/{id}", Path: mustHandlerFields(t, "slug")}
+			},
+			wantErr: "path field slug does not appear in route /users/{id}",
+		},
+		{
+			name: "path field type",
+			spec: func(t *testing.T) HandlerSpec {
+				return HandlerSpec{Name: "GetUser", Group: "user", Method: "GET", Route: "/users/{at}", Path: mustHandlerFields(t, "at:time")}
+			},
+			wantErr: "path field at cannot be of type time",
+		},
+		{
+			name: "query field type",
+			spec: func(t *testing.T) HandlerSpec {
+				return HandlerSpec{Name: "ListUsers", Group: "user", Method: "GET", Query: mustHandlerFields(t, "ids:ints")}
+			},
+			wantErr: "query field ids cannot be of type ints",
+		},
+		{
+			name: "service without a group",
+			spec: func(t *testing.T) HandlerSpec {
+				return HandlerSpec{Name: "GetUser", Group: "user", Method: "GET", Service: "GetProfile"}
+			},
+			wantErr: `invalid service "GetProfile": expected group.Name`,
+		},
+		{
+			name: "unknown service",
+			spec: func(t *testing.T) HandlerSpec {
+				return HandlerSpec{Name: "GetUser", Group: "user", Method: "GET", Service: "user.GetAccount"}
+			},
+			wantErr: "service user.GetAccount not found in " + filepath.Join("internal", "core", "service", "user"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlay := inApp(t)
+			if err := GenerateHandlerFromSpec(tt.spec(t)); err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if changes := overlay.Changes(); len(changes) != 0 {
+				t.Errorf("a rejected handler wrote %s", changes[0].Path)
+			}
+		})
+	}
+}
@@ -2,13 +2,14 @@ package generators
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
 
+	"github.com/alfariiizi/vandor-cli/internal/naming"
 	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
 type JobData struct {
@@ -19,38 +20,33 @@ type JobData struct {
 }
 
 func GenerateJob(jobName string) error {
-	if jobName == "" {
-		return fmt.Errorf("job name cannot be empty")
+	name, err := naming.Component("job", jobName)
+	if err != nil {
+		return err
 	}
-
-	// Capitalize first letter for struct name
-	jobName = strings.ToUpper(jobName[:1]) + jobName[1:]
+	jobName = name.Pascal
 
 	data := JobData{
 		ModuleName: utils.GetModuleName(),
-		StructName: jobName,
-		VarName:    strings.ToLower(jobName[:1]) + jobName[1:], // camelCase
-		JobKey:     "job:" + strings.ToLower(jobName),          // snake_case with prefix
+		StructName: name.Pascal,
+		VarName:    name.Camel,
+		JobKey:     "job:" + strings.ToLower(jobName), // snake_case with prefix
 	}
 
-	// Check if job already exists
-	jobPath := filepath.Join("internal", "delivery", "worker", "job", strings.ToLower(jobName)+".go")
-	if _, err := os.Stat(jobPath); err == nil {
-		return fmt.Errorf("job %s already exists at %s", jobName, jobPath)
-	}
+	jobPath := filepath.Join(workerJobDir(), strings.ToLower(jobName)+".go")
 
 	// Generate job file
 	f := generateJobFile(data)
 
 	// Ensure directory exists
 	dir := filepath.Dir(jobPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := vfs.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	// Write file
-	if err := f.Save(jobPath); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+	if written, err := createFile(f, jobPath, data.ModuleName); !written || err != nil {
+		return err
 	}
 
 	fmt.Printf("Job %s created successfully at %s\n", jobName, jobPath)
@@ -0,0 +1,805 @@
+package generators
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+
+	"github.com/alfariiizi/vandor-cli/internal/entschema"
+	"github.com/alfariiizi/vandor-cli/internal/openapi"
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// openAPIDefaultGroup is the handler group of operations without tags.
+const openAPIDefaultGroup = "common"
+
+// openAPITypesFile holds the schema types of a service group, regenerated on
+// every import.
+const openAPITypesFile = "openapi_types.go"
+
+// OpenAPIImport is the outcome of importing an OpenAPI document.
+type OpenAPIImport struct {
+	Created []string // Handlers created, as group.Name
+	Skipped []string // Operations whose handler or service already exists
+}
+
+// ImportOpenAPI generates a handler and a service stub for each operation of
+// an OpenAPI document, grouped by the operation's first tag. The schemas the
+// operations use become types in the service group package, shared by the
+// service and its handler. Existing handlers and services are left alone.
+func ImportOpenAPI(doc *openapi.Document) (OpenAPIImport, error) {
+	p, err := CurrentProject()
+	if err != nil {
+		return OpenAPIImport{}, err
+	}
+
+	ops, err := openAPIOperations(doc)
+	if err != nil {
+		return OpenAPIImport{}, err
+	}
+	if len(ops) == 0 {
+		return OpenAPIImport{}, fmt.Errorf("the document has no operations")
+	}
+
+	var result OpenAPIImport
+	var files []GeneratedFile
+	groups := map[string]*openAPIGroup{}
+	var groupNames []string
+	for _, op := range ops {
+		g, ok := groups[op.Group]
+		if !ok {
+			g = newOpenAPIGroup(doc, p.Module, op.Group)
+			groups[op.Group] = g
+			groupNames = append(groupNames, op.Group)
+		}
+
+		if err := g.resolve(op); err != nil {
+			return OpenAPIImport{}, fmt.Errorf("%s %s: %w", op.Method, op.Route, err)
+		}
+
+		handlerPath := filepath.Join(routeDir(), op.Group, snakeWords(op.Name)+".go")
+		servicePath := filepath.Join(serviceRootDir(), op.Group, op.Name+".go")
+		if vfs.Exists(p.Path(handlerPath)) || vfs.Exists(p.Path(servicePath)) {
+			result.Skipped = append(result.Skipped, op.Group+"."+op.Name)
+			continue
+		}
+
+		service, err := renderFile(p, g.serviceFile(op), servicePath)
+		if err != nil {
+			return OpenAPIImport{}, err
+		}
+		handler, err := renderFile(p, g.handlerFile(op), handlerPath)
+		if err != nil {
+			return OpenAPIImport{}, err
+		}
+		files = append(files, service, handler)
+		result.Created = append(result.Created, op.Group+"."+op.Name)
+	}
+
+	for _, name := range groupNames {
+		g := groups[name]
+		if len(g.types) == 0 {
+			continue
+		}
+		file, err := renderFile(p, g.typesFile(), filepath.Join(serviceRootDir(), name, openAPITypesFile))
+		if err != nil {
+			return OpenAPIImport{}, err
+		}
+		files = append(files, file)
+	}
+
+	if _, err := WriteFiles(p, files); err != nil {
+		return OpenAPIImport{}, err
+	}
+	return result, nil
+}
+
+// ExportOpenAPI builds an OpenAPI document from the handlers in the route
+// group directories by static analysis, returning it with the warnings about
+// the routes and types that could not be analyzed.
+func ExportOpenAPI(info openapi.Info) (*openapi.Document, []string, error) {
+	p, err := CurrentProject()
+	if err != nil {
+		return nil, nil, err
+	}
+	return openapi.Analyze(p.Root, p.Module, routeDir(), info)
+}
+
+// openAPIOperation is an operation of the document and the Go code it maps
+// to.
+type openAPIOperation struct {
+	*openapi.Operation
+	Name   string // e.g. "ListPets"
+	Group  string // e.g. "pet"
+	Method string
+	Route  string
+	Params []*openapi.Parameter // Path item and operation parameters
+
+	// Set by openAPIGroup.resolve
+	Fields       []openAPIParam
+	Body         jen.Code // Request body type, nil without a JSON body
+	BodyRequired bool
+	ContentType  string
+	Data         jen.Code // Response data type, nil without a JSON body
+}
+
+// openAPIParam is a path, query, header or cookie parameter.
+type openAPIParam struct {
+	GoName string
+	Type   jen.Code
+	Tags   map[string]string
+}
+
+// openAPIOperations returns the operations of a document sorted by route and
+// method, with unique names within their group.
+func openAPIOperations(doc *openapi.Document) ([]*openAPIOperation, error) {
+	routes := make([]string, 0, len(doc.Paths))
+	for route := range doc.Paths {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+
+	var ops []*openAPIOperation
+	names := map[string]string{}
+	for _, route := range routes {
+		item := doc.Paths[route]
+		if item == nil {
+			continue
+		}
+		if item.Ref != "" {
+			return nil, fmt.Errorf("path %s: path item references are not supported", route)
+		}
+		for _, method := range openapi.Methods {
+			operation := item.Operation(method)
+			if operation == nil {
+				continue
+			}
+			switch method {
+			case "GET", "PUT", "POST", "PATCH", "DELETE":
+			default:
+				fmt.Printf("⚠️  Skipping %s %s: only GET, PUT, POST, PATCH and DELETE handlers are generated\n", method, route)
+				continue
+			}
+
+			op := &openAPIOperation{
+				Operation: operation,
+				Method:    method,
+				Route:     route,
+				Group:     openAPIDefaultGroup,
+				Params:    append(append([]*openapi.Parameter{}, item.Parameters...), operation.Parameters...),
+			}
+			if len(operation.Tags) > 0 {
+				op.Group = snakeWords(operation.Tags[0])
+			}
+			if operation.OperationID != "" {
+				op.Name = pascalWords(operation.OperationID)
+			} else {
+				op.Name = operationName(method, route)
+			}
+			if op.Name == "" || op.Group == "" {
+				return nil, fmt.Errorf("%s %s: cannot derive a Go name from operationId %q and tag %q", method, route, operation.OperationID, op.Group)
+			}
+
+			key := op.Group + "." + op.Name
+			if other, ok := names[key]; ok {
+				return nil, fmt.Errorf("%s %s and %s both map to %s", method, route, other, key)
+			}
+			names[key] = method + " " + route
+			ops = append(ops, op)
+		}
+	}
+	return ops, nil
+}
+
+// operationName names an operation without operationId from its method and
+// route, e.g. GET /pets/{petId} becomes GetPetsByPetID.
+func operationName(method, route string) string {
+	name := pascalWords(strings.ToLower(method))
+	for _, segment := range strings.Split(route, "/") {
+		if param, ok := strings.CutPrefix(segment, "{"); ok {
+			name += "By" + pascalWords(strings.TrimSuffix(param, "}"))
+		} else {
+			name += pascalWords(segment)
+		}
+	}
+	return name
+}
+
+// openAPIGroup generates the code of a handler group: the Go types of the
+// schemas its operations use are declared once in the service package.
+type openAPIGroup struct {
+	doc         *openapi.Document
+	module      string
+	servicePath string
+	group       string
+	types       map[string]jen.Code        // Type declarations by name
+	components  map[string]string          // Component schema name to type name
+	inline      map[*openapi.Schema]string // Inline object schemas to type name
+}
+
+func newOpenAPIGroup(doc *openapi.Document, module, group string) *openAPIGroup {
+	return &openAPIGroup{
+		doc:         doc,
+		module:      module,
+		servicePath: importPath(module, serviceRootDir(), group),
+		group:       group,
+		types:       map[string]jen.Code{},
+		components:  map[string]string{},
+		inline:      map[*openapi.Schema]string{},
+	}
+}
+
+// resolve maps the parameters, request body and success response of an
+// operation to Go types.
+func (g *openAPIGroup) resolve(op *openAPIOperation) error {
+	seen := map[string]int{}
+	goNames := map[string]bool{"Body": true}
+	for _, param := range op.Params {
+		param, err := g.doc.Parameter(param)
+		if err != nil {
+			return err
+		}
+		field, err := g.param(op, param)
+		if err != nil {
+			return err
+		}
+		// Operation parameters override the path item's
+		key := param.In + ":" + param.Name
+		if i, ok := seen[key]; ok {
+			field.GoName = op.Fields[i].GoName
+			op.Fields[i] = field
+			continue
+		}
+		if goNames[field.GoName] {
+			field.GoName += pascalWords(param.In)
+		}
+		goNames[field.GoName] = true
+		seen[key] = len(op.Fields)
+		op.Fields = append(op.Fields, field)
+	}
+
+	if op.RequestBody != nil {
+		body, err := g.doc.RequestBody(op.RequestBody)
+		if err != nil {
+			return err
+		}
+		contentType, schema := openapi.JSONSchema(body.Content)
+		if schema == nil {
+			fmt.Printf("⚠️  %s %s: only JSON request bodies are generated\n", op.Method, op.Route)
+		} else {
+			typ, err := g.goType(schema, op.Name+"Request")
+			if err != nil {
+				return err
+			}
+			op.Body = typ
+			op.BodyRequired = body.Required
+			op.ContentType = contentType
+		}
+	}
+
+	response, err := g.successResponse(op)
+	if err != nil {
+		return err
+	}
+	if response != nil {
+		if _, schema := openapi.JSONSchema(response.Content); schema != nil {
+			typ, err := g.goType(schema, op.Name+"Response")
+			if err != nil {
+				return err
+			}
+			op.Data = typ
+		}
+	}
+	return nil
+}
+
+// successResponse returns the lowest 2xx response of an operation, or the
+// default response when there is none.
+func (g *openAPIGroup) successResponse(op *openAPIOperation) (*openapi.Response, error) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	response := op.Responses["default"]
+	if len(codes) > 0 {
+		response = op.Responses[codes[0]]
+	}
+	if response == nil {
+		return nil, nil
+	}
+	return g.doc.Response(response)
+}
+
+// param maps a parameter to an Input struct field.
+func (g *openAPIGroup) param(op *openAPIOperation, param *openapi.Parameter) (openAPIParam, error) {
+	switch param.In {
+	case "path", "query", "header", "cookie":
+	default:
+		return openAPIParam{}, fmt.Errorf("parameter %s: unknown location %q", param.Name, param.In)
+	}
+
+	schema, err := g.doc.Schema(param.Schema)
+	if err != nil {
+		return openAPIParam{}, err
+	}
+	if schema == nil {
+		schema = &openapi.Schema{Type: openapi.Types{"string"}}
+	}
+
+	var typ jen.Code
+	switch schema.Type.Name() {
+	case "array":
+		items, err := g.doc.Schema(schema.Items)
+		if err != nil {
+			return openAPIParam{}, err
+		}
+		if items == nil || !isScalar(items) {
+			return openAPIParam{}, fmt.Errorf("parameter %s: arrays of %v are not supported", param.Name, items)
+		}
+		typ = jen.Index().Add(scalarType(items))
+	default:
+		if !isScalar(schema) {
+			fmt.Printf("⚠️  %s %s: parameter %s is not a scalar and is taken as a string\n", op.Method, op.Route, param.Name)
+			schema = &openapi.Schema{Type: openapi.Types{"string"}}
+		}
+		typ = scalarType(schema)
+	}
+
+	tags := schemaTags(schema)
+	tags[param.In] = param.Name
+	if param.Description != "" {
+		tags["doc"] = param.Description
+	}
+	if param.Required && param.In != "path" {
+		tags["required"] = "true"
+	}
+	if schema.Default != nil {
+		tags["default"] = fmt.Sprint(schema.Default)
+	}
+	return openAPIParam{GoName: pascalWords(param.Name), Type: typ, Tags: tags}, nil
+}
+
+// isScalar reports whether a schema is a string, number or boolean.
+func isScalar(s *openapi.Schema) bool {
+	switch s.Type.Name() {
+	case "string", "integer", "number", "boolean":
+		return true
+	}
+	return false
+}
+
+// scalarType returns the Go type of a scalar schema.
+func scalarType(s *openapi.Schema) *jen.Statement {
+	switch s.Type.Name() {
+	case "integer":
+		switch s.Format {
+		case "int32":
+			return jen.Int32()
+		case "int64":
+			return jen.Int64()
+		}
+		return jen.Int()
+	case "number":
+		if s.Format == "float" {
+			return jen.Float32()
+		}
+		return jen.Float64()
+	case "boolean":
+		return jen.Bool()
+	}
+	switch s.Format {
+	case "date-time":
+		return jen.Qual("time", "Time")
+	case "byte", "binary":
+		return jen.Index().Byte()
+	}
+	return jen.String()
+}
+
+// schemaTags returns the huma validation tags of a schema.
+func schemaTags(s *openapi.Schema) map[string]string {
+	tags := map[string]string{}
+	if s.Format != "" && s.Format != "date-time" && s.Format != "int32" && s.Format != "int64" {
+		tags["format"] = s.Format
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, 0, len(s.Enum))
+		for _, value := range s.Enum {
+			if value != nil {
+				values = append(values, fmt.Sprint(value))
+			}
+		}
+		tags["enum"] = strings.Join(values, ",")
+	}
+	if s.Minimum != nil {
+		tags["minimum"] = strconv.FormatFloat(*s.Minimum, 'f', -1, 64)
+	}
+	if s.Maximum != nil {
+		tags["maximum"] = strconv.FormatFloat(*s.Maximum, 'f', -1, 64)
+	}
+	if s.MinLength != nil {
+		tags["minLength"] = strconv.Itoa(*s.MinLength)
+	}
+	if s.MaxLength != nil {
+		tags["maxLength"] = strconv.Itoa(*s.MaxLength)
+	}
+	if s.Pattern != "" {
+		tags["pattern"] = s.Pattern
+	}
+	return tags
+}
+
+// goType returns the Go type of a schema. Component schemas and inline
+// objects become named types in the service package; name is the type name
+// of an inline object.
+func (g *openAPIGroup) goType(s *openapi.Schema, name string) (*jen.Statement, error) {
+	if s == nil {
+		return jen.Any(), nil
+	}
+
+	if component := openapi.SchemaRef(s); component != "" {
+		return g.component(component)
+	}
+	if s.Ref != "" {
+		return nil, fmt.Errorf("unsupported schema reference %q", s.Ref)
+	}
+
+	switch {
+	case len(s.AllOf) == 1:
+		return g.goType(s.AllOf[0], name)
+	case len(s.AllOf) > 1:
+		return g.object(s, name)
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		return jen.Any(), nil
+	}
+
+	switch s.Type.Name() {
+	case "array":
+		items, err := g.goType(s.Items, name+"Item")
+		if err != nil {
+			return nil, err
+		}
+		return jen.Index().Add(items), nil
+	case "object", "":
+		if len(s.Properties) > 0 {
+			return g.object(s, name)
+		}
+		if s.AdditionalProperties != nil {
+			value, err := g.goType(s.AdditionalProperties, name+"Value")
+			if err != nil {
+				return nil, err
+			}
+			return jen.Map(jen.String()).Add(value), nil
+		}
+		if s.Type.Name() == "" && !s.Type.Is("object") {
+			return jen.Any(), nil
+		}
+		return jen.Map(jen.String()).Any(), nil
+	}
+	return scalarType(s), nil
+}
+
+// component returns the named type of a component schema, declaring it on
+// first use.
+func (g *openAPIGroup) component(component string) (*jen.Statement, error) {
+	if name, ok := g.components[component]; ok {
+		return jen.Qual(g.servicePath, name), nil
+	}
+
+	schema, ok := g.doc.Components.Schemas[component]
+	if !ok {
+		return nil, fmt.Errorf("schema %q not found in components", component)
+	}
+	name := g.typeName(pascalWords(component))
+	g.components[component] = name
+
+	if isObject(schema) {
+		// Declared by object under the component's name
+		g.inline[schema] = name
+		if _, err := g.object(schema, name); err != nil {
+			return nil, err
+		}
+		return jen.Qual(g.servicePath, name), nil
+	}
+
+	g.types[name] = jen.Null()
+	typ, err := g.goType(schema, name+"Value")
+	if err != nil {
+		return nil, err
+	}
+	g.types[name] = declaration(schema.Description, jen.Type().Id(name).Add(typ))
+	return jen.Qual(g.servicePath, name), nil
+}
+
+// isObject reports whether a schema declares a struct.
+func isObject(s *openapi.Schema) bool {
+	return len(s.Properties) > 0 || len(s.AllOf) > 1
+}
+
+// typeName returns name, or name with a number when the group already
+// declares it.
+func (g *openAPIGroup) typeName(name string) string {
+	if _, ok := g.types[name]; !ok {
+		return name
+	}
+	for i := 2; ; i++ {
+		if _, ok := g.types[name+strconv.Itoa(i)]; !ok {
+			return name + strconv.Itoa(i)
+		}
+	}
+}
+
+// object declares the struct of an object schema, merging allOf members.
+func (g *openAPIGroup) object(s *openapi.Schema, name string) (*jen.Statement, error) {
+	if declared, ok := g.inline[s]; ok && declared != name {
+		return jen.Qual(g.servicePath, declared), nil
+	}
+	if _, ok := g.inline[s]; !ok {
+		name = g.typeName(name)
+		g.inline[s] = name
+	}
+	// Reserve the name while the fields are resolved, for recursive schemas
+	g.types[name] = jen.Null()
+
+	properties := map[string]*openapi.Schema{}
+	required := map[string]bool{}
+	if err := g.collectProperties(s, properties, required, 0); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(properties))
+	for property := range properties {
+		names = append(names, property)
+	}
+	sort.Strings(names)
+
+	var fields []jen.Code
+	for _, property := range names {
+		schema := properties[property]
+		fieldName := pascalWords(property)
+		typ, err := g.goType(schema, name+fieldName)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved, err := g.doc.Schema(schema)
+		if err != nil {
+			return nil, err
+		}
+		tags := schemaTags(resolved)
+		tags["json"] = property
+		if resolved.Description != "" {
+			tags["doc"] = resolved.Description
+		}
+		if !required[property] || resolved.IsNullable() {
+			tags["json"] += ",omitempty"
+			if pointable(resolved) {
+				typ = jen.Op("*").Add(typ)
+			}
+		}
+		fields = append(fields, jen.Id(fieldName).Add(typ).Tag(tags))
+	}
+
+	g.types[name] = declaration(s.Description, jen.Type().Id(name).Struct(fields...))
+	return jen.Qual(g.servicePath, name), nil
+}
+
+// collectProperties gathers the properties of an object schema and its allOf
+// members.
+func (g *openAPIGroup) collectProperties(s *openapi.Schema, properties map[string]*openapi.Schema, required map[string]bool, depth int) error {
+	if depth > 32 {
+		return fmt.Errorf("allOf nests too deeply")
+	}
+	s, err := g.doc.Schema(s)
+	if err != nil {
+		return err
+	}
+	for name, property := range s.Properties {
+		properties[name] = property
+	}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	for _, member := range s.AllOf {
+		if err := g.collectProperties(member, properties, required, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pointable reports whether an optional field of the schema is a pointer:
+// slices, maps and interfaces already have a zero value of nil.
+func pointable(s *openapi.Schema) bool {
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return false
+	}
+	switch s.Type.Name() {
+	case "array":
+		return false
+	case "object", "":
+		return isObject(s)
+	case "string":
+		return s.Format != "byte" && s.Format != "binary"
+	}
+	return true
+}
+
+// declaration prefixes a type declaration with its schema description.
+func declaration(description string, decl *jen.Statement) jen.Code {
+	if description == "" {
+		return decl
+	}
+	return jen.Comment(strings.Join(strings.Fields(description), " ")).Line().Add(decl)
+}
+
+// typesFile renders the schema types of the group.
+func (g *openAPIGroup) typesFile() *jen.File {
+	f := jen.NewFilePathName(g.servicePath, g.group+"_service")
+	f.HeaderComment("Code generated by vandor import openapi. DO NOT EDIT.")
+
+	names := make([]string, 0, len(g.types))
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f.Add(g.types[name])
+		f.Line()
+	}
+	return f
+}
+
+// serviceFile renders the service stub of an operation, taking the
+// operation's parameters and body and returning its response data.
+func (g *openAPIGroup) serviceFile(op *openAPIOperation) *jen.File {
+	f := jen.NewFilePathName(g.servicePath, g.group+"_service")
+	f.ImportAlias(g.module+"/internal/core/domain", "domain_entries")
+
+	var input []jen.Code
+	for _, field := range op.Fields {
+		input = append(input, jen.Id(field.GoName).Add(field.Type))
+	}
+	if op.Body != nil {
+		input = append(input, jen.Id("Body").Add(op.Body))
+	}
+	var output []jen.Code
+	if op.Data != nil {
+		output = append(output, jen.Id("Data").Add(op.Data))
+	}
+
+	f.Type().Id(op.Name + "Input").Struct(input...)
+	f.Type().Id(op.Name + "Output").Struct(output...)
+
+	writeService(f, g.module, op.Name, []jen.Code{
+		jen.Comment("TODO: Implement logic"),
+		jen.Line(),
+		jen.Return(jen.Op("&").Id(op.Name+"Output").Values(), jen.Nil()),
+	})
+	return f
+}
+
+// handlerFile renders the huma handler of an operation, which passes its
+// input to the service stub and returns the service's data.
+func (g *openAPIGroup) handlerFile(op *openAPIOperation) *jen.File {
+	f := jen.NewFile(g.group + "_handler")
+	f.ImportName(humaPath, "huma")
+	f.ImportAlias(g.servicePath, g.group+"_service")
+
+	var fields []jen.Code
+	values := jen.Dict{}
+	for _, field := range op.Fields {
+		fields = append(fields, jen.Id(field.GoName).Add(field.Type).Tag(field.Tags))
+		values[jen.Id(field.GoName)] = jen.Id("input").Dot(field.GoName)
+	}
+	if op.Body != nil {
+		tags := map[string]string{"json": "body", "contentType": op.ContentType}
+		if !op.BodyRequired {
+			tags["required"] = "false"
+		}
+		fields = append(fields, jen.Id("Body").Add(op.Body).Tag(tags))
+		values[jen.Id("Body")] = jen.Id("input").Dot("Body")
+	}
+	f.Type().Id(op.Name + "Input").Struct(fields...)
+
+	call := jen.Id("h").Dot("service").Dot(utils.ToPascalCase(g.group)).Dot(op.Name).Dot("Execute").Call(
+		jen.Id("ctx"),
+		jen.Qual(g.servicePath, op.Name+"Input").Values(values),
+	)
+	var body []jen.Code
+	if op.Data != nil {
+		f.Type().Id(op.Name + "Data").Op("=").Add(op.Data)
+		body = []jen.Code{
+			jen.List(jen.Id("res"), jen.Id("err")).Op(":=").Add(call),
+			jen.If(jen.Id("err").Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Id("err"))),
+			jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id("res").Dot("Data")), jen.Nil()),
+		}
+	} else {
+		f.Type().Id(op.Name + "Data").Struct()
+		body = []jen.Code{
+			jen.If(jen.List(jen.Id("_"), jen.Id("err")).Op(":=").Add(call), jen.Id("err").Op("!=").Nil()).Block(
+				jen.Return(jen.Nil(), jen.Id("err")),
+			),
+			jen.Return(jen.Id("h").Dot("GenerateResponse").Call(jen.Id(op.Name+"Data").Values()), jen.Nil()),
+		}
+	}
+
+	summary := op.Summary
+	if summary == "" {
+		summary = op.Name
+	}
+	description := op.Description
+	if description == "" {
+		description = summary
+	}
+	tag := utils.ToPascalCase(g.group)
+	if len(op.Tags) > 0 {
+		tag = op.Tags[0]
+	}
+	writeHandler(f, g.module, handlerRoute{
+		Name:        op.Name,
+		Method:      op.Method,
+		Path:        op.Route,
+		Summary:     summary,
+		Description: description,
+		Tag:         tag,
+		Data:        jen.Id(op.Name + "Data"),
+		Body:        body,
+	})
+	return f
+}
+
+// words splits an OpenAPI name such as "getPetByID", "pet-store" or
+// "X-Request-ID" into lowercase words, keeping acronyms whole.
+func words(s string) []string {
+	var result []string
+	var word []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(word) > 0 {
+			result = append(result, strings.ToLower(string(word)))
+			word = nil
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return result
+}
+
+// pascalWords converts an OpenAPI name to an exported Go identifier with Go
+// acronyms, e.g. "pet_id" and "petId" to "PetID".
+func pascalWords(s string) string {
+	name := entschema.GoName(strings.Join(words(s), "_"))
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+	return name
+}
+
+// snakeWords converts an OpenAPI name to a package or file name, e.g.
+// "Pet Store" to "pet_store".
+func snakeWords(s string) string {
+	return strings.Join(words(s), "_")
+}
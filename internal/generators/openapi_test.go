@@ -0,0 +1,265 @@
+package generators
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/openapi"
+)
+
+// petstore is an OpenAPI document with tagged and untagged operations,
+// path item, referenced, header and array parameters, component and inline
+// schemas, allOf, nullable and map schemas, and bodies of other JSON types.
+var petstore = filepath.Join("testdata", "openapi", "petstore.yaml")
+
+func loadPetstore(t *testing.T) *openapi.Document {
+	t.Helper()
+	doc, err := openapi.Load(petstore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestImportOpenAPI(t *testing.T) {
+	doc := loadPetstore(t)
+	overlay := inApp(t)
+
+	result, err := ImportOpenAPI(doc)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	wantCreated := []string{"common.GetHealth", "pets.ListPets", "pets.CreatePet", "pets.GetPetByID", "pets.DeletePetsByPetID", "pets.UpdatePet", "store.GetInventory"}
+	if !slices.Equal(result.Created, wantCreated) {
+		t.Errorf("created %v, want %v", result.Created, wantCreated)
+	}
+
+	files := written(overlay)
+	compareGolden(t, filepath.Join("openapi", "import"), files)
+	typeCheckSynced(t, Project{Root: ".", Module: appProject.Module}, files)
+
+	// Importing again leaves the handlers and services alone
+	again, err := ImportOpenAPI(doc)
+	if err != nil {
+		t.Fatalf("import again: %v", err)
+	}
+	if len(again.Created) != 0 || !slices.Equal(again.Skipped, wantCreated) {
+		t.Errorf("import again created %v and skipped %v, want every operation skipped", again.Created, again.Skipped)
+	}
+}
+
+// TestOpenAPIRoundTrip exports the handlers imported from a document and
+// compares the operations of both documents.
+func TestOpenAPIRoundTrip(t *testing.T) {
+	in := loadPetstore(t)
+	inApp(t)
+	if _, err := ImportOpenAPI(in); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	out, warnings, err := ExportOpenAPI(in.Info)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("export warnings: %v", warnings)
+	}
+
+	for route, item := range in.Paths {
+		for _, method := range openapi.Methods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			where := method + " " + route
+			exported := out.Paths[route]
+			if exported == nil || exported.Operation(method) == nil {
+				t.Errorf("%s is not exported", where)
+				continue
+			}
+			got := exported.Operation(method)
+
+			if op.OperationID != "" && pascalWords(got.OperationID) != pascalWords(op.OperationID) {
+				t.Errorf("%s: operationId = %q, want %q", where, got.OperationID, op.OperationID)
+			}
+			if op.Summary != "" && got.Summary != op.Summary {
+				t.Errorf("%s: summary = %q, want %q", where, got.Summary, op.Summary)
+			}
+			if op.Description != "" && got.Description != op.Description {
+				t.Errorf("%s: description = %q, want %q", where, got.Description, op.Description)
+			}
+			if len(op.Tags) > 0 && !slices.Equal(got.Tags, op.Tags[:1]) {
+				t.Errorf("%s: tags = %v, want %v", where, got.Tags, op.Tags[:1])
+			}
+
+			params := map[string]*openapi.Parameter{}
+			for _, param := range append(append([]*openapi.Parameter{}, item.Parameters...), op.Parameters...) {
+				param, err := in.Parameter(param)
+				if err != nil {
+					t.Fatal(err)
+				}
+				params[param.In+" "+param.Name] = param
+			}
+			for _, param := range got.Parameters {
+				key := param.In + " " + param.Name
+				want, ok := params[key]
+				if !ok {
+					t.Errorf("%s: unexpected parameter %s", where, key)
+					continue
+				}
+				delete(params, key)
+				if param.Required != want.Required {
+					t.Errorf("%s: parameter %s required = %v, want %v", where, key, param.Required, want.Required)
+				}
+				compareSchemas(t, where+" parameter "+key, in, want.Schema, out, param.Schema)
+			}
+			for key := range params {
+				t.Errorf("%s: parameter %s is not exported", where, key)
+			}
+
+			if op.RequestBody != nil {
+				body, err := in.RequestBody(op.RequestBody)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.RequestBody == nil {
+					t.Errorf("%s: request body is not exported", where)
+				} else {
+					if got.RequestBody.Required != body.Required {
+						t.Errorf("%s: request body required = %v, want %v", where, got.RequestBody.Required, body.Required)
+					}
+					wantType, wantSchema := openapi.JSONSchema(body.Content)
+					gotType, gotSchema := openapi.JSONSchema(got.RequestBody.Content)
+					if gotType != wantType {
+						t.Errorf("%s: request body is %s, want %s", where, gotType, wantType)
+					}
+					compareSchemas(t, where+" request body", in, wantSchema, out, gotSchema)
+				}
+			} else if got.RequestBody != nil {
+				t.Errorf("%s: unexpected request body", where)
+			}
+
+			// Response data is wrapped in the data property of the body
+			var wantData *openapi.Schema
+			for code, response := range op.Responses {
+				if strings.HasPrefix(code, "2") || code == "default" {
+					response, err := in.Response(response)
+					if err != nil {
+						t.Fatal(err)
+					}
+					_, wantData = openapi.JSONSchema(response.Content)
+				}
+			}
+			response := got.Responses["200"]
+			if response == nil {
+				t.Errorf("%s: responses = %v, want 200", where, got.Responses)
+				continue
+			}
+			_, body := openapi.JSONSchema(response.Content)
+			body, err = out.Schema(body)
+			if err != nil || body == nil || body.Properties["data"] == nil {
+				t.Errorf("%s: response body %+v has no data (%v)", where, body, err)
+				continue
+			}
+			if wantData == nil {
+				wantData = &openapi.Schema{Type: openapi.Types{"object"}}
+			}
+			compareSchemas(t, where+" response", in, wantData, out, body.Properties["data"])
+		}
+	}
+}
+
+// compareSchemas reports the differences between a schema of the imported
+// document and the exported one: types, formats, enums, defaults and
+// properties with whether they are required, after resolving references and
+// merging allOf members.
+func compareSchemas(t *testing.T, where string, inDoc *openapi.Document, in *openapi.Schema, outDoc *openapi.Document, out *openapi.Schema) {
+	t.Helper()
+	in, out = flatten(t, inDoc, in), flatten(t, outDoc, out)
+	if in == nil || out == nil {
+		if (in == nil) != (out == nil) {
+			t.Errorf("%s: schema = %+v, want %+v", where, out, in)
+		}
+		return
+	}
+
+	typeName := func(s *openapi.Schema) string {
+		if name := s.Type.Name(); name != "" {
+			return name
+		}
+		return "object"
+	}
+	if typeName(in) != typeName(out) {
+		t.Errorf("%s: type = %v, want %v", where, out.Type, in.Type)
+	}
+	if in.Format != "" && out.Format != in.Format {
+		t.Errorf("%s: format = %q, want %q", where, out.Format, in.Format)
+	}
+	if fmt.Sprint(out.Enum) != fmt.Sprint(in.Enum) {
+		t.Errorf("%s: enum = %v, want %v", where, out.Enum, in.Enum)
+	}
+	if fmt.Sprint(out.Default) != fmt.Sprint(in.Default) {
+		t.Errorf("%s: default = %v, want %v", where, out.Default, in.Default)
+	}
+	if !slices.Equal(out.PropertyNames(), in.PropertyNames()) {
+		t.Errorf("%s: properties = %v, want %v", where, out.PropertyNames(), in.PropertyNames())
+	}
+	for _, name := range in.PropertyNames() {
+		if out.Properties[name] == nil {
+			continue
+		}
+		property := inDoc.Components.Schemas[openapi.SchemaRef(in.Properties[name])]
+		if property == nil {
+			property = in.Properties[name]
+		}
+		// Nullable properties are optional in Go
+		required := in.IsRequired(name) && !property.IsNullable()
+		if out.IsRequired(name) != required {
+			t.Errorf("%s: property %s required = %v, want %v", where, name, out.IsRequired(name), required)
+		}
+		compareSchemas(t, where+"."+name, inDoc, in.Properties[name], outDoc, out.Properties[name])
+	}
+	if in.Items != nil || out.Items != nil {
+		items := flatten(t, inDoc, in.Items)
+		if items != nil && out.Items != nil && items.Type.Name() == "string" && items.Format != "date-time" {
+			// Struct tags cannot give array items a format, e.g. uri
+			copied := *items
+			copied.Format = out.Items.Format
+			items = &copied
+		}
+		compareSchemas(t, where+"[]", inDoc, items, outDoc, out.Items)
+	}
+	if in.AdditionalProperties != nil || out.AdditionalProperties != nil {
+		compareSchemas(t, where+"{}", inDoc, in.AdditionalProperties, outDoc, out.AdditionalProperties)
+	}
+}
+
+// flatten resolves a schema reference and merges the properties of its allOf
+// members into it.
+func flatten(t *testing.T, doc *openapi.Document, s *openapi.Schema) *openapi.Schema {
+	t.Helper()
+	s, err := doc.Schema(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s == nil || len(s.AllOf) == 0 {
+		return s
+	}
+	merged := *s
+	merged.AllOf = nil
+	merged.Properties = map[string]*openapi.Schema{}
+	for name, property := range s.Properties {
+		merged.Properties[name] = property
+	}
+	for _, member := range s.AllOf {
+		member := flatten(t, doc, member)
+		for name, property := range member.Properties {
+			merged.Properties[name] = property
+		}
+		merged.Required = append(merged.Required, member.Required...)
+	}
+	return &merged
+}
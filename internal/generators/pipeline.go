@@ -0,0 +1,273 @@
+package generators
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+	"github.com/alfariiizi/vandor-cli/internal/naming"
+	"github.com/alfariiizi/vandor-cli/internal/overwrite"
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Project describes the Go project that registry generators read from and
+// write to.
+type Project struct {
+	Root   string // Project root directory
+	Module string // Go module path e.g. "github.com/user/project"
+}
+
+// CurrentProject returns the project in the working directory.
+func CurrentProject() (Project, error) {
+	module, err := utils.DetectGoModule()
+	if err != nil {
+		return Project{}, err
+	}
+	return Project{Root: ".", Module: module}, nil
+}
+
+// Path joins a project-relative path onto the project root.
+func (p Project) Path(elem ...string) string {
+	return filepath.Join(append([]string{p.Root}, elem...)...)
+}
+
+// importPath returns the import path of a project-relative directory.
+func importPath(module string, elem ...string) string {
+	return module + "/" + filepath.ToSlash(filepath.Join(elem...))
+}
+
+// sourceFiles returns the Go files registry discovery reads in dir: those
+// directly in it, not in subdirectories, except tests and the generated files
+// named in generated. A missing dir has none.
+func sourceFiles(dir string, generated ...string) ([]string, error) {
+	entries, err := vfs.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || slices.Contains(generated, name) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	return files, nil
+}
+
+// groupDirs returns the names of the subdirectories of dir, each a group of
+// components such as services or handlers. A missing dir has none.
+func groupDirs(dir string) ([]string, error) {
+	entries, err := vfs.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			groups = append(groups, entry.Name())
+		}
+	}
+	return groups, nil
+}
+
+// keepEmpty reports whether a registry with nothing to register is still
+// rendered: when any of its files was generated before, so that it is
+// regenerated empty instead of going stale. Projects that never had such
+// components get no registry.
+func keepEmpty(p Project, paths ...string) bool {
+	for _, path := range paths {
+		if vfs.Exists(p.Path(path)) {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneratedFile is a rendered output file. Path is relative to the project
+// root.
+type GeneratedFile struct {
+	Path    string
+	Content []byte
+}
+
+// RenderResult is the output of a registry generator.
+type RenderResult struct {
+	Files []GeneratedFile
+	Items []string // Registered component names, for reporting
+}
+
+// RegistryGenerator renders one kind of registry from the components it
+// discovers in a project.
+type RegistryGenerator struct {
+	Name    string          // Component kind e.g. "domain"
+	Inputs  func() []string // Project-relative directories the generator discovers from
+	Outputs func() []string // Project-relative paths the generator writes
+	Render  func(p Project) (RenderResult, error)
+}
+
+// Directories the registry generators discover components from, and the add
+// generators create them in, from the project layout. They are looked up when
+// used, once the configuration flags have been applied.
+func domainModelDir() string { return layout.Current().Domain }
+func usecaseDir() string     { return layout.Current().Usecase }
+func serviceRootDir() string { return layout.Current().Service }
+func workerJobDir() string   { return layout.Current().Job }
+func routeDir() string       { return layout.Current().Handler }
+func schedulerDir() string   { return layout.Current().Scheduler }
+
+// Registry files whose place is not the directory they are discovered from,
+// also from the project layout. The job registry is generated into the
+// package of the jobs.
+func domainFile() string    { return filepath.Join(layout.Current().DomainRegistry(), "domain.go") }
+func jobsFile() string      { return filepath.Join(workerJobDir(), "jobs.go") }
+func workerGenFile() string { return filepath.Join(workerJobDir(), "worker_gen.go") }
+
+var (
+	DomainRegistry = RegistryGenerator{
+		Name:    "domain",
+		Inputs:  func() []string { return []string{domainModelDir()} },
+		Outputs: func() []string { return []string{domainFile()} },
+		Render:  RenderDomainRegistry,
+	}
+	UsecaseRegistry = RegistryGenerator{
+		Name:    "usecase",
+		Inputs:  func() []string { return []string{usecaseDir()} },
+		Outputs: func() []string { return []string{filepath.Join(usecaseDir(), "usecases.go")} },
+		Render:  RenderUsecaseRegistry,
+	}
+	ServiceRegistry = RegistryGenerator{
+		Name:    "service",
+		Inputs:  func() []string { return []string{serviceRootDir()} },
+		Outputs: func() []string { return []string{serviceRootDir()} },
+		Render:  RenderServiceRegistry,
+	}
+	JobRegistry = RegistryGenerator{
+		Name:    "job",
+		Inputs:  func() []string { return []string{workerJobDir()} },
+		Outputs: func() []string { return []string{jobsFile(), workerGenFile()} },
+		Render:  RenderJobRegistry,
+	}
+	HandlerRegistry = RegistryGenerator{
+		Name:    "handler",
+		Inputs:  func() []string { return []string{routeDir()} },
+		Outputs: func() []string { return []string{filepath.Join(routeDir(), "routes.go")} },
+		Render:  RenderHandlerRegistry,
+	}
+)
+
+// Generate renders the registry for the current project, writes the files
+// whose content changed and reports what was registered to out.
+func (g RegistryGenerator) Generate(out io.Writer) error {
+	p, err := CurrentProject()
+	if err != nil {
+		return err
+	}
+
+	result, err := g.Render(p)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Files) == 0 {
+		fmt.Fprintf(out, "No %ss found in %s\n", g.Name, g.Inputs()[0])
+		return nil
+	}
+
+	written, err := WriteFiles(p, result.Files)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range result.Files {
+		if written[file.Path] {
+			fmt.Fprintf(out, "Generated %s\n", file.Path)
+		} else {
+			fmt.Fprintf(out, "Unchanged %s\n", file.Path)
+		}
+	}
+	fmt.Fprintf(out, "Registered %d %s(s):\n", len(result.Items), g.Name)
+	for _, item := range result.Items {
+		fmt.Fprintf(out, "  - %s\n", item)
+	}
+	return nil
+}
+
+// WriteFiles writes generated files under the project root, skipping files
+// whose content on disk is already identical. It returns the set of paths
+// that were written.
+func WriteFiles(p Project, files []GeneratedFile) (map[string]bool, error) {
+	written := map[string]bool{}
+	for _, file := range files {
+		changed, err := gosrc.WriteFile(p.Path(file.Path), file.Content)
+		if err != nil {
+			return nil, err
+		}
+		written[file.Path] = changed
+	}
+	return written, nil
+}
+
+// renderFile renders a jennifer file to a formatted GeneratedFile at path.
+func renderFile(p Project, f *jen.File, path string) (GeneratedFile, error) {
+	var buf bytes.Buffer
+	if err := f.Render(&buf); err != nil {
+		return GeneratedFile{}, fmt.Errorf("failed to render %s: %w", path, err)
+	}
+
+	content, err := gosrc.Format(buf.Bytes(), p.Module)
+	if err != nil {
+		return GeneratedFile{}, fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	return GeneratedFile{Path: path, Content: content}, nil
+}
+
+// saveFile renders, formats and writes a scaffolded component file.
+func saveFile(f *jen.File, path, moduleName string) error {
+	file, err := renderFile(Project{Module: moduleName}, f, path)
+	if err != nil {
+		return err
+	}
+	if _, err := gosrc.WriteFile(path, file.Content); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	return nil
+}
+
+// createFile is saveFile for a component an add command creates: an existing
+// file is left to the overwrite mode. It reports whether the file was
+// written.
+func createFile(f *jen.File, path, moduleName string) (bool, error) {
+	file, err := renderFile(Project{Module: moduleName}, f, path)
+	if err != nil {
+		return false, err
+	}
+	if err := naming.Collisions(path, file.Content); err != nil {
+		return false, err
+	}
+	if ok, err := overwrite.Check(path, file.Content); !ok || err != nil {
+		return false, err
+	}
+	if _, err := gosrc.WriteFile(path, file.Content); err != nil {
+		return false, fmt.Errorf("failed to save file: %w", err)
+	}
+	return true, nil
+}
@@ -6,13 +6,12 @@ import (
 	"go/parser"
 	"go/token"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
 
-	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
 )
 
 type DomainInfo struct {
@@ -22,72 +21,63 @@ type DomainInfo struct {
 }
 
 func GenerateDomainRegistry() error {
-	domainsPath := filepath.Join("internal", "core", "domain", "model")
+	return DomainRegistry.Generate(os.Stdout)
+}
 
-	// Discover all domain files
-	domains, err := discoverDomains(domainsPath)
+// RenderDomainRegistry renders domain.go, next to the domain model directory,
+// from the domains declared in it.
+func RenderDomainRegistry(p Project) (RenderResult, error) {
+	domains, err := discoverDomains(p.Path(domainModelDir()))
 	if err != nil {
-		return fmt.Errorf("error discovering domains: %w", err)
+		return RenderResult{}, fmt.Errorf("error discovering domains: %w", err)
 	}
 
-	if len(domains) == 0 {
-		fmt.Println("No domains found in", domainsPath)
-		return nil
+	if len(domains) == 0 && !keepEmpty(p, domainFile()) {
+		return RenderResult{}, nil
 	}
 
-	// Sort domains by name for consistent output
-	sort.Slice(domains, func(i, j int) bool {
-		return domains[i].Name < domains[j].Name
-	})
-
-	// Generate domain registry
-	registryPath := filepath.Join("internal", "core", "domain", "domain.go")
-	if err := generateDomainRegistryFile(domains, registryPath); err != nil {
-		return fmt.Errorf("error generating domain registry: %w", err)
+	file, err := renderFile(p, generateDomainRegistryFile(domains, p.Module), domainFile())
+	if err != nil {
+		return RenderResult{}, err
 	}
 
-	fmt.Printf("Domain registry generated successfully at %s\n", registryPath)
-	fmt.Printf("Registered %d domains:\n", len(domains))
+	result := RenderResult{Files: []GeneratedFile{file}}
 	for _, domain := range domains {
-		fmt.Printf("  - %s\n", domain.Name)
+		result.Items = append(result.Items, domain.Name)
 	}
-	return nil
+	return result, nil
 }
 
 func discoverDomains(domainsPath string) ([]DomainInfo, error) {
-	var domains []DomainInfo
-
-	// Walk through domain model directory
-	err := filepath.Walk(domainsPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip directories and non-Go files
-		if info.IsDir() || !strings.HasSuffix(path, ".go") {
-			return nil
-		}
+	files, err := sourceFiles(domainsPath)
+	if err != nil {
+		return nil, err
+	}
 
+	var domains []DomainInfo
+	for _, path := range files {
 		// Parse the Go file
 		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		node, err := gosrc.ParseFile(fset, path, parser.ParseComments)
 		if err != nil {
-			return fmt.Errorf("failed to parse %s: %w", path, err)
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
 		}
 
 		// Look for domain struct and NewXXXDomain function
-		domainInfo := extractDomainInfo(node, path)
-		if domainInfo != nil {
+		if domainInfo := extractDomainInfo(node); domainInfo != nil {
 			domains = append(domains, *domainInfo)
 		}
+	}
 
-		return nil
+	// Sort domains by name for consistent output
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].Name < domains[j].Name
 	})
 
-	return domains, err
+	return domains, nil
 }
 
-func extractDomainInfo(node *ast.File, filePath string) *DomainInfo {
+func extractDomainInfo(node *ast.File) *DomainInfo {
 	var domainStruct *ast.TypeSpec
 	var newDomainFunc *ast.FuncDecl
 
@@ -140,67 +130,54 @@ func hasDBEmbedding(structType *ast.StructType) bool {
 	return false
 }
 
-func generateDomainRegistryFile(domains []DomainInfo, outputPath string) error {
-	moduleName := utils.GetModuleName()
+func generateDomainRegistryFile(domains []DomainInfo, moduleName string) *jen.File {
+	builderPath := moduleName + "/internal/core/domain/builder"
+	modelPath := importPath(moduleName, domainModelDir())
+	dbPath := moduleName + "/internal/infrastructure/db"
+
 	f := jen.NewFile("domain_entries")
 
 	// Add header comment
-	f.Comment("Package domain_entries provides all domain for the application.")
-	f.Comment("Code generated by Domain Generator. DO NOT EDIT.")
-	f.Comment("Template: https://github.com/alfariiizi/vandor-cli")
+	f.PackageComment("Package domain_entries provides all domain for the application.")
+	f.PackageComment("Code generated by Domain Generator. DO NOT EDIT.")
+	f.PackageComment("Template: https://github.com/alfariiizi/vandor-cli")
 
 	// Add imports
-	f.ImportName(moduleName+"/internal/core/domain/builder", "domain_builder")
-	f.ImportName(moduleName+"/internal/core/domain/model", "domain")
-	f.ImportName(moduleName+"/internal/infrastructure/db", "db")
+	f.ImportAlias(builderPath, "domain_builder")
+	f.ImportAlias(modelPath, "domain")
+	f.ImportName(dbPath, "db")
 	f.ImportName("go.uber.org/fx", "fx")
 
 	// Generate Domain struct
 	domainFields := []jen.Code{}
 	for _, domain := range domains {
 		domainFields = append(domainFields,
-			jen.Id(domain.Name).Qual("domain_builder", "Domain").Types(
-				jen.Op("*").Qual("db", domain.Name),
-				jen.Op("*").Qual("domain", domain.Name),
+			jen.Id(domain.Name).Qual(builderPath, "Domain").Types(
+				jen.Op("*").Qual(dbPath, domain.Name),
+				jen.Op("*").Qual(modelPath, domain.Name),
 			),
 		)
 	}
 	f.Type().Id("Domain").Struct(domainFields...)
 
 	// Generate NewDomain constructor
-
+	constructorDict := jen.Dict{}
+	for _, domain := range domains {
+		constructorDict[jen.Id(domain.Name)] = jen.Qual(modelPath, "New"+domain.Name+"Domain").Call(jen.Id("client"))
+	}
 	f.Func().Id("NewDomain").Params(
-		jen.Id("client").Op("*").Qual("db", "Client"),
+		jen.Id("client").Op("*").Qual(dbPath, "Client"),
 	).Op("*").Id("Domain").Block(
-		jen.Return(jen.Op("&").Id("Domain").Values(jen.Dict(
-			func() jen.Dict {
-				dict := jen.Dict{}
-				for _, domain := range domains {
-					dict[jen.Id(domain.Name)] = jen.Qual("domain", "New"+domain.Name+"Domain").Call(jen.Id("client"))
-				}
-				return dict
-			}(),
-		))),
+		jen.Return(jen.Op("&").Id("Domain").Values(constructorDict)),
 	)
 
 	// Generate fx.Module
-	f.Var().Id("Module").Op("=").Qual("fx", "Module").Call(
+	f.Var().Id("Module").Op("=").Qual("go.uber.org/fx", "Module").Call(
 		jen.Lit("domain"),
-		jen.Qual("fx", "Provide").Call(
+		jen.Qual("go.uber.org/fx", "Provide").Call(
 			jen.Id("NewDomain"),
 		),
 	)
 
-	// Ensure directory exists
-	dir := filepath.Dir(outputPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	// Write file
-	if err := f.Save(outputPath); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
-	}
-
-	return nil
+	return f
 }
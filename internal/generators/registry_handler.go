@@ -12,134 +12,121 @@ import (
 
 	"github.com/dave/jennifer/jen"
 
-	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
 )
 
+// HandlerGroup is a directory under the route directory and the handler
+// constructors declared in it.
+type HandlerGroup struct {
+	Name     string   // Directory name e.g. "user"
+	Handlers []string // Constructors e.g. "NewGetUser"
+}
+
 func GenerateHandlerRegistry() error {
-	routeDir := "internal/delivery/http/route"
-	moduleName := utils.GetModuleName()
+	return HandlerRegistry.Generate(os.Stdout)
+}
 
-	// Discover handlers by groups
-	handlerGroups, err := discoverHandlerGroups(routeDir)
+// RenderHandlerRegistry renders internal/delivery/http/route/routes.go,
+// invoking every handler constructor found in the route groups.
+func RenderHandlerRegistry(p Project) (RenderResult, error) {
+	groups, err := discoverHandlerGroups(p.Path(routeDir()))
 	if err != nil {
-		return fmt.Errorf("error discovering handler groups: %w", err)
+		return RenderResult{}, fmt.Errorf("error discovering handler groups: %w", err)
 	}
 
-	if len(handlerGroups) == 0 {
-		fmt.Println("No handler groups found in", routeDir)
-		return nil
+	output := filepath.Join(routeDir(), "routes.go")
+	if len(groups) == 0 && !keepEmpty(p, output) {
+		return RenderResult{}, nil
 	}
 
-	// Generate routes.go
-	routesPath := filepath.Join(routeDir, "routes.go")
-	if err := generateRoutesFile(handlerGroups, routesPath, moduleName); err != nil {
-		return fmt.Errorf("error generating routes file: %w", err)
+	file, err := renderFile(p, generateRoutesFile(groups, p.Module), output)
+	if err != nil {
+		return RenderResult{}, err
 	}
 
-	fmt.Printf("Handler registry generated successfully at %s\n", routesPath)
-	fmt.Printf("Registered %d handler groups:\n", len(handlerGroups))
-	for group, handlers := range handlerGroups {
-		fmt.Printf("  - %s: %d handlers\n", group, len(handlers))
+	result := RenderResult{Files: []GeneratedFile{file}}
+	for _, group := range groups {
+		for _, handler := range group.Handlers {
+			result.Items = append(result.Items, group.Name+"."+handler)
+		}
 	}
-
-	return nil
+	return result, nil
 }
 
-func discoverHandlerGroups(routeDir string) (map[string][]string, error) {
-	handlerGroups := map[string][]string{}
-
-	entries, err := os.ReadDir(routeDir)
+// discoverHandlerGroups returns the route groups that declare at least one
+// handler constructor, sorted by group name. A group is a directory of the
+// route directory; its handlers are declared in the files directly in it.
+func discoverHandlerGroups(dir string) ([]HandlerGroup, error) {
+	names, err := groupDirs(dir)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
+	var groups []HandlerGroup
 
-		group := entry.Name()
-		groupDir := filepath.Join(routeDir, group)
+	for _, name := range names {
+		group := HandlerGroup{Name: name}
 
-		files, err := os.ReadDir(groupDir)
+		files, err := sourceFiles(filepath.Join(dir, name), "service.go")
 		if err != nil {
-			continue
+			return nil, err
 		}
 
-		var handlers []string
-		for _, file := range files {
-			if strings.HasSuffix(file.Name(), ".go") && file.Name() != "service.go" {
-				fset := token.NewFileSet()
-				astFile, err := parser.ParseFile(fset, filepath.Join(groupDir, file.Name()), nil, parser.AllErrors)
-				if err != nil {
-					continue
-				}
+		for _, path := range files {
+			fset := token.NewFileSet()
+			astFile, err := gosrc.ParseFile(fset, path, parser.AllErrors)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+			}
 
-				for _, decl := range astFile.Decls {
-					fn, ok := decl.(*ast.FuncDecl)
-					if ok && fn.Recv == nil && fn.Name.IsExported() && strings.HasPrefix(fn.Name.Name, "New") {
-						handlers = append(handlers, fn.Name.Name)
-					}
+			for _, decl := range astFile.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if ok && fn.Recv == nil && fn.Name.IsExported() && strings.HasPrefix(fn.Name.Name, "New") {
+					group.Handlers = append(group.Handlers, fn.Name.Name)
 				}
 			}
 		}
 
-		if len(handlers) > 0 {
+		if len(group.Handlers) > 0 {
 			// Sort handlers for consistent output
-			sort.Strings(handlers)
-			handlerGroups[group] = handlers
+			sort.Strings(group.Handlers)
+			groups = append(groups, group)
 		}
 	}
 
-	return handlerGroups, nil
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+
+	return groups, nil
 }
 
-func generateRoutesFile(handlerGroups map[string][]string, outputPath, moduleName string) error {
+func generateRoutesFile(groups []HandlerGroup, moduleName string) *jen.File {
 	f := jen.NewFile("route")
 
 	// Add header comment
-	f.Comment("Package route provides HTTP routes for the application.")
-	f.Comment("Code generated by HTTP Handler Generator. DO NOT EDIT.")
-	f.Comment("Template: https://github.com/alfariiizi/vandor-cli.")
-
-	// Sort groups for consistent output
-	var sortedGroups []string
-	for group := range handlerGroups {
-		sortedGroups = append(sortedGroups, group)
-	}
-	sort.Strings(sortedGroups)
+	f.PackageComment("Package route provides HTTP routes for the application.")
+	f.PackageComment("Code generated by HTTP Handler Generator. DO NOT EDIT.")
+	f.PackageComment("Template: https://github.com/alfariiizi/vandor-cli.")
 
 	// Add imports
-	for _, group := range sortedGroups {
-		importAlias := group + "_handler"
-		f.ImportAlias(moduleName+"/internal/delivery/http/route/"+group, importAlias)
+	for _, group := range groups {
+		f.ImportAlias(importPath(moduleName, routeDir(), group.Name), group.Name+"_handler")
 	}
 	f.ImportName("go.uber.org/fx", "fx")
 
 	// Generate fx.Module with all handler invocations
 	var invocations []jen.Code
-	for _, group := range sortedGroups {
-		importAlias := group + "_handler"
-		for _, handler := range handlerGroups[group] {
-			invocations = append(invocations, jen.Qual(importAlias, handler))
+	for _, group := range groups {
+		for _, handler := range group.Handlers {
+			invocations = append(invocations, jen.Qual(importPath(moduleName, routeDir(), group.Name), handler))
 		}
 	}
 
-	f.Var().Id("Module").Op("=").Qual("fx", "Module").Call(
+	f.Var().Id("Module").Op("=").Qual("go.uber.org/fx", "Module").Call(
 		jen.Lit("route"),
-		jen.Qual("fx", "Invoke").Call(invocations...),
+		jen.Qual("go.uber.org/fx", "Invoke").Call(invocations...),
 	)
 
-	// Ensure directory exists
-	dir := filepath.Dir(outputPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	// Write file
-	if err := f.Save(outputPath); err != nil {
-		return fmt.Errorf("failed to save routes file: %w", err)
-	}
-
-	return nil
+	return f
 }
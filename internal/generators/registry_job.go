@@ -1,6 +1,7 @@
 package generators
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
@@ -12,7 +13,7 @@ import (
 
 	"github.com/dave/jennifer/jen"
 
-	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
 )
 
 type JobInfo struct {
@@ -25,108 +26,234 @@ type JobInfo struct {
 }
 
 func GenerateJobRegistry() error {
-	jobsDir := "internal/delivery/worker/job"
+	return JobRegistry.Generate(os.Stdout)
+}
 
-	// Discover all jobs
-	jobs, err := discoverJobs(jobsDir)
+// RenderJobRegistry renders jobs.go and worker_gen.go from the jobs found
+// in the worker job directory. Both are generated into the package of the
+// jobs, so that they refer to the job types and payloads unqualified.
+func RenderJobRegistry(p Project) (RenderResult, error) {
+	dir := p.Path(workerJobDir())
+	jobs, err := discoverJobs(dir)
 	if err != nil {
-		return fmt.Errorf("error discovering jobs: %w", err)
+		return RenderResult{}, fmt.Errorf("error discovering jobs: %w", err)
 	}
 
-	if len(jobs) == 0 {
-		fmt.Println("No jobs found in", jobsDir)
-		return nil
+	if len(jobs) == 0 && !keepEmpty(p, jobsFile(), workerGenFile()) {
+		return RenderResult{}, nil
 	}
 
-	// Sort jobs by name for consistent output
-	sort.Slice(jobs, func(i, j int) bool {
-		return jobs[i].Name < jobs[j].Name
-	})
+	pkg, err := jobPackage(dir)
+	if err != nil {
+		return RenderResult{}, err
+	}
+	for i := range jobs {
+		jobs[i].ModuleName = p.Module
+	}
 
-	// Generate jobs.go
-	jobsPath := filepath.Join("internal", "core", "job", "jobs.go")
-	if err := generateJobsFile(jobs, jobsPath); err != nil {
-		return fmt.Errorf("error generating jobs file: %w", err)
+	jobsFile, err := renderFile(p, generateJobsFile(pkg, jobs), jobsFile())
+	if err != nil {
+		return RenderResult{}, err
 	}
 
-	// Generate worker_gen.go
-	workerPath := filepath.Join("internal", "delivery", "worker", "worker_gen.go")
-	if err := generateWorkerFile(jobs, workerPath); err != nil {
-		return fmt.Errorf("error generating worker file: %w", err)
+	workerFile, err := renderFile(p, generateWorkerFile(pkg, jobs), workerGenFile())
+	if err != nil {
+		return RenderResult{}, err
 	}
 
-	fmt.Printf("Job registry generated successfully\n")
-	fmt.Printf("  - jobs.go: %s\n", jobsPath)
-	fmt.Printf("  - worker_gen.go: %s\n", workerPath)
-	fmt.Printf("Registered %d jobs:\n", len(jobs))
+	result := RenderResult{Files: []GeneratedFile{jobsFile, workerFile}}
 	for _, job := range jobs {
-		fmt.Printf("  - %s\n", job.Name)
+		result.Items = append(result.Items, job.Name)
 	}
+	return result, nil
+}
+
+// jobRegistryFiles are the files the job registry generates among the jobs.
+var jobRegistryFiles = []string{"jobs.go", "worker_gen.go"}
 
-	return nil
+// jobPackage returns the package of the jobs in dir; the base name of dir
+// when there are none yet.
+func jobPackage(dir string) (string, error) {
+	files, err := sourceFiles(dir, jobRegistryFiles...)
+	if err != nil {
+		return "", err
+	}
+	for _, path := range files {
+		node, err := gosrc.ParseFile(token.NewFileSet(), path, parser.PackageClauseOnly)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return node.Name.Name, nil
+	}
+	return filepath.Base(dir), nil
 }
 
 func discoverJobs(jobsDir string) ([]JobInfo, error) {
-	var jobs []JobInfo
+	files, err := sourceFiles(jobsDir, jobRegistryFiles...)
+	if err != nil {
+		return nil, err
+	}
 
-	err := filepath.Walk(jobsDir, func(path string, info os.FileInfo, err error) error {
+	var jobs []JobInfo
+	var errs []error
+	for _, path := range files {
+		found, err := parseJobFile(path)
 		if err != nil {
-			return err
+			errs = append(errs, err)
+			continue
 		}
+		jobs = append(jobs, found...)
+	}
 
-		if strings.HasSuffix(path, ".go") && !info.IsDir() {
-			job := parseJobFile(path)
-			if job.Name != "" {
-				jobs = append(jobs, job)
-			}
-		}
-		return nil
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// Sort jobs by name for consistent output
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
 	})
 
-	return jobs, err
+	return jobs, nil
 }
 
-func parseJobFile(path string) JobInfo {
+// parseJobFile recognizes jobs by their shape: a <Name>Payload struct, a
+// Handle(ctx context.Context, payload <Name>Payload) error method, the
+// exported <Name> job type and its New<Name> constructor. Files without any
+// of these are helpers and are ignored; files with only part of the shape
+// are reported as malformed.
+func parseJobFile(path string) ([]JobInfo, error) {
 	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, path, nil, parser.AllErrors)
+	node, err := gosrc.ParseFile(fset, path, parser.AllErrors)
 	if err != nil {
-		return JobInfo{}
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
 	}
 
-	jobName := filepath.Base(path)
-	jobName = strings.TrimSuffix(jobName, ".go")
+	payloads := map[string]token.Pos{} // payload struct name => position
+	types := map[string]bool{}
+	funcs := map[string]bool{}
+	handlers := map[string]*ast.FuncDecl{} // payload type name => Handle method
 
-	// Convert filename to PascalCase (e.g., send_email -> SendEmail)
-	parts := strings.Split(jobName, "_")
-	for i, part := range parts {
-		parts[i] = utils.ToPascalCase(part)
-	}
-	baseName := strings.Join(parts, "")
-
-	job := JobInfo{
-		ModuleName: utils.GetModuleName(),
-		BaseName:   baseName,
-		Name:       baseName,
-		VarName:    strings.ToLower(baseName[:1]) + baseName[1:],
-		TaskName:   toTaskName(jobName),
-		Payload:    baseName + "Payload",
-	}
+	var malformed []*ast.FuncDecl
 
-	// Verify this file actually contains a job by looking for type declarations
 	for _, decl := range node.Decls {
-		if g, ok := decl.(*ast.GenDecl); ok && g.Tok == token.TYPE {
-			for _, spec := range g.Specs {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
 				ts := spec.(*ast.TypeSpec)
-				if ts.Name.IsExported() && !strings.HasPrefix(ts.Name.Name, "New") {
-					// Found a valid job type, return the job info
-					return job
+				types[ts.Name.Name] = true
+				if _, ok := ts.Type.(*ast.StructType); ok && strings.HasSuffix(ts.Name.Name, "Payload") && ts.Name.IsExported() {
+					payloads[ts.Name.Name] = ts.Pos()
 				}
 			}
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				funcs[d.Name.Name] = true
+				continue
+			}
+			if d.Name.Name != "Handle" {
+				continue
+			}
+			if payload, ok := handlerPayloadType(d); ok {
+				handlers[payload] = d
+			} else {
+				malformed = append(malformed, d)
+			}
+		}
+	}
+
+	var errs []error
+	for _, fn := range malformed {
+		errs = append(errs, fmt.Errorf("%s: Handle must have signature func(ctx context.Context, payload <Name>Payload) error", fset.Position(fn.Pos())))
+	}
+
+	for payload, fn := range handlers {
+		if _, ok := payloads[payload]; !ok {
+			errs = append(errs, fmt.Errorf("%s: Handle accepts %s but no %s struct is declared in this file", fset.Position(fn.Pos()), payload, payload))
+		}
+	}
+
+	var names []string
+	for payload, pos := range payloads {
+		if _, ok := handlers[payload]; !ok {
+			errs = append(errs, fmt.Errorf("%s: %s has no matching Handle(ctx context.Context, payload %s) error method", fset.Position(pos), payload, payload))
+			continue
+		}
+
+		name := strings.TrimSuffix(payload, "Payload")
+		if !types[name] {
+			errs = append(errs, fmt.Errorf("%s: job type %s is not declared for %s", fset.Position(pos), name, payload))
+			continue
+		}
+		if !funcs["New"+name] {
+			errs = append(errs, fmt.Errorf("%s: constructor New%s is not declared for job %s", fset.Position(pos), name, name))
+			continue
+		}
+		names = append(names, name)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	sort.Strings(names)
+
+	jobs := make([]JobInfo, 0, len(names))
+	for _, name := range names {
+		jobs = append(jobs, JobInfo{
+			BaseName: name,
+			Name:     name,
+			VarName:  strings.ToLower(name[:1]) + name[1:],
+			TaskName: toTaskName(name),
+			Payload:  name + "Payload",
+		})
+	}
+
+	return jobs, nil
+}
+
+// handlerPayloadType reports the payload type name accepted by a Handle method
+// if its signature is func(context.Context, <Name>Payload) error.
+func handlerPayloadType(fn *ast.FuncDecl) (string, bool) {
+	var params []ast.Expr
+	for _, field := range fn.Type.Params.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			params = append(params, field.Type)
 		}
 	}
+	if len(params) != 2 {
+		return "", false
+	}
+
+	sel, ok := params[0].(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Context" {
+		return "", false
+	}
+	if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "context" {
+		return "", false
+	}
+
+	payload, ok := params[1].(*ast.Ident)
+	if !ok || !strings.HasSuffix(payload.Name, "Payload") {
+		return "", false
+	}
+
+	results := fn.Type.Results
+	if results == nil || len(results.List) != 1 || len(results.List[0].Names) > 1 {
+		return "", false
+	}
+	if ident, ok := results.List[0].Type.(*ast.Ident); !ok || ident.Name != "error" {
+		return "", false
+	}
 
-	// No valid job found in this file
-	return JobInfo{}
+	return payload.Name, true
 }
 
 func toTaskName(varName string) string {
@@ -141,11 +268,11 @@ func toTaskName(varName string) string {
 	return strings.ToLower(result.String())
 }
 
-func generateJobsFile(jobs []JobInfo, outputPath string) error {
-	f := jen.NewFile("job")
+func generateJobsFile(pkg string, jobs []JobInfo) *jen.File {
+	f := jen.NewFile(pkg)
 
 	// Add header comment
-	f.Comment("Code generated by vandor; DO NOT EDIT.")
+	f.HeaderComment("Code generated by vandor; DO NOT EDIT.")
 
 	// Add imports
 	f.ImportName("go.uber.org/fx", "fx")
@@ -162,8 +289,8 @@ func generateJobsFile(jobs []JobInfo, outputPath string) error {
 	constructorDict := jen.Dict{}
 
 	for _, job := range jobs {
-		constructorParams = append(constructorParams, jen.Id(job.BaseName).Id(job.BaseName))
-		constructorDict[jen.Id(job.BaseName)] = jen.Id(job.BaseName)
+		constructorParams = append(constructorParams, jen.Id(job.VarName).Id(job.BaseName))
+		constructorDict[jen.Id(job.BaseName)] = jen.Id(job.VarName)
 	}
 
 	// Generate NewJobs constructor
@@ -178,63 +305,32 @@ func generateJobsFile(jobs []JobInfo, outputPath string) error {
 	}
 	moduleProviders = append(moduleProviders, jen.Id("NewJobs"))
 
-	f.Var().Id("Module").Op("=").Qual("fx", "Module").Call(
+	f.Var().Id("Module").Op("=").Qual("go.uber.org/fx", "Module").Call(
 		jen.Lit("job"),
-		jen.Qual("fx", "Provide").Call(moduleProviders...),
+		jen.Qual("go.uber.org/fx", "Provide").Call(moduleProviders...),
 	)
 
-	// Ensure directory exists
-	dir := filepath.Dir(outputPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	// Write file
-	if err := f.Save(outputPath); err != nil {
-		return fmt.Errorf("failed to save jobs file: %w", err)
-	}
-
-	return nil
+	return f
 }
 
-func generateWorkerFile(jobs []JobInfo, outputPath string) error {
-	f := jen.NewFile("worker")
+// generateWorkerFile generates RegisterJobs, which routes the tasks of the
+// worker to the jobs. It is generated with no jobs too, so that the worker
+// keeps building when the last one is removed.
+func generateWorkerFile(pkg string, jobs []JobInfo) *jen.File {
+	f := jen.NewFile(pkg)
 
 	// Add header comment
-	f.Comment("Code generated by vandor; DO NOT EDIT.")
-
-	if len(jobs) == 0 {
-		// Generate empty file
-		f.Comment("No jobs found")
-	} else {
-		moduleName := utils.GetModuleName()
-
-		// Add imports
-		f.ImportName("context", "context")
-		f.ImportName("encoding/json", "json")
-		f.ImportName("log", "log")
-		f.ImportName(moduleName+"/internal/core/job", "job")
-		f.ImportName("github.com/hibiken/asynq", "asynq")
-
-		// Generate RegisterJobs function
-		f.Func().Id("RegisterJobs").Params(
-			jen.Id("mux").Op("*").Qual("asynq", "ServeMux"),
-			jen.Id("jobs").Op("*").Qual("job", "Jobs"),
-		).Block(generateJobRegistrations(jobs)...)
-	}
+	f.HeaderComment("Code generated by vandor; DO NOT EDIT.")
 
-	// Ensure directory exists
-	dir := filepath.Dir(outputPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
+	f.ImportName("github.com/hibiken/asynq", "asynq")
 
-	// Write file
-	if err := f.Save(outputPath); err != nil {
-		return fmt.Errorf("failed to save worker file: %w", err)
-	}
+	// Generate RegisterJobs function
+	f.Func().Id("RegisterJobs").Params(
+		jen.Id("mux").Op("*").Qual("github.com/hibiken/asynq", "ServeMux"),
+		jen.Id("jobs").Op("*").Id("Jobs"),
+	).Block(generateJobRegistrations(jobs)...)
 
-	return nil
+	return f
 }
 
 func generateJobRegistrations(jobs []JobInfo) []jen.Code {
@@ -244,10 +340,10 @@ func generateJobRegistrations(jobs []JobInfo) []jen.Code {
 		// Generate handler function for each job
 		handlerFunc := jen.Func().Params(
 			jen.Id("ctx").Qual("context", "Context"),
-			jen.Id("t").Op("*").Qual("asynq", "Task"),
+			jen.Id("t").Op("*").Qual("github.com/hibiken/asynq", "Task"),
 		).Error().Block(
-			jen.Var().Id("payload").Qual("job", job.Payload),
-			jen.If(jen.Id("err").Op(":=").Qual("json", "Unmarshal").Call(jen.Id("t").Dot("Payload").Call(), jen.Op("&").Id("payload")), jen.Id("err").Op("!=").Nil()).Block(
+			jen.Var().Id("payload").Id(job.Payload),
+			jen.If(jen.Id("err").Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("t").Dot("Payload").Call(), jen.Op("&").Id("payload")), jen.Id("err").Op("!=").Nil()).Block(
 				jen.Return(jen.Id("err")),
 			),
 			jen.Return(jen.Id("jobs").Dot(job.BaseName).Dot("Handle").Call(jen.Id("ctx"), jen.Id("payload"))),
@@ -2,9 +2,6 @@ package generators
 
 import (
 	"fmt"
-	"go/ast"
-	"go/parser"
-	"go/token"
 	"os"
 	"path/filepath"
 	"sort"
@@ -15,205 +12,167 @@ import (
 	"github.com/alfariiizi/vandor-cli/internal/utils"
 )
 
+// ServiceGroup is a directory under internal/core/service and the services
+// declared in it.
+type ServiceGroup struct {
+	Name       string   // Directory name e.g. "user"
+	StructName string   // Group struct e.g. "UserService"
+	Services   []string // Service types e.g. "CreateUser"
+}
+
 func GenerateServiceRegistry() error {
-	serviceRoot := "internal/core/service"
+	return ServiceRegistry.Generate(os.Stdout)
+}
 
-	// Generate group service files first
-	if err := generateGroupServiceFiles(serviceRoot); err != nil {
-		return fmt.Errorf("error generating group service files: %w", err)
+// RenderServiceRegistry renders each group's service.go and the top-level
+// internal/core/service/services.go from a single discovery pass.
+func RenderServiceRegistry(p Project) (RenderResult, error) {
+	groups, err := discoverServiceGroups(p.Path(serviceRootDir()))
+	if err != nil {
+		return RenderResult{}, fmt.Errorf("error discovering services: %w", err)
 	}
 
-	// Then generate main services.go
-	if err := generateMainServiceRegistry(serviceRoot); err != nil {
-		return fmt.Errorf("error generating main service registry: %w", err)
+	var result RenderResult
+	var registered []ServiceGroup
+	for _, group := range groups {
+		output := filepath.Join(serviceRootDir(), group.Name, "service.go")
+		if len(group.Services) == 0 && !keepEmpty(p, output) {
+			continue
+		}
+
+		file, err := renderFile(p, generateGroupServiceFile(group), output)
+		if err != nil {
+			return RenderResult{}, err
+		}
+		result.Files = append(result.Files, file)
+		registered = append(registered, group)
+		for _, service := range group.Services {
+			result.Items = append(result.Items, group.Name+"."+service)
+		}
 	}
 
-	return nil
-}
+	output := filepath.Join(serviceRootDir(), "services.go")
+	if len(registered) == 0 && !keepEmpty(p, output) {
+		return RenderResult{}, nil
+	}
 
-func generateGroupServiceFiles(serviceRoot string) error {
-	groupServiceMap := map[string][]string{}
+	file, err := renderFile(p, generateMainServiceRegistry(registered, p.Module), output)
+	if err != nil {
+		return RenderResult{}, err
+	}
+	result.Files = append(result.Files, file)
 
-	// 1. Walk all group dirs and collect services
-	dirs, err := os.ReadDir(serviceRoot)
+	return result, nil
+}
+
+// discoverServiceGroups returns every service group, sorted by group name
+// with services sorted within each group. A group is a directory of the
+// service root; its services are declared in the files directly in it.
+func discoverServiceGroups(serviceRoot string) ([]ServiceGroup, error) {
+	names, err := groupDirs(serviceRoot)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, dir := range dirs {
-		if !dir.IsDir() {
-			continue
-		}
-		group := dir.Name()
-		groupDir := filepath.Join(serviceRoot, group)
+	var groups []ServiceGroup
 
-		err := filepath.WalkDir(groupDir, func(path string, d os.DirEntry, err error) error {
-			if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), ".go") || d.Name() == "service.go" {
-				return nil
-			}
+	for _, name := range names {
+		group := ServiceGroup{
+			Name:       name,
+			StructName: utils.ToPascalCase(name) + "Service",
+		}
 
-			fset := token.NewFileSet()
-			node, err := parser.ParseFile(fset, path, nil, 0)
+		files, err := sourceFiles(filepath.Join(serviceRoot, name), "service.go")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			found, err := discoverGenericAliases(file)
 			if err != nil {
-				return err
-			}
-
-			for _, decl := range node.Decls {
-				gen, ok := decl.(*ast.GenDecl)
-				if !ok || gen.Tok != token.TYPE {
-					continue
-				}
-				for _, spec := range gen.Specs {
-					typeSpec := spec.(*ast.TypeSpec)
-					if typeSpec.Name.IsExported() {
-						switch typeSpec.Type.(type) {
-						case *ast.IndexExpr, *ast.IndexListExpr:
-							groupServiceMap[group] = append(groupServiceMap[group], typeSpec.Name.Name)
-						}
-					}
-				}
+				return nil, err
 			}
-			return nil
-		})
-		if err != nil {
-			return err
+			group.Services = append(group.Services, found...)
 		}
-	}
 
-	// 2. Generate `service.go` for each group
-	for group, services := range groupServiceMap {
-		if err := generateGroupServiceFile(serviceRoot, group, services); err != nil {
-			return fmt.Errorf("error generating group service file for %s: %w", group, err)
-		}
-		fmt.Printf("Generated group service file for %s with %d services\n", group, len(services))
+		sort.Strings(group.Services)
+		groups = append(groups, group)
 	}
 
-	return nil
-}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
 
-func generateGroupServiceFile(serviceRoot, group string, services []string) error {
-	// Sort services for consistent output
-	sort.Strings(services)
+	return groups, nil
+}
 
-	structName := utils.ToPascalCase(group) + "Service"
-	f := jen.NewFile(group + "_service")
+func generateGroupServiceFile(group ServiceGroup) *jen.File {
+	f := jen.NewFile(group.Name + "_service")
 
 	// Add header comment
-	f.Comment(fmt.Sprintf("Package %s_service provides services for the %s group.", group, group))
-	f.Comment("Code generated by Service Generator. DO NOT EDIT.")
-	f.Comment("Template: https://github.com/alfariiizi/vandor-cli.")
+	f.PackageComment(fmt.Sprintf("Package %s_service provides services for the %s group.", group.Name, group.Name))
+	f.PackageComment("Code generated by Service Generator. DO NOT EDIT.")
+	f.PackageComment("Template: https://github.com/alfariiizi/vandor-cli.")
 
 	// Add imports
 	f.ImportName("go.uber.org/fx", "fx")
 
 	// Generate group service struct
 	serviceFields := []jen.Code{}
-	for _, service := range services {
+	for _, service := range group.Services {
 		serviceFields = append(serviceFields, jen.Id(service).Id(service))
 	}
-	f.Type().Id(structName).Struct(serviceFields...)
+	f.Type().Id(group.StructName).Struct(serviceFields...)
 
 	// Generate constructor parameters
 	constructorParams := []jen.Code{}
 	constructorDict := jen.Dict{}
 
-	for _, service := range services {
+	for _, service := range group.Services {
 		param := strings.ToLower(service[:1]) + service[1:]
 		constructorParams = append(constructorParams, jen.Id(param).Id(service))
 		constructorDict[jen.Id(service)] = jen.Id(param)
 	}
 
 	// Generate constructor
-	f.Func().Id("New" + structName).Params(constructorParams...).Id(structName).Block(
-		jen.Return(jen.Id(structName).Values(constructorDict)),
+	f.Func().Id("New" + group.StructName).Params(constructorParams...).Id(group.StructName).Block(
+		jen.Return(jen.Id(group.StructName).Values(constructorDict)),
 	)
 
 	// Generate fx.Module with providers
 	moduleProviders := []jen.Code{}
-	for _, service := range services {
+	for _, service := range group.Services {
 		moduleProviders = append(moduleProviders, jen.Id("New"+service))
 	}
-	moduleProviders = append(moduleProviders, jen.Id("New"+structName))
+	moduleProviders = append(moduleProviders, jen.Id("New"+group.StructName))
 
-	f.Var().Id("Module").Op("=").Qual("fx", "Module").Call(
-		jen.Lit(group+"_service"),
-		jen.Qual("fx", "Provide").Call(moduleProviders...),
+	f.Var().Id("Module").Op("=").Qual("go.uber.org/fx", "Module").Call(
+		jen.Lit(group.Name+"_service"),
+		jen.Qual("go.uber.org/fx", "Provide").Call(moduleProviders...),
 	)
 
-	// Write file
-	outputPath := filepath.Join(serviceRoot, group, "service.go")
-	if err := f.Save(outputPath); err != nil {
-		return fmt.Errorf("failed to save group service file: %w", err)
-	}
-
-	return nil
+	return f
 }
 
-func generateMainServiceRegistry(serviceRoot string) error {
-	groupStructMap := map[string]string{}
-
-	dirs, err := os.ReadDir(serviceRoot)
-	if err != nil {
-		return err
-	}
-
-	for _, dir := range dirs {
-		if !dir.IsDir() {
-			continue
-		}
-		group := dir.Name()
-		serviceFile := filepath.Join(serviceRoot, group, "service.go")
-
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, serviceFile, nil, 0)
-		if err != nil {
-			continue
-		}
-
-		for _, decl := range node.Decls {
-			gen, ok := decl.(*ast.GenDecl)
-			if !ok || gen.Tok != token.TYPE {
-				continue
-			}
-			for _, spec := range gen.Specs {
-				typeSpec := spec.(*ast.TypeSpec)
-				if typeSpec.Name.IsExported() && strings.HasSuffix(typeSpec.Name.Name, "Service") {
-					groupStructMap[group] = typeSpec.Name.Name
-				}
-			}
-		}
-	}
-
-	// Generate main services.go
-	moduleName := utils.GetModuleName()
+func generateMainServiceRegistry(groups []ServiceGroup, moduleName string) *jen.File {
 	f := jen.NewFile("service")
 
 	// Add header comment
-	f.Comment("Package service provides all services for the application.")
-	f.Comment("Code generated by Service Generator. DO NOT EDIT.")
-	f.Comment("Template: https://github.com/alfariiizi/vandor-cli.")
+	f.PackageComment("Package service provides all services for the application.")
+	f.PackageComment("Code generated by Service Generator. DO NOT EDIT.")
+	f.PackageComment("Template: https://github.com/alfariiizi/vandor-cli.")
 
 	// Add imports
 	f.ImportName("go.uber.org/fx", "fx")
-
-	// Sort groups for consistent output
-	var sortedGroups []string
-	for group := range groupStructMap {
-		sortedGroups = append(sortedGroups, group)
-	}
-	sort.Strings(sortedGroups)
-
-	// Add group imports
-	for _, group := range sortedGroups {
-		f.ImportAlias(moduleName+"/internal/core/service/"+group, group+"_service")
+	for _, group := range groups {
+		f.ImportAlias(importPath(moduleName, serviceRootDir(), group.Name), group.Name+"_service")
 	}
 
 	// Generate Services struct
 	serviceFields := []jen.Code{}
-	for _, group := range sortedGroups {
-		structName := groupStructMap[group]
+	for _, group := range groups {
+		groupPath := importPath(moduleName, serviceRootDir(), group.Name)
 		serviceFields = append(serviceFields,
-			jen.Id(utils.ToPascalCase(group)).Qual(group+"_service", structName),
+			jen.Id(utils.ToPascalCase(group.Name)).Qual(groupPath, group.StructName),
 		)
 	}
 	f.Type().Id("Services").Struct(serviceFields...)
@@ -222,13 +181,13 @@ func generateMainServiceRegistry(serviceRoot string) error {
 	constructorParams := []jen.Code{}
 	constructorDict := jen.Dict{}
 
-	for _, group := range sortedGroups {
-		structName := groupStructMap[group]
-		param := strings.ToLower(group)
+	for _, group := range groups {
+		groupPath := importPath(moduleName, serviceRootDir(), group.Name)
+		param := utils.ToCamelCase(group.Name)
 		constructorParams = append(constructorParams,
-			jen.Id(param).Qual(group+"_service", structName),
+			jen.Id(param).Qual(groupPath, group.StructName),
 		)
-		constructorDict[jen.Id(utils.ToPascalCase(group))] = jen.Id(param)
+		constructorDict[jen.Id(utils.ToPascalCase(group.Name))] = jen.Id(param)
 	}
 
 	// Generate NewServices constructor
@@ -237,28 +196,16 @@ func generateMainServiceRegistry(serviceRoot string) error {
 	)
 
 	// Generate fx.Module with group modules
-	moduleProviders := []jen.Code{jen.Id("NewServices")}
-	for _, group := range sortedGroups {
-		moduleProviders = append(moduleProviders, jen.Qual(group+"_service", "Module"))
+	groupModules := []jen.Code{}
+	for _, group := range groups {
+		groupModules = append(groupModules, jen.Qual(importPath(moduleName, serviceRootDir(), group.Name), "Module"))
 	}
 
-	f.Var().Id("Module").Op("=").Qual("fx", "Module").Call(
+	f.Var().Id("Module").Op("=").Qual("go.uber.org/fx", "Module").Call(
 		jen.Lit("service"),
-		jen.Qual("fx", "Provide").Call(jen.Id("NewServices")),
-		jen.Qual("fx", "Options").Call(moduleProviders[1:]...),
+		jen.Qual("go.uber.org/fx", "Provide").Call(jen.Id("NewServices")),
+		jen.Qual("go.uber.org/fx", "Options").Call(groupModules...),
 	)
 
-	// Write file
-	outputPath := filepath.Join(serviceRoot, "services.go")
-	if err := f.Save(outputPath); err != nil {
-		return fmt.Errorf("failed to save main service registry: %w", err)
-	}
-
-	fmt.Printf("Service registry generated successfully at %s\n", outputPath)
-	fmt.Printf("Registered %d service groups:\n", len(sortedGroups))
-	for _, group := range sortedGroups {
-		fmt.Printf("  - %s (%s)\n", group, groupStructMap[group])
-	}
-
-	return nil
+	return f
 }
@@ -0,0 +1,305 @@
+package generators
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// goldenDir holds the goldens, found from tests that change directory.
+var goldenDir, _ = filepath.Abs(filepath.Join("testdata", "golden"))
+
+func TestRegistryGolden(t *testing.T) {
+	project := Project{Root: filepath.Join("testdata", "project"), Module: "example.com/app"}
+
+	registries := []RegistryGenerator{DomainRegistry, UsecaseRegistry, ServiceRegistry, JobRegistry, HandlerRegistry}
+	for _, registry := range registries {
+		t.Run(registry.Name, func(t *testing.T) {
+			result, err := registry.Render(project)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			if len(result.Files) == 0 {
+				t.Fatal("expected generated files")
+			}
+
+			compareGolden(t, "", result.Files)
+
+			// Goldens must hold code that builds with the project it was
+			// generated from
+			typeCheck(t, project, result.Files)
+
+			// Output must not depend on map iteration or directory order
+			for i := 0; i < 5; i++ {
+				again, err := registry.Render(project)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for j := range result.Files {
+					if !bytes.Equal(result.Files[j].Content, again.Files[j].Content) {
+						t.Fatalf("%s is not deterministic", result.Files[j].Path)
+					}
+				}
+			}
+		})
+	}
+}
+
+// compareGolden compares files with their goldens in testdata/golden/dir, or
+// writes the goldens with -update.
+func compareGolden(t *testing.T, dir string, files []GeneratedFile) {
+	t.Helper()
+	for _, file := range files {
+		golden := filepath.Join(goldenDir, dir, file.Path+".golden")
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(golden, file.Content, 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("read golden (run with -update to create): %v", err)
+		}
+		if !bytes.Equal(file.Content, want) {
+			t.Errorf("%s does not match %s\n--- got ---\n%s", file.Path, golden, file.Content)
+		}
+	}
+}
+
+// typeCheck type-checks the packages of a project with files written over
+// it, without touching the disk.
+func typeCheck(t *testing.T, p Project, files []GeneratedFile) {
+	t.Helper()
+	defer vfs.Use(vfs.NewOverlay(vfs.OS{}))()
+	if _, err := WriteFiles(p, files); err != nil {
+		t.Fatal(err)
+	}
+	if err := gosrc.NewChecker(p.Root, p.Module).CheckProject(); err != nil {
+		t.Errorf("generated code does not type-check:\n%v", err)
+	}
+}
+
+// typeCheckSynced type-checks a project with files written over it and its
+// usecase, service and handler registries synced with them, without touching
+// the disk.
+func typeCheckSynced(t *testing.T, p Project, files []GeneratedFile) {
+	t.Helper()
+	defer vfs.Use(vfs.NewOverlay(vfs.OS{}))()
+	if _, err := WriteFiles(p, files); err != nil {
+		t.Fatal(err)
+	}
+	for _, registry := range []RegistryGenerator{UsecaseRegistry, ServiceRegistry, HandlerRegistry} {
+		result, err := registry.Render(p)
+		if err != nil {
+			t.Fatalf("render %s registry: %v", registry.Name, err)
+		}
+		if _, err := WriteFiles(p, result.Files); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := gosrc.NewChecker(p.Root, p.Module).CheckProject(); err != nil {
+		t.Errorf("generated code does not type-check:\n%v", err)
+	}
+}
+
+func TestJobRegistryTypeChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		project Project
+	}{
+		{"jobs", Project{Root: filepath.Join("testdata", "project"), Module: "example.com/app"}},
+		{"no jobs left", writeProject(t, map[string]string{
+			"internal/delivery/worker/job/jobs.go":       "package job\n",
+			"internal/delivery/worker/job/worker_gen.go": "package job\n",
+		})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := JobRegistry.Render(tt.project)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			typeCheck(t, tt.project, result.Files)
+		})
+	}
+}
+
+func TestJobDiscoveryRejectsMalformedJobs(t *testing.T) {
+	dir := t.TempDir()
+	src := `package job
+
+type BrokenPayload struct{}
+
+type broken struct{}
+
+func (b *broken) Handle(payload BrokenPayload) error { return nil }
+`
+	if err := os.WriteFile(filepath.Join(dir, "broken.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := discoverJobs(dir); err == nil {
+		t.Fatal("expected an error for a job with a malformed Handle signature")
+	}
+}
+
+// writeProject writes files, by slash-separated project-relative path, into a
+// temporary project and returns it.
+func writeProject(t *testing.T, files map[string]string) Project {
+	t.Helper()
+	root := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return Project{Root: root, Module: "example.com/app"}
+}
+
+const (
+	usecaseSrc = `package usecase
+
+import "example.com/app/internal/core/model"
+
+type %[1]s model.Usecase[struct{}, struct{}]
+`
+	serviceSrc = `package user_service
+
+import "example.com/app/internal/core/model"
+
+type %[1]s model.Service[struct{}, struct{}]
+`
+	domainSrc = `package domain
+
+import "example.com/app/internal/infrastructure/db"
+
+type %[1]s struct {
+	*db.%[1]s
+}
+
+func New%[1]sDomain() {}
+`
+	jobSrc = `package job
+
+import (
+	"context"
+
+	"example.com/app/internal/core/model"
+)
+
+type %[1]sPayload struct{}
+
+type %[1]s model.Job[%[1]sPayload]
+
+type job%[1]s struct{}
+
+func New%[1]s() %[1]s { return &job%[1]s{} }
+
+func (j *job%[1]s) Handle(ctx context.Context, payload %[1]sPayload) error { return nil }
+`
+	handlerSrc = `package user_handler
+
+func New%[1]s() {}
+`
+)
+
+func TestDiscoveryReadsTopLevelSourceFilesOnly(t *testing.T) {
+	tests := []struct {
+		registry RegistryGenerator
+		dir      string // Component directory, slash-separated
+		src      string // Source declaring the component named by %[1]s
+		want     string
+	}{
+		{UsecaseRegistry, "internal/core/usecase", usecaseSrc, "CreateUser"},
+		{ServiceRegistry, "internal/core/service/user", serviceSrc, "user.CreateUser"},
+		{DomainRegistry, "internal/core/domain/model", domainSrc, "CreateUser"},
+		{JobRegistry, "internal/delivery/worker/job", jobSrc, "CreateUser"},
+		{HandlerRegistry, "internal/delivery/http/route/user", handlerSrc, "user.NewCreateUser"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.registry.Name, func(t *testing.T) {
+			p := writeProject(t, map[string]string{
+				tt.dir + "/create_user.go":         fmt.Sprintf(tt.src, "CreateUser"),
+				tt.dir + "/fake_test.go":           fmt.Sprintf(tt.src, "FakeUser"),
+				tt.dir + "/nested/nested_user.go":  fmt.Sprintf(tt.src, "NestedUser"),
+				tt.dir + "/nested/deeper/other.go": fmt.Sprintf(tt.src, "DeeperUser"),
+			})
+
+			result, err := tt.registry.Render(p)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			if len(result.Items) != 1 || result.Items[0] != tt.want {
+				t.Errorf("registered %v, want [%s]", result.Items, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmptyRegistries(t *testing.T) {
+	tests := []struct {
+		registry RegistryGenerator
+		outputs  []string // Files the registry generated before its components were removed
+	}{
+		{UsecaseRegistry, []string{"internal/core/usecase/usecases.go"}},
+		{ServiceRegistry, []string{"internal/core/service/services.go", "internal/core/service/user/service.go"}},
+		{DomainRegistry, []string{"internal/core/domain/domain.go"}},
+		{JobRegistry, []string{"internal/delivery/worker/job/jobs.go", "internal/delivery/worker/job/worker_gen.go"}},
+		{HandlerRegistry, []string{"internal/delivery/http/route/routes.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.registry.Name+"/never generated", func(t *testing.T) {
+			result, err := tt.registry.Render(writeProject(t, nil))
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			if len(result.Files) != 0 {
+				t.Errorf("rendered %d file(s) for a project without %ss", len(result.Files), tt.registry.Name)
+			}
+		})
+
+		t.Run(tt.registry.Name+"/emptied", func(t *testing.T) {
+			files := map[string]string{}
+			for _, output := range tt.outputs {
+				files[output] = "package stale\n"
+			}
+			result, err := tt.registry.Render(writeProject(t, files))
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+
+			var rendered []string
+			for _, file := range result.Files {
+				rendered = append(rendered, filepath.ToSlash(file.Path))
+			}
+			sort.Strings(rendered)
+			want := append([]string(nil), tt.outputs...)
+			sort.Strings(want)
+			if strings.Join(rendered, " ") != strings.Join(want, " ") {
+				t.Errorf("rendered %v, want the stale registry %v regenerated", rendered, want)
+			}
+			if len(result.Items) != 0 {
+				t.Errorf("registered %v, want nothing", result.Items)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package generators
 import (
 	"fmt"
 	"go/ast"
-	"go/parser"
 	"go/token"
 	"os"
 	"path/filepath"
@@ -11,41 +10,37 @@ import (
 	"strings"
 
 	"github.com/dave/jennifer/jen"
+
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
 )
 
 func GenerateUsecaseRegistry() error {
-	usecaseDir := "internal/core/usecase"
+	return UsecaseRegistry.Generate(os.Stdout)
+}
 
-	// Discover usecases
-	usecases, err := discoverUsecases(usecaseDir)
+// RenderUsecaseRegistry renders internal/core/usecase/usecases.go from the
+// usecases declared in internal/core/usecase.
+func RenderUsecaseRegistry(p Project) (RenderResult, error) {
+	usecases, err := discoverUsecases(p.Path(usecaseDir()))
 	if err != nil {
-		return fmt.Errorf("error discovering usecases: %w", err)
+		return RenderResult{}, fmt.Errorf("error discovering usecases: %w", err)
 	}
 
-	if len(usecases) == 0 {
-		fmt.Println("No usecases found in", usecaseDir)
-		return nil
+	output := filepath.Join(usecaseDir(), "usecases.go")
+	if len(usecases) == 0 && !keepEmpty(p, output) {
+		return RenderResult{}, nil
 	}
 
-	// Sort for consistent output
-	sort.Strings(usecases)
-
-	// Generate usecases.go
-	registryPath := filepath.Join(usecaseDir, "usecases.go")
-	if err := generateUsecaseRegistryFile(usecases, registryPath); err != nil {
-		return fmt.Errorf("error generating usecase registry: %w", err)
+	file, err := renderFile(p, generateUsecaseRegistryFile(usecases), output)
+	if err != nil {
+		return RenderResult{}, err
 	}
 
-	fmt.Printf("Usecase registry generated successfully at %s\n", registryPath)
-	fmt.Printf("Registered %d usecases:\n", len(usecases))
-	for _, usecase := range usecases {
-		fmt.Printf("  - %s\n", usecase)
-	}
-	return nil
+	return RenderResult{Files: []GeneratedFile{file}, Items: usecases}, nil
 }
 
-func discoverUsecases(usecaseDir string) ([]string, error) {
-	files, err := os.ReadDir(usecaseDir)
+func discoverUsecases(dir string) ([]string, error) {
+	files, err := sourceFiles(dir, "usecases.go")
 	if err != nil {
 		return nil, err
 	}
@@ -53,46 +48,57 @@ func discoverUsecases(usecaseDir string) ([]string, error) {
 	var usecases []string
 
 	for _, file := range files {
-		if file.IsDir() || file.Name() == "usecases.go" || !strings.HasSuffix(file.Name(), ".go") {
-			continue
-		}
-
-		path := filepath.Join(usecaseDir, file.Name())
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, path, nil, 0)
+		found, err := discoverGenericAliases(file)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+			return nil, err
 		}
+		usecases = append(usecases, found...)
+	}
+
+	// Sort for consistent output
+	sort.Strings(usecases)
 
-		for _, decl := range node.Decls {
-			gen, ok := decl.(*ast.GenDecl)
-			if !ok || gen.Tok != token.TYPE {
+	return usecases, nil
+}
+
+// discoverGenericAliases returns the exported types in a file that are
+// instantiations of a generic type, e.g. `type CreateUser model.Usecase[I, O]`.
+// This is how usecases and services are declared, and it keeps their
+// input/output structs out of the registries.
+func discoverGenericAliases(path string) ([]string, error) {
+	fset := token.NewFileSet()
+	node, err := gosrc.ParseFile(fset, path, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var names []string
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if !typeSpec.Name.IsExported() {
 				continue
 			}
-			for _, spec := range gen.Specs {
-				typeSpec := spec.(*ast.TypeSpec)
-				if !typeSpec.Name.IsExported() {
-					continue
-				}
-				// Filter only aliases to model.Usecase[...] (not structs like LoginInput)
-				switch typeSpec.Type.(type) {
-				case *ast.IndexExpr, *ast.IndexListExpr:
-					usecases = append(usecases, typeSpec.Name.Name)
-				}
+			switch typeSpec.Type.(type) {
+			case *ast.IndexExpr, *ast.IndexListExpr:
+				names = append(names, typeSpec.Name.Name)
 			}
 		}
 	}
-
-	return usecases, nil
+	return names, nil
 }
 
-func generateUsecaseRegistryFile(usecases []string, outputPath string) error {
+func generateUsecaseRegistryFile(usecases []string) *jen.File {
 	f := jen.NewFile("usecase")
 
 	// Add header comment
-	f.Comment("Package usecase provide usecases for the application.")
-	f.Comment("Code generated by Usecase Generator. DO NOT EDIT.")
-	f.Comment("Template: https://github.com/alfariiizi/vandor-cli.")
+	f.PackageComment("Package usecase provide usecases for the application.")
+	f.PackageComment("Code generated by Usecase Generator. DO NOT EDIT.")
+	f.PackageComment("Template: https://github.com/alfariiizi/vandor-cli.")
 
 	// Add imports
 	f.ImportName("go.uber.org/fx", "fx")
@@ -126,21 +132,10 @@ func generateUsecaseRegistryFile(usecases []string, outputPath string) error {
 	}
 	moduleProviders = append(moduleProviders, jen.Id("NewUsecases"))
 
-	f.Var().Id("Module").Op("=").Qual("fx", "Module").Call(
+	f.Var().Id("Module").Op("=").Qual("go.uber.org/fx", "Module").Call(
 		jen.Lit("usecase"),
-		jen.Qual("fx", "Provide").Call(moduleProviders...),
+		jen.Qual("go.uber.org/fx", "Provide").Call(moduleProviders...),
 	)
 
-	// Ensure directory exists
-	dir := filepath.Dir(outputPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
-	}
-
-	// Write file
-	if err := f.Save(outputPath); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
-	}
-
-	return nil
+	return f
 }
@@ -2,13 +2,14 @@ package generators
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
 
+	"github.com/alfariiizi/vandor-cli/internal/naming"
 	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
 type SchedulerData struct {
@@ -18,12 +19,11 @@ type SchedulerData struct {
 }
 
 func GenerateScheduler(schedulerName string) error {
-	if schedulerName == "" {
-		return fmt.Errorf("scheduler name cannot be empty")
+	name, err := naming.Component("scheduler", schedulerName)
+	if err != nil {
+		return err
 	}
-
-	// Capitalize first letter for PascalCase
-	schedulerName = strings.ToUpper(schedulerName[:1]) + schedulerName[1:]
+	schedulerName = name.Pascal
 
 	data := SchedulerData{
 		ModuleName:   utils.GetModuleName(),
@@ -31,24 +31,20 @@ func GenerateScheduler(schedulerName string) error {
 		FunctionName: "Register" + schedulerName + "Job",
 	}
 
-	// Check if scheduler already exists
-	schedulerPath := filepath.Join("internal", "cron", "scheduler", strings.ToLower(schedulerName)+".go")
-	if _, err := os.Stat(schedulerPath); err == nil {
-		return fmt.Errorf("scheduler %s already exists at %s", schedulerName, schedulerPath)
-	}
+	schedulerPath := filepath.Join(schedulerDir(), strings.ToLower(schedulerName)+".go")
 
 	// Generate scheduler file
 	f := generateSchedulerFile(data)
 
 	// Ensure directory exists
 	dir := filepath.Dir(schedulerPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := vfs.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	// Write file
-	if err := f.Save(schedulerPath); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+	if written, err := createFile(f, schedulerPath, data.ModuleName); !written || err != nil {
+		return err
 	}
 
 	fmt.Printf("Scheduler %s created successfully at %s\n", schedulerName, schedulerPath)
@@ -61,7 +57,7 @@ func generateSchedulerFile(data SchedulerData) *jen.File {
 	// Add imports
 	f.ImportName("context", "context")
 	f.ImportName("log", "log")
-	f.ImportName(data.ModuleName+"/internal/core/job", "job")
+	f.ImportName(importPath(data.ModuleName, workerJobDir()), "job")
 	f.ImportName(data.ModuleName+"/internal/cron/init", "cron")
 
 	// Generate scheduler registration function
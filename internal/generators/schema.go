@@ -0,0 +1,728 @@
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+
+	"github.com/alfariiizi/vandor-cli/internal/entschema"
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Import paths of the ent schema builders.
+const (
+	entPath      = "entgo.io/ent"
+	entFieldPath = "entgo.io/ent/schema/field"
+	entEdgePath  = "entgo.io/ent/schema/edge"
+	entIndexPath = "entgo.io/ent/schema/index"
+	entMixinPath = "entgo.io/ent/schema/mixin"
+	uuidPath     = "github.com/google/uuid"
+)
+
+// SchemaSpec describes an ent schema to create, or the additions to an
+// existing one.
+type SchemaSpec struct {
+	Name    string // Schema type e.g. "User"
+	Fields  []SchemaFieldSpec
+	Edges   []SchemaEdgeSpec
+	Indexes []SchemaIndexSpec
+	Mixins  []string // "timestamps" and/or "soft-delete"
+}
+
+// SchemaFieldSpec is a field given as name:type[:modifier...], e.g.
+// "email:string:unique" or "role:enum:values=admin|member:default=member".
+type SchemaFieldSpec struct {
+	Name      string
+	Type      string // Key of schemaFieldTypes
+	Optional  bool
+	Nillable  bool
+	Unique    bool
+	Immutable bool
+	Sensitive bool
+	Index     bool
+	Default   string
+	Values    []string // Enum values
+}
+
+// SchemaEdgeSpec is an edge given as name:Type[:modifier...], e.g.
+// "owner:User:required". Edges are unique (belongs-to) unless "many" is set;
+// "ref=<edge>" declares the inverse of an edge of Type and "field" binds a
+// <name>_id foreign key field.
+type SchemaEdgeSpec struct {
+	Name     string
+	Type     string
+	Required bool
+	Many     bool
+	Ref      string
+	Field    bool
+}
+
+// SchemaIndexSpec is an index given as field[,field...][:unique].
+type SchemaIndexSpec struct {
+	Fields []string
+	Unique bool
+}
+
+// schemaFieldTypes maps field spec types to ent field builders.
+var schemaFieldTypes = map[string]string{
+	"string":  "String",
+	"text":    "Text",
+	"enum":    "Enum",
+	"bool":    "Bool",
+	"int":     "Int",
+	"int8":    "Int8",
+	"int16":   "Int16",
+	"int32":   "Int32",
+	"int64":   "Int64",
+	"uint":    "Uint",
+	"uint8":   "Uint8",
+	"uint16":  "Uint16",
+	"uint32":  "Uint32",
+	"uint64":  "Uint64",
+	"float":   "Float",
+	"float32": "Float32",
+	"time":    "Time",
+	"uuid":    "UUID",
+	"bytes":   "Bytes",
+	"strings": "Strings",
+}
+
+// schemaMixins are the mixins add schema can apply.
+var schemaMixins = map[string]bool{"timestamps": true, "soft-delete": true}
+
+// softDeleteMixinFile declares the mixin used by the soft-delete option.
+var softDeleteMixinFile = filepath.Join(entschema.Dir, "mixin_soft_delete.go")
+
+// ParseSchemaField parses a field spec like "email:string:unique".
+func ParseSchemaField(spec string) (SchemaFieldSpec, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || parts[0] == "" {
+		return SchemaFieldSpec{}, fmt.Errorf("invalid field %q: expected name:type[:modifier...]", spec)
+	}
+
+	field := SchemaFieldSpec{Name: utils.ToSnakeCase(parts[0]), Type: strings.ToLower(parts[1])}
+	if _, ok := schemaFieldTypes[field.Type]; !ok {
+		return SchemaFieldSpec{}, fmt.Errorf("invalid field %q: unknown type %q", spec, parts[1])
+	}
+
+	for _, modifier := range parts[2:] {
+		key, value, _ := strings.Cut(modifier, "=")
+		switch key {
+		case "optional":
+			field.Optional = true
+		case "nillable":
+			field.Nillable = true
+		case "unique":
+			field.Unique = true
+		case "immutable":
+			field.Immutable = true
+		case "sensitive":
+			field.Sensitive = true
+		case "index":
+			field.Index = true
+		case "default":
+			field.Default = value
+		case "values":
+			field.Values = strings.Split(value, "|")
+		default:
+			return SchemaFieldSpec{}, fmt.Errorf("invalid field %q: unknown modifier %q", spec, modifier)
+		}
+	}
+
+	if field.Type == "enum" && len(field.Values) == 0 {
+		return SchemaFieldSpec{}, fmt.Errorf("invalid field %q: enum fields need values=a|b", spec)
+	}
+	if field.Default != "" {
+		if _, err := fieldDefault(field); err != nil {
+			return SchemaFieldSpec{}, fmt.Errorf("invalid field %q: %w", spec, err)
+		}
+	}
+	return field, nil
+}
+
+// ParseSchemaEdge parses an edge spec like "owner:User:required".
+func ParseSchemaEdge(spec string) (SchemaEdgeSpec, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return SchemaEdgeSpec{}, fmt.Errorf("invalid edge %q: expected name:Type[:modifier...]", spec)
+	}
+
+	edge := SchemaEdgeSpec{Name: utils.ToSnakeCase(parts[0]), Type: utils.ToPascalCase(parts[1])}
+	for _, modifier := range parts[2:] {
+		key, value, _ := strings.Cut(modifier, "=")
+		switch key {
+		case "required":
+			edge.Required = true
+		case "many":
+			edge.Many = true
+		case "ref":
+			edge.Ref = value
+		case "field":
+			edge.Field = true
+		default:
+			return SchemaEdgeSpec{}, fmt.Errorf("invalid edge %q: unknown modifier %q", spec, modifier)
+		}
+	}
+
+	if edge.Field && edge.Many {
+		return SchemaEdgeSpec{}, fmt.Errorf("invalid edge %q: only unique edges can bind a field", spec)
+	}
+	return edge, nil
+}
+
+// ParseSchemaIndex parses an index spec like "first_name,last_name:unique".
+func ParseSchemaIndex(spec string) (SchemaIndexSpec, error) {
+	fields, modifier, _ := strings.Cut(spec, ":")
+	if fields == "" || (modifier != "" && modifier != "unique") {
+		return SchemaIndexSpec{}, fmt.Errorf("invalid index %q: expected field[,field...][:unique]", spec)
+	}
+
+	index := SchemaIndexSpec{Unique: modifier == "unique"}
+	for _, field := range strings.Split(fields, ",") {
+		index.Fields = append(index.Fields, utils.ToSnakeCase(strings.TrimSpace(field)))
+	}
+	return index, nil
+}
+
+// ValidateSchemaMixin checks a mixin name given to add schema.
+func ValidateSchemaMixin(name string) error {
+	if !schemaMixins[name] {
+		return fmt.Errorf("unknown mixin %q: expected timestamps or soft-delete", name)
+	}
+	return nil
+}
+
+// GenerateSchema creates an ent schema in database/schema, or adds the
+// spec's fields, edges, indexes and mixins to the schema when it exists. It
+// reports whether an existing schema was updated.
+func GenerateSchema(spec SchemaSpec) (bool, error) {
+	if spec.Name == "" {
+		return false, fmt.Errorf("schema name cannot be empty")
+	}
+	spec.Name = utils.ToPascalCase(spec.Name)
+
+	schemas, err := entschema.Load(entschema.Dir)
+	if err != nil && vfs.Exists(entschema.Dir) {
+		return false, err
+	}
+
+	for _, edge := range spec.Edges {
+		if _, ok := entschema.Find(schemas, edge.Type); !ok && edge.Type != spec.Name {
+			fmt.Printf("Warning: edge %s refers to %s, which has no schema in %s yet\n", edge.Name, edge.Type, entschema.Dir)
+		}
+	}
+
+	existing, exists := entschema.Find(schemas, spec.Name)
+	if exists {
+		if err := checkSchemaAdditions(existing, spec); err != nil {
+			return false, err
+		}
+	}
+
+	for _, mixin := range spec.Mixins {
+		if mixin == "soft-delete" && !vfs.Exists(softDeleteMixinFile) {
+			if err := saveFile(generateSoftDeleteMixinFile(), softDeleteMixinFile, ""); err != nil {
+				return false, err
+			}
+			fmt.Printf("Mixin SoftDeleteMixin created at %s\n", softDeleteMixinFile)
+		}
+	}
+
+	if exists {
+		path, err := findSchemaFile(spec.Name)
+		if err != nil {
+			return false, err
+		}
+		if err := updateSchemaFile(path, spec, schemas); err != nil {
+			return false, err
+		}
+		fmt.Printf("Schema %s updated at %s\n", spec.Name, path)
+		return true, nil
+	}
+
+	path := filepath.Join(entschema.Dir, strings.ToLower(spec.Name)+".go")
+	if vfs.Exists(path) {
+		return false, fmt.Errorf("%s already exists and does not declare schema %s", path, spec.Name)
+	}
+	if err := saveFile(generateSchemaFile(spec, schemas), path, ""); err != nil {
+		return false, err
+	}
+	fmt.Printf("Schema %s created successfully at %s\n", spec.Name, path)
+	return false, nil
+}
+
+// newSchemaFile returns a file of the schema package that imports the ent
+// builder packages under their own names.
+func newSchemaFile() *jen.File {
+	f := jen.NewFile("schema")
+	f.ImportNames(map[string]string{
+		entPath:      "ent",
+		entFieldPath: "field",
+		entEdgePath:  "edge",
+		entIndexPath: "index",
+		entMixinPath: "mixin",
+		uuidPath:     "uuid",
+	})
+	return f
+}
+
+func generateSchemaFile(spec SchemaSpec, schemas []*entschema.Schema) *jen.File {
+	f := newSchemaFile()
+	name := spec.Name
+
+	f.Commentf("%s holds the schema definition for the %s entity.", name, name)
+	f.Type().Id(name).Struct(jen.Qual(entPath, "Schema"))
+
+	if len(spec.Mixins) > 0 {
+		f.Commentf("Mixin of the %s.", name)
+		f.Func().Params(jen.Id(name)).Id("Mixin").Params().Index().Qual(entPath, "Mixin").Block(
+			jen.Return(jen.Index().Qual(entPath, "Mixin").ValuesFunc(func(g *jen.Group) {
+				for _, mixin := range spec.Mixins {
+					g.Line().Add(mixinCode(mixin))
+				}
+				g.Line()
+			})),
+		)
+	}
+
+	fields, edges, indexes := schemaCode(spec, schemas)
+	schemaMethod(f, name, "Fields", "Field", fields)
+	schemaMethod(f, name, "Edges", "Edge", edges)
+	if len(indexes) > 0 {
+		schemaMethod(f, name, "Indexes", "Index", indexes)
+	}
+	return f
+}
+
+// schemaMethod declares a schema method returning a slice of ent builders,
+// or nil when there are none.
+func schemaMethod(f *jen.File, name, method, elem string, code []jen.Code) {
+	result := jen.Nil()
+	if len(code) > 0 {
+		result = jen.Index().Qual(entPath, elem).ValuesFunc(func(g *jen.Group) {
+			for _, c := range code {
+				g.Line().Add(c)
+			}
+			g.Line()
+		})
+	}
+
+	f.Commentf("%s of the %s.", method, name)
+	f.Func().Params(jen.Id(name)).Id(method).Params().Index().Qual(entPath, elem).Block(jen.Return(result))
+}
+
+// schemaCode returns the field, edge and index builders of a spec. Edges
+// binding a field contribute a foreign key field typed after the target's ID.
+func schemaCode(spec SchemaSpec, schemas []*entschema.Schema) (fields, edges, indexes []jen.Code) {
+	for _, field := range spec.Fields {
+		fields = append(fields, fieldCode(field))
+		if field.Index {
+			indexes = append(indexes, indexCode(SchemaIndexSpec{Fields: []string{field.Name}}))
+		}
+	}
+	for _, edge := range spec.Edges {
+		if edge.Field {
+			idType := "int"
+			if target, ok := entschema.Find(schemas, edge.Type); ok {
+				idType = target.IDType
+			}
+			fields = append(fields, foreignKeyCode(edge, idType))
+		}
+		edges = append(edges, edgeCode(edge))
+	}
+	for _, index := range spec.Indexes {
+		indexes = append(indexes, indexCode(index))
+	}
+	return fields, edges, indexes
+}
+
+func fieldCode(field SchemaFieldSpec) *jen.Statement {
+	builder := schemaFieldTypes[field.Type]
+	args := []jen.Code{jen.Lit(field.Name)}
+	if builder == "UUID" {
+		args = append(args, jen.Qual(uuidPath, "UUID").Values())
+	}
+
+	code := jen.Qual(entFieldPath, builder).Call(args...)
+	if len(field.Values) > 0 {
+		values := make([]jen.Code, len(field.Values))
+		for i, value := range field.Values {
+			values[i] = jen.Lit(value)
+		}
+		code.Dot("Values").Call(values...)
+	}
+	if field.Default != "" {
+		value, _ := fieldDefault(field)
+		code.Dot("Default").Call(value)
+	}
+	for _, modifier := range []struct {
+		set  bool
+		name string
+	}{
+		{field.Optional, "Optional"},
+		{field.Nillable, "Nillable"},
+		{field.Unique, "Unique"},
+		{field.Immutable, "Immutable"},
+		{field.Sensitive, "Sensitive"},
+	} {
+		if modifier.set {
+			code.Dot(modifier.name).Call()
+		}
+	}
+	return code
+}
+
+// fieldDefault returns the Default argument of a field. Time and UUID fields
+// take "now" and "new", which become time.Now and uuid.New.
+func fieldDefault(field SchemaFieldSpec) (jen.Code, error) {
+	value := field.Default
+	switch schemaFieldTypes[field.Type] {
+	case "String", "Text":
+		return jen.Lit(value), nil
+	case "Enum":
+		for _, v := range field.Values {
+			if v == value {
+				return jen.Lit(value), nil
+			}
+		}
+		return nil, fmt.Errorf("default %q is not one of the enum values", value)
+	case "Bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("default %q is not a bool", value)
+		}
+		return jen.Lit(b), nil
+	case "Float", "Float32":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("default %q is not a number", value)
+		}
+		return jen.Op(value), nil
+	case "Time":
+		if value != "now" {
+			return nil, fmt.Errorf("time fields only support default=now")
+		}
+		return jen.Qual("time", "Now"), nil
+	case "UUID":
+		if value != "new" {
+			return nil, fmt.Errorf("uuid fields only support default=new")
+		}
+		return jen.Qual(uuidPath, "New"), nil
+	case "Bytes", "Strings":
+		return nil, fmt.Errorf("%s fields do not support a default", field.Type)
+	}
+
+	if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+		return nil, fmt.Errorf("default %q is not an integer", value)
+	}
+	return jen.Op(value), nil
+}
+
+// foreignKeyCode declares the field an edge binds with .Field.
+func foreignKeyCode(edge SchemaEdgeSpec, idType string) *jen.Statement {
+	builder := "Int"
+	var args []jen.Code
+	switch idType {
+	case "uuid.UUID":
+		builder = "UUID"
+		args = []jen.Code{jen.Qual(uuidPath, "UUID").Values()}
+	case "string":
+		builder = "String"
+	case "int64":
+		builder = "Int64"
+	}
+
+	code := jen.Qual(entFieldPath, builder).Call(append([]jen.Code{jen.Lit(edge.Name + "_id")}, args...)...)
+	if !edge.Required {
+		code.Dot("Optional").Call()
+	}
+	return code
+}
+
+func edgeCode(edge SchemaEdgeSpec) *jen.Statement {
+	target := jen.Id(edge.Type).Dot("Type")
+	var code *jen.Statement
+	if edge.Ref != "" {
+		code = jen.Qual(entEdgePath, "From").Call(jen.Lit(edge.Name), target).Dot("Ref").Call(jen.Lit(edge.Ref))
+	} else {
+		code = jen.Qual(entEdgePath, "To").Call(jen.Lit(edge.Name), target)
+	}
+	if !edge.Many {
+		code.Dot("Unique").Call()
+	}
+	if edge.Field {
+		code.Dot("Field").Call(jen.Lit(edge.Name + "_id"))
+	}
+	if edge.Required {
+		code.Dot("Required").Call()
+	}
+	return code
+}
+
+func indexCode(index SchemaIndexSpec) *jen.Statement {
+	fields := make([]jen.Code, len(index.Fields))
+	for i, field := range index.Fields {
+		fields[i] = jen.Lit(field)
+	}
+	code := jen.Qual(entIndexPath, "Fields").Call(fields...)
+	if index.Unique {
+		code.Dot("Unique").Call()
+	}
+	return code
+}
+
+func mixinCode(name string) *jen.Statement {
+	if name == "soft-delete" {
+		return jen.Id("SoftDeleteMixin").Values()
+	}
+	return jen.Qual(entMixinPath, "Time").Values()
+}
+
+func generateSoftDeleteMixinFile() *jen.File {
+	f := newSchemaFile()
+
+	f.Comment("SoftDeleteMixin adds a delete_time field. Rows are soft-deleted by setting")
+	f.Comment("it instead of being removed; queries should filter on delete_time IS NULL.")
+	f.Type().Id("SoftDeleteMixin").Struct(jen.Qual(entMixinPath, "Schema"))
+
+	f.Comment("Fields of the SoftDeleteMixin.")
+	f.Func().Params(jen.Id("SoftDeleteMixin")).Id("Fields").Params().Index().Qual(entPath, "Field").Block(
+		jen.Return(jen.Index().Qual(entPath, "Field").Values(
+			jen.Line().Qual(entFieldPath, "Time").Call(jen.Lit("delete_time")).Dot("Optional").Call().Dot("Nillable").Call(),
+			jen.Line(),
+		)),
+	)
+
+	f.Comment("Indexes of the SoftDeleteMixin.")
+	f.Func().Params(jen.Id("SoftDeleteMixin")).Id("Indexes").Params().Index().Qual(entPath, "Index").Block(
+		jen.Return(jen.Index().Qual(entPath, "Index").Values(
+			jen.Line().Qual(entIndexPath, "Fields").Call(jen.Lit("delete_time")),
+			jen.Line(),
+		)),
+	)
+
+	return f
+}
+
+// findSchemaFile returns the file in database/schema declaring a type.
+func findSchemaFile(name string) (string, error) {
+	entries, err := vfs.ReadDir(entschema.Dir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		path := filepath.Join(entschema.Dir, entry.Name())
+		file, err := gosrc.ParseFile(token.NewFileSet(), path, 0)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if obj := file.Scope.Lookup(name); obj != nil && obj.Kind == ast.Typ {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("schema %s not found in %s", name, entschema.Dir)
+}
+
+// checkSchemaAdditions rejects fields, edges and mixins an existing schema
+// already has.
+func checkSchemaAdditions(existing *entschema.Schema, spec SchemaSpec) error {
+	names := []string{}
+	for _, field := range spec.Fields {
+		names = append(names, field.Name)
+	}
+	for _, edge := range spec.Edges {
+		if edge.Field {
+			names = append(names, edge.Name+"_id")
+		}
+		for _, e := range existing.Edges {
+			if e.Name == edge.Name {
+				return fmt.Errorf("schema %s already has an edge %s", spec.Name, edge.Name)
+			}
+		}
+	}
+	for _, mixin := range spec.Mixins {
+		switch mixin {
+		case "timestamps":
+			names = append(names, "create_time")
+		case "soft-delete":
+			names = append(names, "delete_time")
+		}
+	}
+
+	for _, name := range names {
+		if _, ok := existing.Field(name); ok {
+			return fmt.Errorf("schema %s already has a field %s", spec.Name, name)
+		}
+	}
+	return nil
+}
+
+// updateSchemaFile adds the spec to the schema declared in path by editing
+// the slice literals its methods return, leaving the rest of the file as it
+// is.
+func updateSchemaFile(path string, spec SchemaSpec, schemas []*entschema.Schema) error {
+	src, err := vfs.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	fields, edges, indexes := schemaCode(spec, schemas)
+	var mixins []jen.Code
+	for _, mixin := range spec.Mixins {
+		mixins = append(mixins, mixinCode(mixin))
+	}
+
+	imports := map[string]bool{}
+	for _, edit := range []struct {
+		method string
+		elem   string
+		code   []jen.Code
+	}{
+		{"Mixin", "Mixin", mixins},
+		{"Fields", "Field", fields},
+		{"Edges", "Edge", edges},
+		{"Indexes", "Index", indexes},
+	} {
+		if len(edit.code) == 0 {
+			continue
+		}
+		var elems []string
+		for _, code := range edit.code {
+			source, paths, err := renderExpr(code)
+			if err != nil {
+				return err
+			}
+			elems = append(elems, source)
+			for _, path := range paths {
+				imports[path] = true
+			}
+		}
+		imports[entPath] = true
+
+		src, err = appendToSchemaMethod(src, spec.Name, edit.method, edit.elem, elems)
+		if err != nil {
+			return err
+		}
+	}
+
+	var paths []string
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	src, err = gosrc.AddImports(src, paths...)
+	if err != nil {
+		return err
+	}
+	content, err := gosrc.Format(src, "")
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	_, err = gosrc.WriteFile(path, content)
+	return err
+}
+
+// renderExpr renders a jennifer expression to source, returning the import
+// paths it refers to.
+func renderExpr(code jen.Code) (string, []string, error) {
+	f := newSchemaFile()
+	f.Var().Id("_").Op("=").Add(code)
+
+	var buf bytes.Buffer
+	if err := f.Render(&buf); err != nil {
+		return "", nil, err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", buf.Bytes(), 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var paths []string
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil && spec.Name.Name != filepath.Base(path) {
+			return "", nil, fmt.Errorf("cannot add %s: it needs an import alias", path)
+		}
+		paths = append(paths, path)
+	}
+
+	value := file.Decls[len(file.Decls)-1].(*ast.GenDecl).Specs[0].(*ast.ValueSpec).Values[0]
+	return string(buf.Bytes()[fset.Position(value.Pos()).Offset:fset.Position(value.End()).Offset]), paths, nil
+}
+
+// appendToSchemaMethod appends elements to the slice literal a schema method
+// returns, declaring the method when the schema has none.
+func appendToSchemaMethod(src []byte, name, method, elem string, elems []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var fn *ast.FuncDecl
+	for _, decl := range file.Decls {
+		d, ok := decl.(*ast.FuncDecl)
+		if !ok || d.Recv == nil || len(d.Recv.List) != 1 || d.Body == nil || d.Name.Name != method {
+			continue
+		}
+		if recv, ok := d.Recv.List[0].Type.(*ast.Ident); ok && recv.Name == name {
+			fn = d
+		}
+	}
+
+	list := "[]ent." + elem + "{\n" + strings.Join(elems, ",\n") + ",\n}"
+	if fn == nil {
+		var out bytes.Buffer
+		out.Write(bytes.TrimRight(src, "\n"))
+		fmt.Fprintf(&out, "\n\n// %s of the %s.\nfunc (%s) %s() []ent.%s {\n\treturn %s\n}\n", method, name, name, method, elem, list)
+		return out.Bytes(), nil
+	}
+
+	var result ast.Expr
+	for _, stmt := range fn.Body.List {
+		if ret, ok := stmt.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			result = ret.Results[0]
+		}
+	}
+
+	var out bytes.Buffer
+	switch r := result.(type) {
+	case *ast.Ident:
+		if r.Name != "nil" {
+			return nil, fmt.Errorf("cannot edit %s.%s: it does not return a slice literal", name, method)
+		}
+		start := fset.Position(r.Pos()).Offset
+		out.Write(src[:start])
+		out.WriteString(list)
+		out.Write(src[fset.Position(r.End()).Offset:])
+	case *ast.CompositeLit:
+		insert := fset.Position(r.Rbrace).Offset
+		text := strings.Join(elems, ",\n") + ",\n"
+		if len(r.Elts) > 0 {
+			last := fset.Position(r.Elts[len(r.Elts)-1].End()).Offset
+			if !bytes.Contains(src[last:insert], []byte(",")) {
+				text = ",\n" + text
+			}
+		} else {
+			text = "\n" + text
+		}
+		out.Write(src[:insert])
+		out.WriteString(text)
+		out.Write(src[insert:])
+	default:
+		return nil, fmt.Errorf("cannot edit %s.%s: it does not return a slice literal", name, method)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,167 @@
+package generators
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/entschema"
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+)
+
+// mustSchemaSpec builds a spec from add schema arguments.
+func mustSchemaSpec(t *testing.T, name string, fields, edges, indexes, mixins []string) SchemaSpec {
+	t.Helper()
+	spec := SchemaSpec{Name: name, Mixins: mixins}
+	for _, arg := range fields {
+		field, err := ParseSchemaField(arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec.Fields = append(spec.Fields, field)
+	}
+	for _, arg := range edges {
+		edge, err := ParseSchemaEdge(arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec.Edges = append(spec.Edges, edge)
+	}
+	for _, arg := range indexes {
+		index, err := ParseSchemaIndex(arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec.Indexes = append(spec.Indexes, index)
+	}
+	return spec
+}
+
+func TestGenerateSchema(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        func(t *testing.T) SchemaSpec
+		wantUpdated bool
+		wantFiles   []string
+		// Fields of the schema read back as CRUD reads it, with the edges
+		wantFields []string
+		wantEdges  []string
+	}{
+		{
+			name: "new schema",
+			spec: func(t *testing.T) SchemaSpec {
+				return mustSchemaSpec(t, "comment",
+					[]string{"body:text", "rating:int:optional:default=5", "state:enum:values=visible|hidden:default=visible", "external_id:uuid:unique:immutable"},
+					[]string{"post:Post:required:field", "author:User:ref=comments"},
+					[]string{"post_id,rating", "external_id:unique"},
+					[]string{"timestamps", "soft-delete"})
+			},
+			wantFiles:  []string{"database/schema/comment.go", "database/schema/mixin_soft_delete.go"},
+			wantFields: []string{"create_time", "update_time", "delete_time", "body", "rating", "state", "external_id", "post_id"},
+			wantEdges:  []string{"post", "author"},
+		},
+		{
+			name: "existing schema",
+			spec: func(t *testing.T) SchemaSpec {
+				return mustSchemaSpec(t, "Post",
+					[]string{"slug:string:unique", "archived:bool:default=false"},
+					[]string{"editor:User:field"},
+					[]string{"slug,status:unique"},
+					[]string{"soft-delete"})
+			},
+			wantUpdated: true,
+			wantFiles:   []string{"database/schema/mixin_soft_delete.go", "database/schema/post.go"},
+			wantFields:  []string{"create_time", "update_time", "delete_time", "title", "body", "status", "views", "published_at", "tags", "edit_token", "slug", "archived", "editor_id"},
+			wantEdges:   []string{"author", "editor"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlay := inApp(t)
+			spec := tt.spec(t)
+
+			updated, err := GenerateSchema(spec)
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+			if updated != tt.wantUpdated {
+				t.Errorf("updated = %v, want %v", updated, tt.wantUpdated)
+			}
+
+			files := written(overlay)
+			var paths []string
+			for _, file := range files {
+				paths = append(paths, filepath.ToSlash(file.Path))
+			}
+			if !slices.Equal(paths, tt.wantFiles) {
+				t.Fatalf("wrote %v, want %v", paths, tt.wantFiles)
+			}
+			compareGolden(t, filepath.Join("schema", strings.ReplaceAll(tt.name, " ", "_")), files)
+
+			if err := gosrc.NewChecker(".", appProject.Module).CheckProject(); err != nil {
+				t.Errorf("schema does not type-check:\n%v", err)
+			}
+
+			schemas, err := entschema.Load(entschema.Dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schema, ok := entschema.Find(schemas, utils.ToPascalCase(spec.Name))
+			if !ok {
+				t.Fatalf("schema %s is not read back", spec.Name)
+			}
+			var fields, edges []string
+			for _, field := range schema.Fields {
+				fields = append(fields, field.Name)
+			}
+			for _, edge := range schema.Edges {
+				edges = append(edges, edge.Name)
+			}
+			if !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("fields read back = %v, want %v", fields, tt.wantFields)
+			}
+			if !slices.Equal(edges, tt.wantEdges) {
+				t.Errorf("edges read back = %v, want %v", edges, tt.wantEdges)
+			}
+		})
+	}
+}
+
+func TestGenerateSchemaRejectsExisting(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    SchemaSpec
+		wantErr string
+	}{
+		{
+			name:    "field",
+			spec:    SchemaSpec{Name: "Post", Fields: []SchemaFieldSpec{{Name: "title", Type: "string"}}},
+			wantErr: "schema Post already has a field title",
+		},
+		{
+			name:    "edge",
+			spec:    SchemaSpec{Name: "User", Edges: []SchemaEdgeSpec{{Name: "posts", Type: "Post", Many: true}}},
+			wantErr: "schema User already has an edge posts",
+		},
+		{
+			name:    "mixin",
+			spec:    SchemaSpec{Name: "Post", Mixins: []string{"timestamps"}},
+			wantErr: "schema Post already has a field create_time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlay := inApp(t)
+			if _, err := GenerateSchema(tt.spec); err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if changes := overlay.Changes(); len(changes) != 0 {
+				t.Errorf("a rejected schema wrote %s", changes[0].Path)
+			}
+		})
+	}
+}
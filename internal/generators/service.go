@@ -2,55 +2,54 @@ package generators
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/dave/jennifer/jen"
 
+	"github.com/alfariiizi/vandor-cli/internal/naming"
 	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
 type ServiceData struct {
-	ModuleName  string // e.g., "github.com/alfariiizi/vandor-cli"
-	Name        string // e.g., "CreateUser"
-	ServiceName string // e.g., "create_user"
-	Receiver    string // e.g., "createUser"
+	ModuleName string // e.g., "github.com/alfariiizi/vandor-cli"
+	Name       string // e.g., "CreateUser"
+	Group      string // e.g., "user"
+	Receiver   string // e.g., "createUser"
 }
 
-func GenerateService(serviceName string) error {
-	if serviceName == "" {
-		return fmt.Errorf("service name cannot be empty")
+func GenerateService(group, serviceName string) error {
+	pkg, err := naming.Package("service group", group)
+	if err != nil {
+		return err
 	}
-
-	// Capitalize first letter for type name
-	serviceName = strings.ToUpper(serviceName[:1]) + serviceName[1:]
+	name, err := naming.Component("service", serviceName)
+	if err != nil {
+		return err
+	}
+	serviceName = name.Pascal
 
 	data := ServiceData{
-		ModuleName:  utils.GetModuleName(),
-		Name:        serviceName,
-		ServiceName: strings.ToLower(serviceName),                       // snake_case for package name
-		Receiver:    strings.ToLower(serviceName[:1]) + serviceName[1:], // camelCase for receiver
+		ModuleName: utils.GetModuleName(),
+		Name:       name.Pascal,
+		Group:      pkg, // snake_case for package name
+		Receiver:   name.Camel,
 	}
 
-	// Check if service already exists
-	servicePath := filepath.Join("internal", "core", "service", strings.ToLower(serviceName)+".go")
-	if _, err := os.Stat(servicePath); err == nil {
-		return fmt.Errorf("service %s already exists at %s", serviceName, servicePath)
-	}
+	servicePath := filepath.Join(serviceRootDir(), data.Group, name.Snake+".go")
 
 	// Generate service file
 	f := generateServiceFile(data)
 
 	// Ensure directory exists
 	dir := filepath.Dir(servicePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := vfs.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	// Write file
-	if err := f.Save(servicePath); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+	if written, err := createFile(f, servicePath, data.ModuleName); !written || err != nil {
+		return err
 	}
 
 	fmt.Printf("Service %s created successfully at %s\n", serviceName, servicePath)
@@ -58,7 +57,7 @@ func GenerateService(serviceName string) error {
 }
 
 func generateServiceFile(data ServiceData) *jen.File {
-	f := jen.NewFile(data.ServiceName + "_service")
+	f := jen.NewFile(data.Group + "_service")
 
 	// Add imports
 	f.ImportName("context", "context")
@@ -169,3 +168,88 @@ func generateServiceFile(data ServiceData) *jen.File {
 
 	return f
 }
+
+// writeService lays out the service scaffold for the name+"Input" and
+// name+"Output" types the caller declares: the service type, the
+// implementation and its constructor, Validate, Execute, Observer and a
+// Process method with the given body.
+func writeService(f *jen.File, module, name string, process []jen.Code) {
+	receiver := utils.ToCamelCase(name)
+	domainPath := module + "/internal/core/domain"
+	modelPath := module + "/internal/core/model"
+	usecasePath := importPath(module, usecaseDir())
+	dbPath := module + "/internal/infrastructure/db"
+	validatorPath := module + "/internal/pkg/validator"
+	loggerPath := module + "/internal/pkg/logger"
+
+	f.Type().Id(name).Qual(modelPath, "Service").Types(jen.Id(name+"Input"), jen.Id(name+"Output"))
+
+	f.Type().Id(receiver).Struct(
+		jen.Id("domain").Op("*").Qual(domainPath, "Domain"),
+		jen.Id("client").Op("*").Qual(dbPath, "Client"),
+		jen.Id("usecase").Op("*").Qual(usecasePath, "Usecases"),
+		jen.Id("validator").Qual(validatorPath, "Validator"),
+	)
+
+	f.Func().Id("New"+name).Params(
+		jen.Id("domain").Op("*").Qual(domainPath, "Domain"),
+		jen.Id("client").Op("*").Qual(dbPath, "Client"),
+		jen.Id("usecase").Op("*").Qual(usecasePath, "Usecases"),
+		jen.Id("validator").Qual(validatorPath, "Validator"),
+	).Id(name).Block(
+		jen.Return(jen.Op("&").Id(receiver).Values(jen.Dict{
+			jen.Id("domain"):    jen.Id("domain"),
+			jen.Id("client"):    jen.Id("client"),
+			jen.Id("usecase"):   jen.Id("usecase"),
+			jen.Id("validator"): jen.Id("validator"),
+		})),
+	)
+
+	f.Func().Params(jen.Id("s").Op("*").Id(receiver)).Id("Validate").Params(
+		jen.Id("input").Id(name + "Input"),
+	).Error().Block(
+		jen.Return(jen.Id("s").Dot("validator").Dot("Validate").Call(jen.Id("input"))),
+	)
+
+	f.Func().Params(jen.Id("s").Op("*").Id(receiver)).Id("Execute").Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("input").Id(name+"Input"),
+	).Params(jen.Op("*").Id(name+"Output"), jen.Error()).Block(
+		jen.If(jen.Id("err").Op(":=").Id("s").Dot("Validate").Call(jen.Id("input")), jen.Id("err").Op("!=").Nil()).Block(
+			jen.Return(jen.Nil(), jen.Id("err")),
+		),
+		jen.Line(),
+		jen.List(jen.Id("res"), jen.Id("err")).Op(":=").Id("s").Dot("Process").Call(jen.Id("ctx"), jen.Id("input")),
+		jen.If(jen.Id("err").Op("!=").Nil()).Block(
+			jen.Qual(loggerPath, "Get").Call().Dot("Error").Call().
+				Dot("Str").Call(jen.Lit("service"), jen.Lit(name)).
+				Dot("Str").Call(jen.Lit("method"), jen.Lit("Process")).
+				Dot("Err").Call(jen.Id("err")).
+				Dot("Msg").Call(jen.Lit("failed to execute service")),
+			jen.Return(jen.Nil(), jen.Id("err")),
+		),
+		jen.Line(),
+		jen.If(jen.Id("err").Op(":=").Id("s").Dot("Observer").Call(jen.Id("ctx"), jen.Id("input"), jen.Id("res")), jen.Id("err").Op("!=").Nil()).Block(
+			jen.Qual(loggerPath, "Get").Call().Dot("Error").Call().
+				Dot("Str").Call(jen.Lit("service"), jen.Lit(name)).
+				Dot("Str").Call(jen.Lit("method"), jen.Lit("Observer")).
+				Dot("Err").Call(jen.Id("err")).
+				Dot("Msg").Call(jen.Lit("failed to execute observer")),
+		),
+		jen.Line(),
+		jen.Return(jen.Id("res"), jen.Nil()),
+	)
+
+	f.Func().Params(jen.Id("s").Op("*").Id(receiver)).Id("Observer").Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("input").Id(name+"Input"),
+		jen.Id("output").Op("*").Id(name+"Output"),
+	).Error().Block(
+		jen.Return(jen.Nil()),
+	)
+
+	f.Func().Params(jen.Id("s").Op("*").Id(receiver)).Id("Process").Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("input").Id(name+"Input"),
+	).Params(jen.Op("*").Id(name+"Output"), jen.Error()).Block(process...)
+}
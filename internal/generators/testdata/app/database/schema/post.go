@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+)
+
+// Post holds the schema definition for the Post entity.
+type Post struct {
+	ent.Schema
+}
+
+// Mixin of the Post.
+func (Post) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.Time{},
+	}
+}
+
+// Fields of the Post.
+func (Post) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title").NotEmpty(),
+		field.Text("body").Optional(),
+		field.Enum("status").Values("draft", "published").Default("draft"),
+		field.Int("views").Default(0),
+		field.Time("published_at").Optional().Nillable(),
+		field.Strings("tags").Optional(),
+		field.String("edit_token").Optional().Sensitive(),
+	}
+}
+
+// Edges of the Post.
+func (Post) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("author", User.Type).Ref("posts").Unique().Required(),
+	}
+}
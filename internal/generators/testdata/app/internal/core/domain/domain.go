@@ -0,0 +1,3 @@
+package domain_entries
+
+type Domain struct{}
@@ -0,0 +1,34 @@
+package user_service
+
+import (
+	"context"
+
+	"example.com/app/internal/core/model"
+)
+
+type GetProfileInput struct {
+	ID      int
+	Verbose *bool
+}
+
+type GetProfileOutput struct {
+	Name  string
+	Email string
+	Posts int
+}
+
+type GetProfile model.Service[GetProfileInput, GetProfileOutput]
+
+type getProfile struct{}
+
+func NewGetProfile() GetProfile {
+	return &getProfile{}
+}
+
+func (s *getProfile) Validate(input GetProfileInput) error {
+	return nil
+}
+
+func (s *getProfile) Execute(ctx context.Context, input GetProfileInput) (*GetProfileOutput, error) {
+	return &GetProfileOutput{}, nil
+}
@@ -0,0 +1,3 @@
+package usecase
+
+type Usecases struct{}
@@ -0,0 +1,7 @@
+package api
+
+import "github.com/danielgtaylor/huma/v2"
+
+type HttpApi struct {
+	BaseAPI huma.API
+}
@@ -0,0 +1,22 @@
+package method
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type Operation struct {
+	Summary     string
+	Description string
+	Tags        []string
+	BearerAuth  bool
+}
+
+type Handler[I, O any] func(ctx context.Context, input *I) (*O, error)
+
+func GET[I, O any](api huma.API, path string, op Operation, handler Handler[I, O])    {}
+func POST[I, O any](api huma.API, path string, op Operation, handler Handler[I, O])   {}
+func PUT[I, O any](api huma.API, path string, op Operation, handler Handler[I, O])    {}
+func PATCH[I, O any](api huma.API, path string, op Operation, handler Handler[I, O])  {}
+func DELETE[I, O any](api huma.API, path string, op Operation, handler Handler[I, O]) {}
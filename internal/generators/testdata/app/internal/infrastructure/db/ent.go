@@ -0,0 +1,122 @@
+// Package db holds the parts of the ent client generated code uses.
+package db
+
+import (
+	"context"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+
+	"example.com/app/internal/infrastructure/db/post"
+	"example.com/app/internal/infrastructure/db/predicate"
+)
+
+type Client struct {
+	Post *PostClient
+	User *UserClient
+}
+
+type OrderFunc func(*sql.Selector)
+
+func Asc(fields ...string) OrderFunc  { return nil }
+func Desc(fields ...string) OrderFunc { return nil }
+
+func IsNotFound(err error) bool        { return false }
+func IsConstraintError(err error) bool { return false }
+func IsValidationError(err error) bool { return false }
+
+type Post struct {
+	ID          int
+	CreateTime  time.Time
+	UpdateTime  time.Time
+	Title       string
+	Body        string
+	Status      post.Status
+	Views       int
+	PublishedAt *time.Time
+	Tags        []string
+	EditToken   string `json:"-"`
+}
+
+type PostClient struct{}
+
+type (
+	PostQuery     struct{}
+	PostCreate    struct{}
+	PostUpdateOne struct{}
+	PostDeleteOne struct{}
+)
+
+func (c *PostClient) Query() *PostQuery                              { return &PostQuery{} }
+func (c *PostClient) Get(ctx context.Context, id int) (*Post, error) { return nil, nil }
+func (c *PostClient) Create() *PostCreate                            { return &PostCreate{} }
+func (c *PostClient) UpdateOneID(id int) *PostUpdateOne              { return &PostUpdateOne{} }
+func (c *PostClient) DeleteOneID(id int) *PostDeleteOne              { return &PostDeleteOne{} }
+func (q *PostQuery) Where(ps ...predicate.Post) *PostQuery           { return q }
+func (q *PostQuery) Clone() *PostQuery                               { return q }
+func (q *PostQuery) Count(ctx context.Context) (int, error)          { return 0, nil }
+func (q *PostQuery) Order(o ...OrderFunc) *PostQuery                 { return q }
+func (q *PostQuery) Limit(limit int) *PostQuery                      { return q }
+func (q *PostQuery) Offset(offset int) *PostQuery                    { return q }
+func (q *PostQuery) All(ctx context.Context) ([]*Post, error)        { return nil, nil }
+func (c *PostCreate) SetTitle(s string) *PostCreate                  { return c }
+func (c *PostCreate) SetBody(s string) *PostCreate                   { return c }
+func (c *PostCreate) SetStatus(s post.Status) *PostCreate            { return c }
+func (c *PostCreate) SetViews(i int) *PostCreate                     { return c }
+func (c *PostCreate) SetPublishedAt(t time.Time) *PostCreate         { return c }
+func (c *PostCreate) SetTags(s []string) *PostCreate                 { return c }
+func (c *PostCreate) SetEditToken(s string) *PostCreate              { return c }
+func (c *PostCreate) SetAuthorID(id uuid.UUID) *PostCreate           { return c }
+func (c *PostCreate) Save(ctx context.Context) (*Post, error)        { return nil, nil }
+func (u *PostUpdateOne) SetTitle(s string) *PostUpdateOne            { return u }
+func (u *PostUpdateOne) SetBody(s string) *PostUpdateOne             { return u }
+func (u *PostUpdateOne) SetStatus(s post.Status) *PostUpdateOne      { return u }
+func (u *PostUpdateOne) SetViews(i int) *PostUpdateOne               { return u }
+func (u *PostUpdateOne) SetPublishedAt(t time.Time) *PostUpdateOne   { return u }
+func (u *PostUpdateOne) SetTags(s []string) *PostUpdateOne           { return u }
+func (u *PostUpdateOne) SetEditToken(s string) *PostUpdateOne        { return u }
+func (u *PostUpdateOne) SetAuthorID(id uuid.UUID) *PostUpdateOne     { return u }
+func (u *PostUpdateOne) Save(ctx context.Context) (*Post, error)     { return nil, nil }
+func (d *PostDeleteOne) Exec(ctx context.Context) error              { return nil }
+
+type User struct {
+	ID       uuid.UUID
+	Name     string
+	Email    string
+	Password string `json:"-"`
+	Bio      *string
+}
+
+type UserClient struct{}
+
+type (
+	UserQuery     struct{}
+	UserCreate    struct{}
+	UserUpdateOne struct{}
+	UserDeleteOne struct{}
+)
+
+func (c *UserClient) Query() *UserQuery                                    { return &UserQuery{} }
+func (c *UserClient) Get(ctx context.Context, id uuid.UUID) (*User, error) { return nil, nil }
+func (c *UserClient) Create() *UserCreate                                  { return &UserCreate{} }
+func (c *UserClient) UpdateOneID(id uuid.UUID) *UserUpdateOne              { return &UserUpdateOne{} }
+func (c *UserClient) DeleteOneID(id uuid.UUID) *UserDeleteOne              { return &UserDeleteOne{} }
+func (q *UserQuery) Where(ps ...predicate.User) *UserQuery                 { return q }
+func (q *UserQuery) Clone() *UserQuery                                     { return q }
+func (q *UserQuery) Count(ctx context.Context) (int, error)                { return 0, nil }
+func (q *UserQuery) Order(o ...OrderFunc) *UserQuery                       { return q }
+func (q *UserQuery) Limit(limit int) *UserQuery                            { return q }
+func (q *UserQuery) Offset(offset int) *UserQuery                          { return q }
+func (q *UserQuery) All(ctx context.Context) ([]*User, error)              { return nil, nil }
+func (c *UserCreate) SetName(s string) *UserCreate                         { return c }
+func (c *UserCreate) SetEmail(s string) *UserCreate                        { return c }
+func (c *UserCreate) SetPassword(s string) *UserCreate                     { return c }
+func (c *UserCreate) SetBio(s string) *UserCreate                          { return c }
+func (c *UserCreate) Save(ctx context.Context) (*User, error)              { return nil, nil }
+func (u *UserUpdateOne) SetName(s string) *UserUpdateOne                   { return u }
+func (u *UserUpdateOne) SetEmail(s string) *UserUpdateOne                  { return u }
+func (u *UserUpdateOne) SetPassword(s string) *UserUpdateOne               { return u }
+func (u *UserUpdateOne) SetBio(s string) *UserUpdateOne                    { return u }
+func (u *UserUpdateOne) Save(ctx context.Context) (*User, error)           { return nil, nil }
+func (d *UserDeleteOne) Exec(ctx context.Context) error                    { return nil }
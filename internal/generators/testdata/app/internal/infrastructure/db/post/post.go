@@ -0,0 +1,29 @@
+package post
+
+import "example.com/app/internal/infrastructure/db/predicate"
+
+const (
+	FieldID          = "id"
+	FieldCreateTime  = "create_time"
+	FieldUpdateTime  = "update_time"
+	FieldTitle       = "title"
+	FieldBody        = "body"
+	FieldStatus      = "status"
+	FieldViews       = "views"
+	FieldPublishedAt = "published_at"
+	FieldTags        = "tags"
+	FieldEditToken   = "edit_token"
+)
+
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
+)
+
+func (s Status) String() string { return string(s) }
+
+func TitleContains(v string) predicate.Post { return nil }
+func BodyContains(v string) predicate.Post  { return nil }
+func StatusEQ(v Status) predicate.Post      { return nil }
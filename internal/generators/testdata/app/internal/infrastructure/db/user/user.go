@@ -0,0 +1,15 @@
+package user
+
+import "example.com/app/internal/infrastructure/db/predicate"
+
+const (
+	FieldID       = "id"
+	FieldName     = "name"
+	FieldEmail    = "email"
+	FieldPassword = "password"
+	FieldBio      = "bio"
+)
+
+func NameContains(v string) predicate.User  { return nil }
+func EmailContains(v string) predicate.User { return nil }
+func BioContains(v string) predicate.User   { return nil }
@@ -0,0 +1,3 @@
+package sse
+
+type Manager struct{}
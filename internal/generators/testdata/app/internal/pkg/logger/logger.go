@@ -0,0 +1,7 @@
+package logger
+
+import "github.com/rs/zerolog"
+
+func Get() *zerolog.Logger {
+	return nil
+}
@@ -0,0 +1,5 @@
+package validator
+
+type Validator interface {
+	Validate(i any) error
+}
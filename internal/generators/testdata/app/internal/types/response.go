@@ -0,0 +1,13 @@
+package types
+
+type OutputResponseData[T any] struct {
+	Body struct {
+		Data T `json:"data"`
+	}
+}
+
+func GenerateOutputResponseData[T any](data T) *OutputResponseData[T] {
+	output := &OutputResponseData[T]{}
+	output.Body.Data = data
+	return output
+}
@@ -0,0 +1,12 @@
+package domain_builder
+
+import "example.com/app/internal/infrastructure/db"
+
+type Domain[E, D any] struct {
+	wrap   func(E, *db.Client) D
+	client *db.Client
+}
+
+func NewDomain[E, D any](wrap func(E, *db.Client) D, client *db.Client) Domain[E, D] {
+	return Domain[E, D]{wrap: wrap, client: client}
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	domain_builder "example.com/app/internal/core/domain/builder"
+	"example.com/app/internal/infrastructure/db"
+)
+
+type Post struct {
+	*db.Post
+	client *db.Client
+}
+
+func NewPostDomain(client *db.Client) domain_builder.Domain[*db.Post, *Post] {
+	return domain_builder.NewDomain(func(e *db.Post, c *db.Client) *Post {
+		return &Post{Post: e, client: c}
+	}, client)
+}
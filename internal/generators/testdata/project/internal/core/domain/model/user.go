@@ -0,0 +1,17 @@
+package domain
+
+import (
+	domain_builder "example.com/app/internal/core/domain/builder"
+	"example.com/app/internal/infrastructure/db"
+)
+
+type User struct {
+	*db.User
+	client *db.Client
+}
+
+func NewUserDomain(client *db.Client) domain_builder.Domain[*db.User, *User] {
+	return domain_builder.NewDomain(func(e *db.User, c *db.Client) *User {
+		return &User{User: e, client: c}
+	}, client)
+}
@@ -0,0 +1,18 @@
+package model
+
+import "context"
+
+type Usecase[I, O any] interface {
+	Validate(input I) error
+	Execute(ctx context.Context, input I) (*O, error)
+}
+
+type Service[I, O any] interface {
+	Validate(input I) error
+	Execute(ctx context.Context, input I) (*O, error)
+}
+
+type Job[P any] interface {
+	Key() string
+	Handle(ctx context.Context, payload P) error
+}
@@ -0,0 +1,27 @@
+package post_service
+
+import (
+	"context"
+
+	"example.com/app/internal/core/model"
+)
+
+type ListPostsInput struct{}
+
+type ListPostsOutput struct{}
+
+type ListPosts model.Service[ListPostsInput, ListPostsOutput]
+
+type listPosts struct{}
+
+func NewListPosts() ListPosts {
+	return &listPosts{}
+}
+
+func (s *listPosts) Validate(input ListPostsInput) error {
+	return nil
+}
+
+func (s *listPosts) Execute(ctx context.Context, input ListPostsInput) (*ListPostsOutput, error) {
+	return &ListPostsOutput{}, nil
+}
@@ -0,0 +1,27 @@
+package user_service
+
+import (
+	"context"
+
+	"example.com/app/internal/core/model"
+)
+
+type CreateUserInput struct{}
+
+type CreateUserOutput struct{}
+
+type CreateUser model.Service[CreateUserInput, CreateUserOutput]
+
+type createUser struct{}
+
+func NewCreateUser() CreateUser {
+	return &createUser{}
+}
+
+func (s *createUser) Validate(input CreateUserInput) error {
+	return nil
+}
+
+func (s *createUser) Execute(ctx context.Context, input CreateUserInput) (*CreateUserOutput, error) {
+	return &CreateUserOutput{}, nil
+}
@@ -0,0 +1,27 @@
+package user_service
+
+import (
+	"context"
+
+	"example.com/app/internal/core/model"
+)
+
+type GetUserInput struct{}
+
+type GetUserOutput struct{}
+
+type GetUser model.Service[GetUserInput, GetUserOutput]
+
+type getUser struct{}
+
+func NewGetUser() GetUser {
+	return &getUser{}
+}
+
+func (s *getUser) Validate(input GetUserInput) error {
+	return nil
+}
+
+func (s *getUser) Execute(ctx context.Context, input GetUserInput) (*GetUserOutput, error) {
+	return &GetUserOutput{}, nil
+}
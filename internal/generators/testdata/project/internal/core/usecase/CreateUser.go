@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"example.com/app/internal/core/model"
+)
+
+type CreateUserInput struct{}
+
+type CreateUserOutput struct{}
+
+type CreateUser model.Usecase[CreateUserInput, CreateUserOutput]
+
+type createUser struct{}
+
+func NewCreateUser() CreateUser {
+	return &createUser{}
+}
+
+func (uc *createUser) Validate(input CreateUserInput) error {
+	return nil
+}
+
+func (uc *createUser) Execute(ctx context.Context, input CreateUserInput) (*CreateUserOutput, error) {
+	return &CreateUserOutput{}, nil
+}
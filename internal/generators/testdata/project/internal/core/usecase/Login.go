@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+
+	"example.com/app/internal/core/model"
+)
+
+type LoginInput struct {
+	Email string
+}
+
+type LoginOutput struct {
+	Token string
+}
+
+type Login model.Usecase[LoginInput, LoginOutput]
+
+type login struct{}
+
+func NewLogin() Login {
+	return &login{}
+}
+
+func (uc *login) Validate(input LoginInput) error {
+	return nil
+}
+
+func (uc *login) Execute(ctx context.Context, input LoginInput) (*LoginOutput, error) {
+	return &LoginOutput{}, nil
+}
@@ -0,0 +1,11 @@
+package user_handler
+
+type getUser struct{}
+
+func NewGetUser() *getUser {
+	return &getUser{}
+}
+
+func NewListUsers() *getUser {
+	return &getUser{}
+}
@@ -0,0 +1,5 @@
+package job
+
+func formatAddress(to string) string {
+	return "<" + to + ">"
+}
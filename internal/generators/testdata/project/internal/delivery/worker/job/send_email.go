@@ -0,0 +1,27 @@
+package job
+
+import (
+	"context"
+
+	"example.com/app/internal/core/model"
+)
+
+type SendEmailPayload struct {
+	To string
+}
+
+type SendEmail model.Job[SendEmailPayload]
+
+type sendEmail struct{}
+
+func NewSendEmail() SendEmail {
+	return &sendEmail{}
+}
+
+func (j *sendEmail) Key() string {
+	return "job:send_email"
+}
+
+func (j *sendEmail) Handle(ctx context.Context, payload SendEmailPayload) error {
+	return nil
+}
@@ -0,0 +1,13 @@
+package db
+
+type Client struct{}
+
+type User struct {
+	ID   int
+	Name string
+}
+
+type Post struct {
+	ID    int
+	Title string
+}
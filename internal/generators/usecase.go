@@ -2,13 +2,13 @@ package generators
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/dave/jennifer/jen"
 
+	"github.com/alfariiizi/vandor-cli/internal/naming"
 	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
 type UsecaseData struct {
@@ -18,37 +18,32 @@ type UsecaseData struct {
 }
 
 func GenerateUsecase(usecaseName string) error {
-	if usecaseName == "" {
-		return fmt.Errorf("usecase name cannot be empty")
+	name, err := naming.Component("usecase", usecaseName)
+	if err != nil {
+		return err
 	}
-
-	// Capitalize first letter for type name
-	usecaseName = strings.ToUpper(usecaseName[:1]) + usecaseName[1:]
+	usecaseName = name.Pascal
 
 	data := UsecaseData{
 		ModuleName: utils.GetModuleName(),
-		Name:       usecaseName,
-		Receiver:   strings.ToLower(usecaseName[:1]) + usecaseName[1:], // camelCase for receiver
+		Name:       name.Pascal,
+		Receiver:   name.Camel,
 	}
 
-	// Check if usecase already exists
-	usecasePath := filepath.Join("internal", "core", "usecase", utils.ToPascalCase(usecaseName)+".go")
-	if _, err := os.Stat(usecasePath); err == nil {
-		return fmt.Errorf("usecase %s already exists at %s", usecaseName, usecasePath)
-	}
+	usecasePath := filepath.Join(usecaseDir(), name.Pascal+".go")
 
 	// Generate usecase file
 	f := generateUsecaseFile(data)
 
 	// Ensure directory exists
 	dir := filepath.Dir(usecasePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := vfs.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	// Write file
-	if err := f.Save(usecasePath); err != nil {
-		return fmt.Errorf("failed to save file: %w", err)
+	if written, err := createFile(f, usecasePath, data.ModuleName); !written || err != nil {
+		return err
 	}
 
 	fmt.Printf("Usecase %s created successfully at %s\n", usecaseName, usecasePath)
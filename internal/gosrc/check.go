@@ -0,0 +1,304 @@
+package gosrc
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Checker type-checks Go packages without building them. The standard
+// library is checked from source and the packages of the project from its
+// directory, read through vfs. Other imports cannot be resolved offline:
+// they are unknown packages, whose uses are not checked.
+type Checker struct {
+	root   string // Project directory; none when module is ""
+	module string // Module path of the project
+
+	fset     *token.FileSet
+	std      types.Importer
+	packages map[string]*types.Package // Project packages by import path; nil while being checked
+	errs     []string
+}
+
+// NewChecker returns a checker resolving the imports of module from the
+// project in root. With an empty module, only the standard library is
+// resolved.
+func NewChecker(root, module string) *Checker {
+	fset := token.NewFileSet()
+	return &Checker{
+		root:     root,
+		module:   module,
+		fset:     fset,
+		std:      importer.ForCompiler(fset, "source", nil),
+		packages: map[string]*types.Package{},
+	}
+}
+
+// errUnresolved is returned for imports outside the standard library and
+// the project, which the type checker then treats as unknown packages.
+var errUnresolved = errors.New("not in the standard library or the project")
+
+// Import implements types.Importer.
+func (c *Checker) Import(path string) (*types.Package, error) {
+	if c.inModule(path) {
+		return c.importProject(path)
+	}
+	if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+		return nil, errUnresolved
+	}
+	return c.std.Import(path)
+}
+
+func (c *Checker) inModule(path string) bool {
+	return c.module != "" && (path == c.module || strings.HasPrefix(path, c.module+"/"))
+}
+
+// CheckProject type-checks every package of the project, skipping hidden,
+// testdata and vendor directories.
+func (c *Checker) CheckProject() error {
+	start := len(c.errs)
+	err := vfs.WalkDir(c.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if name := d.Name(); path != c.root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor") {
+			return filepath.SkipDir
+		}
+		if files, err := goFiles(path); err != nil || len(files) == 0 {
+			return err
+		}
+
+		rel, err := filepath.Rel(c.root, path)
+		if err != nil {
+			return err
+		}
+		importPath := c.module
+		if rel != "." {
+			importPath += "/" + filepath.ToSlash(rel)
+		}
+		if _, err := c.importProject(importPath); err != nil {
+			c.errs = append(c.errs, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return c.result(start)
+}
+
+// CheckFiles type-checks Go sources by path, a package being the files of a
+// directory with the same package clause. Files that are not Go are
+// ignored.
+func (c *Checker) CheckFiles(sources map[string]string) error {
+	start := len(c.errs)
+
+	paths := make([]string, 0, len(sources))
+	for path := range sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	packages := map[string][]*ast.File{}
+	var keys []string
+	for _, path := range paths {
+		if filepath.Ext(path) != ".go" {
+			continue
+		}
+		f, err := parser.ParseFile(c.fset, filepath.ToSlash(path), sources[path], parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		key := filepath.Dir(path) + " " + f.Name.Name
+		if _, ok := packages[key]; !ok {
+			keys = append(keys, key)
+		}
+		packages[key] = append(packages[key], f)
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		c.check(key, packages[key])
+	}
+	return c.result(start)
+}
+
+// result returns the errors found since the first start ones.
+func (c *Checker) result(start int) error {
+	if len(c.errs) == start {
+		return nil
+	}
+	return errors.New(strings.Join(c.errs[start:], "\n"))
+}
+
+// importProject checks the package of the project at an import path once.
+func (c *Checker) importProject(path string) (*types.Package, error) {
+	if pkg, ok := c.packages[path]; ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle through %s", path)
+		}
+		return pkg, nil
+	}
+	c.packages[path] = nil
+
+	dir := filepath.Join(c.root, filepath.FromSlash(strings.TrimPrefix(strings.TrimPrefix(path, c.module), "/")))
+	files, err := goFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	var parsed []*ast.File
+	for _, file := range files {
+		src, err := vfs.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		name := file
+		if rel, err := filepath.Rel(c.root, file); err == nil {
+			name = filepath.ToSlash(rel)
+		}
+		f, err := parser.ParseFile(c.fset, name, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if len(parsed) > 0 && f.Name.Name != parsed[0].Name.Name {
+			return nil, fmt.Errorf("%s: found packages %s and %s", dir, parsed[0].Name.Name, f.Name.Name)
+		}
+		parsed = append(parsed, f)
+	}
+
+	pkg := c.check(path, parsed)
+	c.packages[path] = pkg
+	return pkg, nil
+}
+
+// check type-checks the files of one package, recording its errors.
+func (c *Checker) check(path string, files []*ast.File) *types.Package {
+	for _, f := range files {
+		c.nameImports(f)
+	}
+
+	var found []types.Error
+	conf := types.Config{
+		Importer: c,
+		Error: func(err error) {
+			// Unknown packages are expected; go/types does not report uses
+			// of them
+			if terr, ok := err.(types.Error); ok && !strings.Contains(terr.Msg, errUnresolved.Error()) {
+				found = append(found, terr)
+			}
+		},
+	}
+	pkg, _ := conf.Check(path, c.fset, files, nil)
+
+	// Nor can it tell what types declared from unknown packages accept
+	unknown := unknownTypes(pkg)
+	for _, err := range found {
+		if !unknown.MatchString(err.Msg) {
+			c.errs = append(c.errs, err.Error())
+		}
+	}
+	return pkg
+}
+
+// goFiles returns the Go files of the package in dir, less its tests.
+func goFiles(dir string) ([]string, error) {
+	entries, err := vfs.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+	return files, nil
+}
+
+// nameImports names the unknown imports of f by their conventional package
+// name, e.g. huma for github.com/danielgtaylor/huma/v2, since they cannot be
+// resolved to learn it.
+func (c *Checker) nameImports(f *ast.File) {
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil || err != nil || c.inModule(path) {
+			continue
+		}
+		if first, _, _ := strings.Cut(path, "/"); !strings.Contains(first, ".") {
+			continue
+		}
+
+		elems := strings.Split(path, "/")
+		name := elems[len(elems)-1]
+		if len(elems) > 1 && majorVersion.MatchString(name) {
+			name = elems[len(elems)-2]
+		}
+		name = strings.TrimPrefix(majorSuffix.ReplaceAllString(name, ""), "go-")
+		spec.Name = ast.NewIdent(strings.NewReplacer("-", "", ".", "").Replace(name))
+	}
+}
+
+var (
+	majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+	majorSuffix  = regexp.MustCompile(`\.v[0-9]+$`)
+)
+
+// unknownTypes returns a pattern matching the names of the types of pkg
+// whose definition could not be checked, e.g. defined from a type of an
+// unknown package or embedding one, like ent schemas embedding ent.Schema;
+// one matching nothing when there are none.
+func unknownTypes(pkg *types.Package) *regexp.Regexp {
+	var names []string
+	if pkg != nil {
+		for _, name := range pkg.Scope().Names() {
+			if obj, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok && isUnknown(obj.Type()) {
+				names = append(names, regexp.QuoteMeta(name))
+			}
+		}
+	}
+	if len(names) == 0 {
+		return regexp.MustCompile(`$^`)
+	}
+	return regexp.MustCompile(`\b(` + strings.Join(names, "|") + `)\b`)
+}
+
+// isUnknown reports whether the underlying type or the method set of typ
+// cannot be known.
+func isUnknown(typ types.Type) bool {
+	switch underlying := typ.Underlying().(type) {
+	case *types.Basic:
+		return underlying.Kind() == types.Invalid
+	case *types.Struct:
+		for i := 0; i < underlying.NumFields(); i++ {
+			field := underlying.Field(i)
+			embedded := field.Type()
+			if pointer, ok := embedded.(*types.Pointer); ok {
+				embedded = pointer.Elem()
+			}
+			if field.Embedded() && embedded.Underlying() == types.Typ[types.Invalid] {
+				return true
+			}
+		}
+	}
+	return false
+}
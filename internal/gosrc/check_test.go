@@ -0,0 +1,94 @@
+package gosrc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckProject(t *testing.T) {
+	model := "package model\n\ntype User struct{ Name string }\n"
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			files: map[string]string{
+				"model/model.go": model,
+				"app/app.go":     "package app\n\nimport (\n\t\"strings\"\n\n\t\"example.com/app/model\"\n)\n\nfunc Name(u model.User) string { return strings.ToUpper(u.Name) }\n",
+			},
+		},
+		{
+			name: "unknown packages are not checked",
+			files: map[string]string{
+				"app/app.go": "package app\n\nimport \"github.com/danielgtaylor/huma/v2\"\n\nvar API huma.API = huma.New(1, 2)\n\ntype Op huma.Operation\n\nvar _ = Op{Path: \"/\"}\n",
+			},
+		},
+		{
+			name: "methods of types embedding unknown types are not checked",
+			files: map[string]string{
+				"schema/user.go": "package schema\n\nimport \"entgo.io/ent\"\n\ntype User struct {\n\tent.Schema\n}\n\ntype Post struct {\n\t*ent.Schema\n}\n\nvar _ = User.Type\n\nvar _ = Post{}.Edges()\n",
+			},
+		},
+		{
+			name: "undefined in the project",
+			files: map[string]string{
+				"model/model.go": model,
+				"app/app.go":     "package app\n\nimport \"example.com/app/model\"\n\nvar _ = model.Post{}\n\nvar _ = missing\n",
+			},
+			wantErr: []string{"app/app.go:5:15: undefined: model.Post", "app/app.go:7:9: undefined: missing"},
+		},
+		{
+			name: "missing project package",
+			files: map[string]string{
+				"app/app.go": "package app\n\nimport \"example.com/app/model\"\n\nvar _ = model.User{}\n",
+			},
+			wantErr: []string{"could not import example.com/app/model"},
+		},
+		{
+			name: "errors in imported packages",
+			files: map[string]string{
+				"model/model.go": "package model\n\ntype User struct{ Name undefinedType }\n",
+				"app/app.go":     "package app\n\nimport \"example.com/app/model\"\n\nvar _ = model.User{}\n",
+			},
+			wantErr: []string{"model/model.go:3:24: undefined: undefinedType"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(root, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := NewChecker(root, "example.com/app").CheckProject()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected type errors")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+			if n := strings.Count(err.Error(), "undefinedType"); n > 1 {
+				t.Errorf("an error is reported %d times:\n%v", n, err)
+			}
+		})
+	}
+}
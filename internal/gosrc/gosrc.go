@@ -0,0 +1,255 @@
+// Package gosrc formats and writes generated Go source.
+package gosrc
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/imports"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Format gofmts src and groups its imports into standard library,
+// third-party and local blocks, where local imports are those under
+// localPrefix (usually the project module). It replaces running goimports
+// over generated code.
+func Format(src []byte, localPrefix string) ([]byte, error) {
+	grouped, err := groupImports(src, localPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := format.Source(grouped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format source: %w", err)
+	}
+	return out, nil
+}
+
+// WriteFile writes content to path unless the file already holds exactly the
+// same bytes. It reports whether the file was written.
+func WriteFile(path string, content []byte) (bool, error) {
+	if existing, err := vfs.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+		return false, nil
+	}
+
+	if err := vfs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := vfs.WriteFile(path, content, 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// ParseFile parses the Go file at path, reading it through vfs so that files
+// written earlier in a preview are visible.
+func ParseFile(fset *token.FileSet, path string, mode parser.Mode) (*ast.File, error) {
+	src, err := vfs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parser.ParseFile(fset, path, src, mode)
+}
+
+// FixImports adds the imports src is missing and removes those it does not
+// use, as goimports does. path is where the file lives, and decides which
+// packages can be imported from it.
+func FixImports(path string, src []byte) ([]byte, error) {
+	out, err := imports.Process(path, src, &imports.Options{Comments: true, TabIndent: true, TabWidth: 8})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fix imports: %w", err)
+	}
+	return out, nil
+}
+
+// FormatDir fixes the imports of every Go file under dir and formats it in
+// place, leaving files that are already formatted untouched.
+func FormatDir(dir, localPrefix string) error {
+	return vfs.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		src, err := vfs.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fixed, err := FixImports(path, src)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		out, err := Format(fixed, localPrefix)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		_, err = WriteFile(path, out)
+		return err
+	})
+}
+
+const (
+	stdGroup = iota
+	thirdPartyGroup
+	localGroup
+)
+
+func importGroup(path, localPrefix string) int {
+	if localPrefix != "" && (path == localPrefix || strings.HasPrefix(path, localPrefix+"/")) {
+		return localGroup
+	}
+	if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+		return thirdPartyGroup
+	}
+	return stdGroup
+}
+
+// groupImports rewrites the file's import declaration into sorted groups.
+// Files with several import declarations or comments inside the import
+// block are returned unchanged.
+func groupImports(src []byte, localPrefix string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source: %w", err)
+	}
+
+	var decl *ast.GenDecl
+	for _, d := range file.Decls {
+		g, ok := d.(*ast.GenDecl)
+		if !ok || g.Tok != token.IMPORT {
+			continue
+		}
+		if decl != nil {
+			return src, nil
+		}
+		decl = g
+	}
+	if decl == nil || len(decl.Specs) == 0 {
+		return src, nil
+	}
+	for _, c := range file.Comments {
+		if c.Pos() >= decl.Pos() && c.End() <= decl.End() {
+			return src, nil
+		}
+	}
+
+	type entry struct {
+		path string
+		line string
+	}
+	var groups [3][]entry
+	for _, spec := range decl.Specs {
+		is := spec.(*ast.ImportSpec)
+		path, err := strconv.Unquote(is.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		line := is.Path.Value
+		if is.Name != nil {
+			line = is.Name.Name + " " + line
+		}
+		g := importGroup(path, localPrefix)
+		groups[g] = append(groups[g], entry{path: path, line: line})
+	}
+
+	var blocks []string
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool { return group[i].path < group[j].path })
+		lines := make([]string, len(group))
+		for i, e := range group {
+			lines[i] = "\t" + e.line
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+
+	block := "import (\n" + strings.Join(blocks, "\n\n") + "\n)"
+	if len(decl.Specs) == 1 {
+		block = "import " + strings.TrimSpace(blocks[0])
+	}
+
+	start := fset.Position(decl.Pos()).Offset
+	end := fset.Position(decl.End()).Offset
+
+	var out bytes.Buffer
+	out.Write(src[:start])
+	out.WriteString(block)
+	out.Write(src[end:])
+	return out.Bytes(), nil
+}
+
+// AddImports adds imports of the given paths that src does not import yet.
+// The result is not formatted; pass it through Format.
+func AddImports(src []byte, paths ...string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source: %w", err)
+	}
+
+	imported := map[string]bool{}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		imported[path] = true
+	}
+
+	var missing []string
+	for _, path := range paths {
+		if !imported[path] {
+			imported[path] = true
+			missing = append(missing, strconv.Quote(path))
+		}
+	}
+	if len(missing) == 0 {
+		return src, nil
+	}
+
+	var decl *ast.GenDecl
+	for _, d := range file.Decls {
+		if g, ok := d.(*ast.GenDecl); ok && g.Tok == token.IMPORT {
+			decl = g
+			break
+		}
+	}
+
+	var out bytes.Buffer
+	switch {
+	case decl == nil:
+		end := fset.Position(file.Name.End()).Offset
+		out.Write(src[:end])
+		out.WriteString("\n\nimport (\n\t" + strings.Join(missing, "\n\t") + "\n)")
+		out.Write(src[end:])
+	case decl.Lparen.IsValid():
+		rparen := fset.Position(decl.Rparen).Offset
+		out.Write(src[:rparen])
+		out.WriteString("\t" + strings.Join(missing, "\n\t") + "\n")
+		out.Write(src[rparen:])
+	default:
+		start := fset.Position(decl.Pos()).Offset
+		end := fset.Position(decl.End()).Offset
+		spec := strings.TrimSpace(strings.TrimPrefix(string(src[start:end]), "import"))
+		out.Write(src[:start])
+		out.WriteString("import (\n\t" + spec + "\n\t" + strings.Join(missing, "\n\t") + "\n)")
+		out.Write(src[end:])
+	}
+	return out.Bytes(), nil
+}
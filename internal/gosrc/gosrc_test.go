@@ -0,0 +1,77 @@
+package gosrc
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestFormatDirFixesImports(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":         "module example.com/app\n\ngo 1.23\n",
+		"model/model.go": "package model\n\ntype User struct{ Name string }\n",
+		"db/user.go": `package db
+
+import (
+	"fmt"
+	"os"
+)
+
+func Name(u *model.User) string {
+	return strings.ToUpper(u.Name)
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Local packages are resolved from the module of the working directory,
+	// which is the project root when vandor runs.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	if err := FormatDir("db", "example.com/app"); err != nil {
+		t.Fatalf("FormatDir: %v", err)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join("db", "user.go"), nil, parser.ImportsOnly)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := map[string]bool{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		got[path] = true
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"strings", true},
+		{"example.com/app/model", true},
+		{"fmt", false},
+		{"os", false},
+	}
+	for _, tt := range tests {
+		if got[tt.path] != tt.want {
+			t.Errorf("import %q: got %v, want %v", tt.path, got[tt.path], tt.want)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Package history journals the files each generation command writes, with
+// their previous contents, so that a run can be undone.
+package history
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Dir is the project-relative directory the journal is kept in.
+var Dir = filepath.Join(".vandor", "history")
+
+// maxEntries bounds the journal; older entries are pruned.
+const maxEntries = 100
+
+// Entry is one journaled command run.
+type Entry struct {
+	ID      int         `json:"id"`
+	Command string      `json:"command"`
+	Time    time.Time   `json:"time"`
+	Files   []FileEntry `json:"files"`
+	Undone  bool        `json:"undone,omitempty"`
+}
+
+// FileEntry is a file written by a run.
+type FileEntry struct {
+	Path     string `json:"path"`
+	Created  bool   `json:"created"`            // The file did not exist before the run
+	Previous []byte `json:"previous,omitempty"` // Content before the run
+	SHA256   string `json:"sha256"`             // Content after the run
+}
+
+// Recorder is a filesystem that writes through to its base while remembering
+// the original content of every file it touches.
+type Recorder struct {
+	vfs.FS
+	command string
+	restore func()
+
+	mu       sync.Mutex
+	previous map[string]*FileEntry
+	order    []string
+}
+
+// Start begins journaling writes made through vfs for the named command.
+func Start(command string) *Recorder {
+	r := &Recorder{
+		FS:       vfs.Current(),
+		command:  command,
+		previous: map[string]*FileEntry{},
+	}
+	r.restore = vfs.Use(r)
+	return r
+}
+
+// WriteFile records the file's content before its first write in this run.
+func (r *Recorder) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	name = filepath.Clean(name)
+	if !strings.HasPrefix(filepath.ToSlash(name), ".vandor/") {
+		r.mu.Lock()
+		if _, ok := r.previous[name]; !ok {
+			entry := &FileEntry{Path: name}
+			content, err := r.FS.ReadFile(name)
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				entry.Created = true
+			case err != nil:
+				r.mu.Unlock()
+				return err
+			default:
+				entry.Previous = content
+			}
+			r.previous[name] = entry
+			r.order = append(r.order, name)
+		}
+		r.mu.Unlock()
+	}
+	return r.FS.WriteFile(name, data, perm)
+}
+
+// Finish stops journaling and saves an entry for the files that changed. It
+// returns the entry, or nil when nothing changed.
+func (r *Recorder) Finish() (*Entry, error) {
+	r.restore()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := &Entry{Command: r.command, Time: time.Now()}
+	for _, name := range r.order {
+		file := r.previous[name]
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to journal %s: %w", name, err)
+		}
+		if !file.Created && bytes.Equal(content, file.Previous) {
+			continue
+		}
+		file.SHA256 = hash(content)
+		entry.Files = append(entry.Files, *file)
+	}
+	if len(entry.Files) == 0 {
+		return nil, nil
+	}
+
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	entry.ID = 1
+	if len(entries) > 0 {
+		entry.ID = entries[0].ID + 1
+	}
+	if err := save(entry); err != nil {
+		return nil, err
+	}
+
+	for _, old := range entries[min(len(entries), maxEntries-1):] {
+		_ = os.Remove(entryPath(old.ID))
+	}
+	return entry, nil
+}
+
+// List returns the journal, newest first.
+func List() ([]Entry, error) {
+	files, err := os.ReadDir(Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, file := range files {
+		id, err := strconv.Atoi(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil || file.IsDir() {
+			continue
+		}
+		entry, err := Load(id)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID > entries[j].ID })
+	return entries, nil
+}
+
+// Load reads a journal entry.
+func Load(id int) (*Entry, error) {
+	data, err := os.ReadFile(entryPath(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no history entry %d", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to read history entry %d: %w", id, err)
+	}
+	return &entry, nil
+}
+
+// Latest returns the newest entry that has not been undone.
+func Latest() (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.Undone {
+			return &entry, nil
+		}
+	}
+	return nil, errors.New("nothing to undo")
+}
+
+// Modified returns the files of an entry that changed since its run.
+func Modified(entry *Entry) []string {
+	var modified []string
+	for _, file := range entry.Files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil || hash(content) != file.SHA256 {
+			modified = append(modified, file.Path)
+		}
+	}
+	return modified
+}
+
+// Undo restores the files of an entry to their state before its run: created
+// files are removed and modified files get their previous content back. It
+// refuses when any of the files changed since the run.
+func Undo(entry *Entry) error {
+	if entry.Undone {
+		return fmt.Errorf("history entry %d was already undone", entry.ID)
+	}
+	if modified := Modified(entry); len(modified) > 0 {
+		return fmt.Errorf("refusing to undo entry %d: files changed since it ran:\n  %s", entry.ID, strings.Join(modified, "\n  "))
+	}
+
+	for _, file := range entry.Files {
+		if file.Created {
+			if err := os.Remove(file.Path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", file.Path, err)
+			}
+			removeEmptyParents(filepath.Dir(file.Path))
+			continue
+		}
+		if err := os.WriteFile(file.Path, file.Previous, 0644); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", file.Path, err)
+		}
+	}
+
+	entry.Undone = true
+	return save(entry)
+}
+
+// removeEmptyParents removes dir and its parents while they are empty.
+func removeEmptyParents(dir string) {
+	for dir != "." && dir != string(filepath.Separator) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func save(entry *Entry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	if err := os.WriteFile(entryPath(entry.ID), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+	return nil
+}
+
+func entryPath(id int) string {
+	return filepath.Join(Dir, strconv.Itoa(id)+".json")
+}
+
+func hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
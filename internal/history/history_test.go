@@ -0,0 +1,182 @@
+package history
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// inProject runs the test from a temporary project holding files; the
+// journal and its paths are relative to the working directory.
+func inProject(t *testing.T, files map[string]string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	for name, content := range files {
+		writeFile(t, name, content)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// record runs writes through a recorder for command, as a generation
+// command does.
+func record(t *testing.T, command string, writes map[string]string) *Entry {
+	t.Helper()
+	r := Start(command)
+	for name, content := range writes {
+		if err := vfs.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := vfs.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entry, err := r.Finish()
+	if err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	return entry
+}
+
+func TestRecord(t *testing.T) {
+	inProject(t, map[string]string{"edited.go": "old", "same.go": "same"})
+
+	entry := record(t, "add usecase", map[string]string{
+		"edited.go":                  "new",
+		"same.go":                    "same",
+		filepath.Join("pkg", "a.go"): "created",
+		filepath.Join(".vandor", "cache", "sync.json"): "{}",
+	})
+	if entry == nil {
+		t.Fatal("no entry recorded")
+	}
+	if entry.ID != 1 || entry.Command != "add usecase" {
+		t.Errorf("entry %d %q, want 1 %q", entry.ID, entry.Command, "add usecase")
+	}
+
+	files := map[string]FileEntry{}
+	for _, file := range entry.Files {
+		files[file.Path] = file
+	}
+	tests := []struct {
+		path     string
+		recorded bool
+		created  bool
+		previous string
+	}{
+		{"edited.go", true, false, "old"},
+		{filepath.Join("pkg", "a.go"), true, true, ""},
+		{"same.go", false, false, ""},
+		{filepath.Join(".vandor", "cache", "sync.json"), false, false, ""},
+	}
+	for _, tt := range tests {
+		file, ok := files[tt.path]
+		if ok != tt.recorded {
+			t.Errorf("%s recorded = %v, want %v", tt.path, ok, tt.recorded)
+			continue
+		}
+		if ok && (file.Created != tt.created || string(file.Previous) != tt.previous) {
+			t.Errorf("%s: created %v previous %q, want %v %q", tt.path, file.Created, file.Previous, tt.created, tt.previous)
+		}
+	}
+	if vfs.Current() != (vfs.OS{}) {
+		t.Error("the recorder is still in use after Finish")
+	}
+}
+
+func TestRecordNothingChanged(t *testing.T) {
+	inProject(t, map[string]string{"same.go": "same"})
+
+	if entry := record(t, "sync", map[string]string{"same.go": "same"}); entry != nil {
+		t.Errorf("entry recorded for a run that changed nothing: %+v", entry)
+	}
+	if entries, err := List(); err != nil || len(entries) != 0 {
+		t.Errorf("journal = %v, %v; want empty", entries, err)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	inProject(t, map[string]string{"edited.go": "old"})
+
+	record(t, "first", map[string]string{"first.go": "first"})
+	entry := record(t, "second", map[string]string{
+		"edited.go":                         "new",
+		filepath.Join("pkg", "sub", "a.go"): "created",
+	})
+
+	latest, err := Latest()
+	if err != nil || latest.ID != entry.ID || latest.ID != 2 {
+		t.Fatalf("latest = %+v, %v; want entry 2", latest, err)
+	}
+	if err := Undo(latest); err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+
+	tests := []struct {
+		path    string
+		content string // "" when the path must not exist
+	}{
+		{"edited.go", "old"},
+		{filepath.Join("pkg", "sub", "a.go"), ""},
+		{"pkg", ""},
+		{"first.go", "first"},
+	}
+	for _, tt := range tests {
+		content, err := os.ReadFile(tt.path)
+		if tt.content == "" {
+			if _, err := os.Stat(tt.path); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("%s still exists", tt.path)
+			}
+			continue
+		}
+		if err != nil || string(content) != tt.content {
+			t.Errorf("%s = %q, %v; want %q", tt.path, content, err, tt.content)
+		}
+	}
+
+	if err := Undo(latest); err == nil {
+		t.Error("undoing an entry twice succeeded")
+	}
+	next, err := Latest()
+	if err != nil || next.ID != 1 {
+		t.Errorf("latest after undo = %+v, %v; want entry 1", next, err)
+	}
+}
+
+func TestUndoRefusesModifiedFiles(t *testing.T) {
+	inProject(t, nil)
+
+	entry := record(t, "add domain", map[string]string{"a.go": "generated", "b.go": "generated"})
+	writeFile(t, "b.go", "edited by hand")
+
+	err := Undo(entry)
+	if err == nil || !strings.Contains(err.Error(), "b.go") {
+		t.Fatalf("error = %v, want a refusal naming b.go", err)
+	}
+	for _, name := range []string{"a.go", "b.go"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("%s was touched by a refused undo: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Package layout describes where components live in a Vandor project. The
+// add commands create components and the sync generators discover them in
+// the same directories, so a component just added is always picked up by
+// the next sync.
+package layout
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+)
+
+// Layout is the project-relative directory of each component kind. Paths
+// are slash-separated in vandor-config.yaml and OS-specific here.
+type Layout struct {
+	Domain    string `yaml:"domain"`    // Domain models wrapped by the domain registry
+	Usecase   string `yaml:"usecase"`   // Usecases
+	Service   string `yaml:"service"`   // Service groups, one directory per group
+	Job       string `yaml:"job"`       // Worker jobs
+	Handler   string `yaml:"handler"`   // HTTP handler groups, one directory per group
+	Scheduler string `yaml:"scheduler"` // Schedulers
+	Seed      string `yaml:"seed"`      // Database seeds
+	Enum      string `yaml:"enum"`      // Enums
+}
+
+// Default returns the standard Vandor project layout.
+func Default() Layout {
+	return Layout{
+		Domain:    filepath.Join("internal", "core", "domain", "model"),
+		Usecase:   filepath.Join("internal", "core", "usecase"),
+		Service:   filepath.Join("internal", "core", "service"),
+		Job:       filepath.Join("internal", "delivery", "worker", "job"),
+		Handler:   filepath.Join("internal", "delivery", "http", "route"),
+		Scheduler: filepath.Join("internal", "core", "scheduler"),
+		Seed:      filepath.Join("internal", "infrastructure", "seed"),
+		Enum:      filepath.Join("internal", "core", "enum"),
+	}
+}
+
+// Load returns the default layout with the overrides under layout in the
+// project configuration.
+func Load() (Layout, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return Default(), err
+	}
+	return FromConfig(cfg)
+}
+
+// FromConfig returns the default layout with the overrides under layout in
+// cfg.
+func FromConfig(cfg config.Config) (Layout, error) {
+	l := Default()
+	fields := l.fields()
+	for kind, dir := range cfg.Layout {
+		field, ok := fields[kind]
+		if !ok {
+			return Default(), fmt.Errorf("%s: unknown layout component %q%s", cfg.Source(), kind, config.Suggest(kind, Kinds()))
+		}
+		clean, err := clean(dir)
+		if err != nil {
+			return Default(), fmt.Errorf("%s: layout.%s: %w", cfg.Source(), kind, err)
+		}
+		*field = clean
+	}
+	return l, nil
+}
+
+var (
+	once    sync.Once
+	current Layout
+)
+
+// Current returns the layout of the project in the current directory,
+// loaded once. A broken override is reported and the default layout used,
+// so that the rest of the CLI keeps working.
+func Current() Layout {
+	once.Do(func() {
+		var err error
+		if current, err = Load(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Ignoring project layout: %v\n", err)
+		}
+	})
+	return current
+}
+
+// ResetCurrent forgets the layout loaded by Current, which loads it again
+// on its next call, e.g. once the project configuration changed.
+func ResetCurrent() {
+	once = sync.Once{}
+}
+
+// DomainRegistry returns the directory of the domain registry, which wraps
+// the domain models from the parent of their directory.
+func (l Layout) DomainRegistry() string {
+	return filepath.Dir(l.Domain)
+}
+
+// Kinds returns the component kinds of the layout in declaration order.
+func Kinds() []string {
+	t := reflect.TypeOf(Layout{})
+	kinds := make([]string, t.NumField())
+	for i := range kinds {
+		kinds[i] = t.Field(i).Tag.Get("yaml")
+	}
+	return kinds
+}
+
+// Dir returns the directory of a component kind.
+func (l Layout) Dir(kind string) (string, bool) {
+	field, ok := l.fields()[kind]
+	if !ok {
+		return "", false
+	}
+	return *field, true
+}
+
+// fields maps the component kinds to the fields of l.
+func (l *Layout) fields() map[string]*string {
+	v := reflect.ValueOf(l).Elem()
+	fields := map[string]*string{}
+	for i, kind := range Kinds() {
+		fields[kind] = v.Field(i).Addr().Interface().(*string)
+	}
+	return fields
+}
+
+// clean validates a directory from the config and converts it to an OS path.
+func clean(dir string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("directory cannot be empty")
+	}
+	if filepath.IsAbs(dir) || strings.HasPrefix(dir, "/") {
+		return "", fmt.Errorf("%s must be relative to the project root", dir)
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(dir))
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s must be inside the project", dir)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,129 @@
+package layout
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+)
+
+func TestFromConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  map[string]string
+		want    func(l *Layout)
+		wantErr string
+	}{
+		{name: "default", want: func(l *Layout) {}},
+		{
+			name:   "overrides",
+			layout: map[string]string{"job": "internal/worker/jobs", "handler": "internal/http/handler/"},
+			want: func(l *Layout) {
+				l.Job = filepath.Join("internal", "worker", "jobs")
+				l.Handler = filepath.Join("internal", "http", "handler")
+			},
+		},
+		{name: "unknown kind", layout: map[string]string{"usecases": "internal/usecase"}, wantErr: `unknown layout component "usecases" (did you mean "usecase"?)`},
+		{name: "empty", layout: map[string]string{"job": ""}, wantErr: "layout.job: directory cannot be empty"},
+		{name: "absolute", layout: map[string]string{"job": "/srv/jobs"}, wantErr: "must be relative to the project root"},
+		{name: "outside the project", layout: map[string]string{"job": "internal/../../jobs"}, wantErr: "must be inside the project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromConfig(config.Config{Layout: tt.layout})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				if got != Default() {
+					t.Errorf("layout = %+v, want the default one", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := Default()
+			tt.want(&want)
+			if got != want {
+				t.Errorf("layout = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// inProject runs the test from an empty project directory, with no user
+// configuration and no flags given, and the layout loaded again.
+func inProject(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(config.FileEnv, "")
+	config.Setup("", nil)
+	ResetCurrent()
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+		config.Setup("", nil)
+		ResetCurrent()
+	})
+}
+
+func TestCurrent(t *testing.T) {
+	usecase := filepath.Join("internal", "app", "usecase")
+	tests := []struct {
+		name string
+		file string
+		env  map[string]string
+		set  []string
+		want string
+	}{
+		{name: "default", want: Default().Usecase},
+		{name: "project file", file: "layout:\n  usecase: internal/app/usecase\n", want: usecase},
+		{name: "environment", env: map[string]string{"VANDOR_LAYOUT_USECASE": "internal/app/usecase"}, want: usecase},
+		{name: "--set", file: "layout:\n  usecase: internal/file\n", set: []string{"layout.usecase=internal/app/usecase"}, want: usecase},
+		{name: "broken layout falls back to the default", file: "layout:\n  usecases: internal/app/usecase\n", want: Default().Usecase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inProject(t)
+			if tt.file != "" {
+				if err := os.WriteFile(config.FileName, []byte(tt.file), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			config.Setup("", tt.set)
+
+			if got := Current().Usecase; got != tt.want {
+				t.Errorf("usecase directory = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainRegistry(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{Default().Domain, filepath.Join("internal", "core", "domain")},
+		{filepath.Join("internal", "domain", "model"), filepath.Join("internal", "domain")},
+	}
+	for _, tt := range tests {
+		if got := (Layout{Domain: tt.domain}).DomainRegistry(); got != tt.want {
+			t.Errorf("domain registry of %s = %q, want %q", tt.domain, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package layout
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// legacy lists the directories earlier versions of the add commands created
+// components in, by kind, which sync never discovered them from.
+var legacy = map[string][]string{
+	"domain":    {filepath.Join("internal", "core", "domain")},
+	"job":       {filepath.Join("internal", "core", "job")},
+	"handler":   {filepath.Join("internal", "delivery", "http", "handler")},
+	"scheduler": {filepath.Join("internal", "cron", "scheduler")},
+}
+
+// retired lists, by kind, the generated files earlier versions of sync
+// wrote, which it now writes elsewhere: the job registry, now generated into
+// the package of the jobs.
+var retired = map[string][]retiredFile{
+	"job": {
+		{Path: filepath.Join("internal", "core", "job", "jobs.go"), Package: true},
+		{Path: filepath.Join("internal", "delivery", "worker", "worker_gen.go")},
+	},
+}
+
+// retiredFile is a generated file sync no longer writes.
+type retiredFile struct {
+	Path    string
+	Package bool // Its package retires with it, and imports of it follow the components of its kind
+}
+
+// grouped are the kinds with a directory per group.
+var grouped = map[string]bool{"service": true, "handler": true}
+
+// Migration is the plan that brings a project into its layout.
+type Migration struct {
+	Moves []Move
+	// Removes are the project-relative paths of retired generated files.
+	Removes []string
+	// Packages maps the project-relative directories of packages that move
+	// whole to their new directories, so that imports of them follow.
+	Packages map[string]string
+}
+
+// Move is a Go file to move to where the layout expects it.
+type Move struct {
+	Kind      string
+	From      string // Project-relative path of the file
+	To        string // Project-relative path the layout expects it at
+	Package   string // New package clause, when the file changes package
+	Generated bool   // The file is generated, and may replace a generated file
+}
+
+// Plan returns the migration that brings the components of the project in
+// root into layout l.
+//
+// Components created in legacy directories move into the layout, leaving
+// generated files such as the registries behind for sync. The default
+// directories of kinds l overrides move whole, registries included, and
+// imports of their packages are rewritten; so does the domain registry when
+// its directory changes. Retired generated files are removed, and imports
+// of the legacy job registry follow the jobs. Moving onto an existing file
+// is an error, unless both are generated.
+func Plan(root string, l Layout) (Migration, error) {
+	m := Migration{Packages: map[string]string{}}
+	defaults := Default()
+	for _, kind := range Kinds() {
+		target, _ := l.Dir(kind)
+		for _, source := range legacy[kind] {
+			if source == target {
+				continue
+			}
+			if err := m.plan(root, kind, source, target, false); err != nil {
+				return Migration{}, err
+			}
+		}
+		if def, _ := defaults.Dir(kind); def != target {
+			if err := m.plan(root, kind, def, target, true); err != nil {
+				return Migration{}, err
+			}
+		}
+		for _, file := range retired[kind] {
+			if !generated(filepath.Join(root, file.Path)) {
+				continue
+			}
+			m.Removes = append(m.Removes, file.Path)
+			if dir := filepath.Dir(file.Path); file.Package && dir != target {
+				m.Packages[dir] = target
+			}
+		}
+	}
+	if from, to := defaults.DomainRegistry(), l.DomainRegistry(); from != to {
+		m.planGenerated(root, "domain", from, to)
+	}
+
+	var conflicts []string
+	seen := map[string]bool{}
+	for _, move := range m.Moves {
+		path := filepath.Join(root, move.To)
+		if seen[move.To] || (exists(path) && !(move.Generated && generated(path))) {
+			conflicts = append(conflicts, fmt.Sprintf("%s -> %s", move.From, move.To))
+		}
+		seen[move.To] = true
+	}
+	if len(conflicts) > 0 {
+		return Migration{}, fmt.Errorf("files already exist where the layout expects:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+	return m, nil
+}
+
+// plan adds the moves of one kind from a source directory. A relocated
+// directory moves whole; otherwise its generated files stay.
+func (m *Migration) plan(root, kind, source, target string, relocate bool) error {
+	if !grouped[kind] || relocate {
+		if err := m.planDir(root, kind, source, target, relocate); err != nil {
+			return err
+		}
+	}
+	if !grouped[kind] {
+		return nil
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, source))
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		group := filepath.Join(source, entry.Name())
+		// A group directory holding the target is the target's parent, not
+		// a group
+		if !entry.IsDir() || group == target || strings.HasPrefix(target, group+string(filepath.Separator)) {
+			continue
+		}
+		if err := m.planDir(root, kind, group, filepath.Join(target, entry.Name()), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planDir adds the moves of the Go files directly in from to directory to.
+// Unless whole is set, generated files are left behind; a package moving
+// whole is recorded so that its imports follow.
+func (m *Migration) planDir(root, kind, from, to string, whole bool) error {
+	entries, err := os.ReadDir(filepath.Join(root, from))
+	if err != nil {
+		return nil
+	}
+
+	pkg, err := packageOf(filepath.Join(root, to))
+	if err != nil {
+		return err
+	}
+
+	moved := false
+	for _, entry := range entries {
+		path := filepath.Join(from, entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(root, path), nil, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if !whole && ast.IsGenerated(f) {
+			continue
+		}
+
+		move := Move{Kind: kind, From: path, To: filepath.Join(to, entry.Name()), Generated: ast.IsGenerated(f)}
+		if current := f.Name.Name; pkg != "" {
+			want := pkg
+			if strings.HasSuffix(current, "_test") && !strings.HasSuffix(want, "_test") {
+				want += "_test"
+			}
+			if current != want {
+				move.Package = want
+			}
+		}
+		m.Moves = append(m.Moves, move)
+		moved = true
+	}
+
+	if whole && moved {
+		m.Packages[from] = to
+	}
+	return nil
+}
+
+// planGenerated adds the moves of the generated Go files directly in from,
+// such as a registry kept outside the directory of its kind, to directory
+// to, recording the package as moved.
+func (m *Migration) planGenerated(root, kind, from, to string) {
+	entries, err := os.ReadDir(filepath.Join(root, from))
+	if err != nil {
+		return
+	}
+	moved := false
+	for _, entry := range entries {
+		path := filepath.Join(from, entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || !generated(filepath.Join(root, path)) {
+			continue
+		}
+		m.Moves = append(m.Moves, Move{Kind: kind, From: path, To: filepath.Join(to, entry.Name()), Generated: true})
+		moved = true
+	}
+	if moved {
+		m.Packages[from] = to
+	}
+}
+
+// packageOf returns the package of the first non-test Go file of a
+// directory; "" when there is none.
+func packageOf(dir string) (string, error) {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	sort.Strings(matches)
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), match, nil, parser.PackageClauseOnly)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", match, err)
+		}
+		return f.Name.Name, nil
+	}
+	return "", nil
+}
+
+// generated reports whether the Go file at path is generated.
+func generated(path string) bool {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly|parser.ParseComments)
+	return err == nil && ast.IsGenerated(f)
+}
+
+// Apply moves the files of a migration, giving them their new package
+// clause, removes its retired files and the directories it leaves empty,
+// and rewrites the imports of the packages that moved in every Go file of
+// the project of module.
+func Apply(root, module string, m Migration) error {
+	emptied := map[string]bool{}
+	for _, move := range m.Moves {
+		from, to := filepath.Join(root, move.From), filepath.Join(root, move.To)
+		content, err := os.ReadFile(from)
+		if err != nil {
+			return err
+		}
+		if move.Package != "" {
+			if content, err = setPackage(from, content, move.Package); err != nil {
+				return err
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(to), err)
+		}
+		if err := os.WriteFile(to, content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", to, err)
+		}
+		if err := os.Remove(from); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", from, err)
+		}
+		emptied[filepath.Dir(from)] = true
+	}
+
+	for _, path := range m.Removes {
+		if err := os.Remove(filepath.Join(root, path)); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		emptied[filepath.Dir(filepath.Join(root, path))] = true
+	}
+
+	for dir := range emptied {
+		removeEmptyParents(root, dir)
+	}
+
+	if len(m.Packages) == 0 {
+		return nil
+	}
+	imports := map[string]string{}
+	for from, to := range m.Packages {
+		imports[module+"/"+filepath.ToSlash(from)] = module + "/" + filepath.ToSlash(to)
+	}
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		return rewriteImports(path, imports)
+	})
+}
+
+// rewriteImports replaces the import paths of a Go file that are keys of
+// imports with their values.
+func rewriteImports(path string, imports map[string]string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, content, parser.ImportsOnly)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	// Replace from the last import so earlier offsets stay valid
+	out := content
+	for i := len(f.Imports) - 1; i >= 0; i-- {
+		spec := f.Imports[i]
+		current, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		replacement, ok := imports[current]
+		if !ok {
+			continue
+		}
+		start := fset.Position(spec.Path.Pos()).Offset
+		end := fset.Position(spec.Path.End()).Offset
+
+		replaced := append([]byte{}, out[:start]...)
+		replaced = append(replaced, strconv.Quote(replacement)...)
+		out = append(replaced, out[end:]...)
+	}
+	if bytes.Equal(out, content) {
+		return nil
+	}
+	return os.WriteFile(path, out, 0644)
+}
+
+// removeEmptyParents removes dir and its parents up to root while they are
+// empty.
+func removeEmptyParents(root, dir string) {
+	root = filepath.Clean(root)
+	for dir != root && dir != "." && dir != string(filepath.Separator) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// setPackage replaces the package name of a Go file's package clause.
+func setPackage(path string, content []byte, pkg string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, content, parser.PackageClauseOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	start := fset.Position(f.Name.Pos()).Offset
+	end := fset.Position(f.Name.End()).Offset
+
+	out := append([]byte{}, content[:start]...)
+	out = append(out, pkg...)
+	return append(out, content[end:]...), nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
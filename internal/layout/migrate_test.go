@@ -0,0 +1,254 @@
+package layout_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+	"github.com/alfariiizi/vandor-cli/internal/generators"
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+)
+
+const module = "example.com/app"
+
+// project is a project with its domains and jobs in the default layout, an
+// earlier job created in the legacy job directory and the job registry
+// earlier versions of sync generated.
+var project = map[string]string{
+	"go.mod": "module example.com/app\n\ngo 1.23\n",
+	"internal/core/model/model.go": `package model
+
+import "context"
+
+type Job[P any] interface {
+	Key() string
+	Handle(ctx context.Context, payload P) error
+}
+`,
+	"internal/infrastructure/db/db.go": "package db\n\ntype Client struct{}\n\ntype User struct{ ID int }\n",
+	"internal/core/domain/builder/builder.go": `package domain_builder
+
+import "example.com/app/internal/infrastructure/db"
+
+type Domain[E, D any] struct {
+	wrap   func(E, *db.Client) D
+	client *db.Client
+}
+
+func NewDomain[E, D any](wrap func(E, *db.Client) D, client *db.Client) Domain[E, D] {
+	return Domain[E, D]{wrap: wrap, client: client}
+}
+`,
+	"internal/core/domain/model/user.go": `package domain
+
+import (
+	domain_builder "example.com/app/internal/core/domain/builder"
+	"example.com/app/internal/infrastructure/db"
+)
+
+type User struct {
+	*db.User
+	client *db.Client
+}
+
+func NewUserDomain(client *db.Client) domain_builder.Domain[*db.User, *User] {
+	return domain_builder.NewDomain(func(e *db.User, c *db.Client) *User {
+		return &User{User: e, client: c}
+	}, client)
+}
+`,
+	"internal/delivery/worker/job/send_email.go": jobSrc("SendEmail"),
+	"internal/core/job/cleanup.go":               jobSrc("Cleanup"),
+	"internal/core/job/jobs.go":                  "// Code generated by vandor; DO NOT EDIT.\n\npackage job\n\ntype Jobs struct {\n\tCleanup Cleanup\n}\n",
+	"internal/delivery/worker/worker_gen.go":     "// Code generated by vandor; DO NOT EDIT.\n\npackage worker\n\nimport \"example.com/app/internal/core/job\"\n\nvar _ = job.CleanupPayload{}\n",
+	"internal/delivery/worker/client.go":         "package worker\n\ntype Client struct{}\n",
+	"cmd/app/main.go": `package main
+
+import (
+	domain_entries "example.com/app/internal/core/domain"
+	"example.com/app/internal/core/job"
+)
+
+var (
+	_ = domain_entries.NewDomain
+	_ = job.NewJobs
+	_ = job.RegisterJobs
+)
+
+func main() {}
+`,
+}
+
+func jobSrc(name string) string {
+	return strings.NewReplacer("Name", name, "name", strings.ToLower(name[:1])+name[1:]).Replace(`package job
+
+import (
+	"context"
+
+	"example.com/app/internal/core/model"
+)
+
+type NamePayload struct{}
+
+type Name model.Job[NamePayload]
+
+type name struct{}
+
+func NewName() Name {
+	return &name{}
+}
+
+func (j *name) Key() string {
+	return "job:name"
+}
+
+func (j *name) Handle(ctx context.Context, payload NamePayload) error {
+	return nil
+}
+`)
+}
+
+// inProject runs the test from a project holding files, with no user
+// configuration and the layout loaded again.
+func inProject(t *testing.T, files map[string]string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(config.FileEnv, "")
+	config.Setup("", nil)
+	layout.ResetCurrent()
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+		layout.ResetCurrent()
+	})
+
+	for name, content := range files {
+		writeFile(t, name, content)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// syncRegistries regenerates the domain and job registries, as layout
+// migrate does.
+func syncRegistries(t *testing.T) {
+	t.Helper()
+	for _, registry := range []generators.RegistryGenerator{generators.DomainRegistry, generators.JobRegistry} {
+		if err := registry.Generate(io.Discard); err != nil {
+			t.Fatalf("sync %s: %v", registry.Name, err)
+		}
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	inProject(t, project)
+	syncRegistries(t)
+
+	writeFile(t, config.FileName, "layout:\n  domain: internal/domain/model\n  job: internal/worker/jobs\n")
+	layout.ResetCurrent()
+	l := layout.Current()
+
+	migration, err := layout.Plan(".", l)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if err := layout.Apply(".", module, migration); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	syncRegistries(t)
+
+	tests := []struct {
+		path   string
+		exists bool
+	}{
+		{"internal/domain/model/user.go", true},
+		{"internal/domain/domain.go", true},
+		{"internal/worker/jobs/send_email.go", true},
+		{"internal/worker/jobs/cleanup.go", true},
+		{"internal/worker/jobs/jobs.go", true},
+		{"internal/worker/jobs/worker_gen.go", true},
+		{"internal/delivery/worker/client.go", true},
+		{"internal/core/domain/builder/builder.go", true},
+		{"internal/core/domain/model", false},
+		{"internal/core/domain/domain.go", false},
+		{"internal/core/job", false},
+		{"internal/delivery/worker/job", false},
+		{"internal/delivery/worker/worker_gen.go", false},
+	}
+	for _, tt := range tests {
+		_, err := os.Stat(filepath.FromSlash(tt.path))
+		if exists := err == nil; exists != tt.exists {
+			t.Errorf("%s exists = %v, want %v", tt.path, exists, tt.exists)
+		}
+	}
+
+	registry, err := os.ReadFile(filepath.Join("internal", "worker", "jobs", "jobs.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "fx.Provide(NewCleanup, NewSendEmail, NewJobs)"; !strings.Contains(string(registry), want) {
+		t.Errorf("job registry does not register both jobs:\n%s", registry)
+	}
+
+	if err := gosrc.NewChecker(".", module).CheckProject(); err != nil {
+		t.Errorf("project does not type-check after migrating:\n%v", err)
+	}
+}
+
+func TestMigrateNothingToDo(t *testing.T) {
+	files := map[string]string{}
+	for name, content := range project {
+		if !strings.HasPrefix(name, "internal/core/job/") && name != "internal/delivery/worker/worker_gen.go" {
+			files[name] = content
+		}
+	}
+	files["cmd/app/main.go"] = "package main\n\nfunc main() {}\n"
+	inProject(t, files)
+	syncRegistries(t)
+
+	migration, err := layout.Plan(".", layout.Current())
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if len(migration.Moves) != 0 || len(migration.Removes) != 0 {
+		t.Errorf("migration of a project in its layout = %+v, want none", migration)
+	}
+	if err := gosrc.NewChecker(".", module).CheckProject(); err != nil {
+		t.Errorf("project does not type-check:\n%v", err)
+	}
+}
+
+func TestMigrateRefusesToOverwrite(t *testing.T) {
+	inProject(t, project)
+	writeFile(t, "internal/worker/jobs/send_email.go", "package jobs\n")
+
+	l := layout.Default()
+	l.Job = filepath.Join("internal", "worker", "jobs")
+	_, err := layout.Plan(".", l)
+	if err == nil || !strings.Contains(err.Error(), filepath.Join("internal", "worker", "jobs", "send_email.go")) {
+		t.Fatalf("error = %v, want a conflict on send_email.go", err)
+	}
+	if _, err := os.Stat(filepath.Join("internal", "delivery", "worker", "job", "send_email.go")); errors.Is(err, fs.ErrNotExist) {
+		t.Error("a refused migration moved files")
+	}
+}
@@ -0,0 +1,233 @@
+// Package naming validates and normalizes the names given to the add
+// commands, and detects generated declarations that collide with the ones
+// already in a package.
+package naming
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Name is a component name in the forms generated code uses.
+type Name struct {
+	Pascal string // Exported type name, e.g. "CreateUser"
+	Camel  string // Receiver and variable name, e.g. "createUser"
+	Snake  string // e.g. "create_user"
+	Kebab  string // e.g. "create-user"
+}
+
+// Error is an invalid name, with a valid one to use instead when there is
+// one.
+type Error struct {
+	Kind       string // What is named, e.g. "usecase" or "service group"
+	Name       string // The name as given
+	Reason     string
+	Suggestion string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("invalid %s name %q: %s", e.Kind, e.Name, e.Reason)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return msg
+}
+
+// Component validates the name of a component of kind, e.g. "usecase", and
+// returns its forms. Names may be given in any case, with words separated by
+// underscores, dashes or spaces.
+func Component(kind, name string) (Name, error) {
+	if strings.TrimSpace(name) == "" {
+		return Name{}, fmt.Errorf("%s name cannot be empty", kind)
+	}
+	if err := check(kind, name); err != nil {
+		return Name{}, err
+	}
+
+	n := forms(name)
+	if !token.IsIdentifier(n.Pascal) || !token.IsExported(n.Pascal) {
+		return Name{}, &Error{Kind: kind, Name: name, Reason: "must start with a letter that has an upper case"}
+	}
+	if reason := reserved(n.Camel); reason != "" {
+		return Name{}, &Error{Kind: kind, Name: name, Reason: reason, Suggestion: n.Pascal + utils.ToPascalCase(kind)}
+	}
+	return n, nil
+}
+
+// Package validates a group name, e.g. of a service, and returns the package
+// name it is generated in, in snake case.
+func Package(kind, group string) (string, error) {
+	if strings.TrimSpace(group) == "" {
+		return "", fmt.Errorf("%s cannot be empty", kind)
+	}
+	if err := check(kind, group); err != nil {
+		return "", err
+	}
+
+	pkg := forms(group).Snake
+	if reason := reserved(pkg); reason != "" {
+		return "", &Error{Kind: kind, Name: group, Reason: reason, Suggestion: pkg + "s"}
+	}
+	if !token.IsIdentifier(pkg) {
+		return "", &Error{Kind: kind, Name: group, Reason: "is not a valid package name"}
+	}
+	return pkg, nil
+}
+
+// forms returns the forms of a name, on top of the case conversions of
+// utils.
+func forms(name string) Name {
+	pascal := utils.ToPascalCase(strings.TrimSpace(name))
+	return Name{
+		Pascal: pascal,
+		Camel:  utils.ToCamelCase(pascal),
+		Snake:  utils.ToSnakeCase(pascal),
+		Kebab:  utils.ToKebabCase(pascal),
+	}
+}
+
+// check rejects names holding characters that cannot be part of an
+// identifier, or starting with a digit.
+func check(kind, name string) error {
+	var invalid []string
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_- ", r) {
+			invalid = append(invalid, fmt.Sprintf("%q", r))
+		}
+	}
+	if len(invalid) > 0 {
+		return &Error{
+			Kind:       kind,
+			Name:       name,
+			Reason:     "contains " + strings.Join(invalid, ", "),
+			Suggestion: suggest(utils.ToGoIdentifier(name)),
+		}
+	}
+
+	trimmed := strings.TrimLeft(name, "_- ")
+	if trimmed != "" && unicode.IsDigit([]rune(trimmed)[0]) {
+		return &Error{Kind: kind, Name: name, Reason: "starts with a digit", Suggestion: suggest(trimmed)}
+	}
+	return nil
+}
+
+// digits spell out the digits a name cannot start with.
+var digits = []string{"Zero", "One", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine"}
+
+// suggest returns the valid Pascal case name closest to name: its leading
+// digits spelled out, e.g. "2fa" becomes "TwoFa"; "" when there is none.
+func suggest(name string) string {
+	name = strings.TrimLeft(name, "_- ")
+	var prefix strings.Builder
+	for name != "" && name[0] >= '0' && name[0] <= '9' {
+		prefix.WriteString(digits[name[0]-'0'])
+		name = name[1:]
+	}
+
+	suggestion := utils.ToPascalCase(prefix.String() + "_" + name)
+	if !token.IsIdentifier(suggestion) || !token.IsExported(suggestion) || reserved(utils.ToCamelCase(suggestion)) != "" {
+		return ""
+	}
+	return suggestion
+}
+
+// reserved returns why an unexported identifier cannot name generated
+// code: it is a Go keyword, or a predeclared type or constant that
+// generated code uses and would be shadowed; "" when it can.
+func reserved(ident string) string {
+	if token.IsKeyword(ident) {
+		return "its camel case " + ident + " is a Go keyword"
+	}
+	switch types.Universe.Lookup(ident).(type) {
+	case *types.TypeName, *types.Const, *types.Nil:
+		return "its camel case " + ident + " is a predeclared Go identifier"
+	}
+	return ""
+}
+
+// Collisions reports the top-level declarations of src, the Go file to be
+// written to path, that other files of its package already declare. The
+// file at path itself is not compared, since it is replaced.
+func Collisions(path string, src []byte) error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	declared := declarations(f)
+	if len(declared) == 0 {
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+	entries, err := vfs.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var collisions []string
+	for _, entry := range entries {
+		other := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || !strings.HasSuffix(other, ".go") || filepath.Clean(other) == filepath.Clean(path) {
+			continue
+		}
+		existing, err := gosrc.ParseFile(fset, other, parser.SkipObjectResolution)
+		if err != nil || existing.Name.Name != f.Name.Name {
+			continue
+		}
+		for name, pos := range declarations(existing) {
+			if _, ok := declared[name]; ok {
+				collisions = append(collisions, fmt.Sprintf("%s is already declared at %s", name, fset.Position(pos)))
+			}
+		}
+	}
+
+	if len(collisions) == 0 {
+		return nil
+	}
+	sort.Strings(collisions)
+	return fmt.Errorf("cannot create %s:\n  %s", path, strings.Join(collisions, "\n  "))
+}
+
+// declarations returns the package-level names f declares, with their
+// positions.
+func declarations(f *ast.File) map[string]token.Pos {
+	names := map[string]token.Pos{}
+	add := func(id *ast.Ident) {
+		if id.Name != "_" && id.Name != "init" {
+			names[id.Name] = id.Pos()
+		}
+	}
+
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				add(d.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					add(s.Name)
+				case *ast.ValueSpec:
+					for _, id := range s.Names {
+						add(id)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
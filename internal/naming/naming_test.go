@@ -0,0 +1,158 @@
+package naming
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestComponent(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       Name
+		wantErr    string
+		suggestion string
+	}{
+		{name: "create_user", want: Name{"CreateUser", "createUser", "create_user", "create-user"}},
+		{name: "CreateUser", want: Name{"CreateUser", "createUser", "create_user", "create-user"}},
+		{name: "createUser", want: Name{"CreateUser", "createUser", "create_user", "create-user"}},
+		{name: " create user ", want: Name{"CreateUser", "createUser", "create_user", "create-user"}},
+		{name: "Delete", want: Name{"Delete", "delete", "delete", "delete"}},
+		{name: "", wantErr: "usecase name cannot be empty"},
+		{name: "user@name", wantErr: `contains '@'`, suggestion: "Username"},
+		{name: "2fa", wantErr: "starts with a digit", suggestion: "TwoFa"},
+		{name: "type", wantErr: "type is a Go keyword", suggestion: "TypeUsecase"},
+		{name: "string", wantErr: "string is a predeclared Go identifier", suggestion: "StringUsecase"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Component("usecase", tt.name)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("got %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			var nameErr *Error
+			if errors.As(err, &nameErr) && nameErr.Suggestion != tt.suggestion {
+				t.Errorf("suggestion = %q, want %q", nameErr.Suggestion, tt.suggestion)
+			}
+		})
+	}
+}
+
+func TestPackage(t *testing.T) {
+	tests := []struct {
+		group      string
+		want       string
+		wantErr    string
+		suggestion string
+	}{
+		{group: "user", want: "user"},
+		{group: "BlogPost", want: "blog_post"},
+		{group: "blog-post", want: "blog_post"},
+		{group: "", wantErr: "service group cannot be empty"},
+		{group: "my.group", wantErr: `contains '.'`, suggestion: "Mygroup"},
+		{group: "func", wantErr: "func is a Go keyword", suggestion: "funcs"},
+		{group: "string", wantErr: "string is a predeclared Go identifier", suggestion: "strings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.group, func(t *testing.T) {
+			got, err := Package("service group", tt.group)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("got %q, want %q", got, tt.want)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			var nameErr *Error
+			if errors.As(err, &nameErr) && nameErr.Suggestion != tt.suggestion {
+				t.Errorf("suggestion = %q, want %q", nameErr.Suggestion, tt.suggestion)
+			}
+		})
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	dir := t.TempDir()
+	existing := map[string]string{
+		"user.go":      "package domain\n\ntype User struct{}\n\nfunc NewUserDomain() {}\n\nfunc (u *User) String() string { return \"\" }\n",
+		"other.go":     "package other\n\ntype Product struct{}\n",
+		"replace.go":   "package domain\n\ntype Order struct{}\n",
+		"notes.txt":    "type Product struct{}\n",
+		"constants.go": "package domain\n\nconst (\n\tMaxUsers = 10\n\t_        = 0\n)\n",
+	}
+	for name, content := range existing {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		src     string
+		wantErr []string
+	}{
+		{
+			name: "no collision",
+			file: "product.go",
+			src:  "package domain\n\ntype Product struct{}\n\nfunc String() {}\n\nvar _ = 1\n",
+		},
+		{
+			name:    "type and function",
+			file:    "user2.go",
+			src:     "package domain\n\ntype User struct{}\n\nfunc NewUserDomain() {}\n",
+			wantErr: []string{"User is already declared", "NewUserDomain is already declared"},
+		},
+		{
+			name:    "constant",
+			file:    "limits.go",
+			src:     "package domain\n\nvar MaxUsers = 5\n",
+			wantErr: []string{"MaxUsers is already declared"},
+		},
+		{
+			name: "the file being replaced",
+			file: "replace.go",
+			src:  "package domain\n\ntype Order struct{ ID int }\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Collisions(filepath.Join(dir, tt.file), []byte(tt.src))
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected a collision")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,1030 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Version is the OpenAPI version of exported documents.
+const Version = "3.1.0"
+
+// bearerScheme is the security scheme of operations registered with
+// BearerAuth.
+const bearerScheme = "bearer"
+
+const humaPath = "github.com/danielgtaylor/huma/v2"
+
+// Analyze builds an OpenAPI document from the huma handlers in the group
+// directories under dir, without compiling or running them. Routes are read
+// from the method.<METHOD>(api, path, method.Operation{...}, h.Handler) and
+// huma.Register calls of RegisterRoutes methods, and schemas from the Input
+// and Output types of the handler functions, following the struct tags huma
+// reads. module is the project's Go module, used to find the packages the
+// handlers import. Constructs that cannot be analyzed are reported as
+// warnings and left out.
+func Analyze(root, module, dir string, info Info) (*Document, []string, error) {
+	a := &analyzer{
+		root:       root,
+		module:     module,
+		pkgs:       map[string]*pkg{},
+		components: map[string]string{},
+		owners:     map[string]string{},
+		doc: &Document{
+			OpenAPI:    Version,
+			Info:       info,
+			Paths:      map[string]*PathItem{},
+			Components: &Components{Schemas: map[string]*Schema{}},
+		},
+	}
+
+	entries, err := vfs.ReadDir(filepath.Join(root, dir))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read handlers in %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		p, err := a.load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := a.routes(p); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(a.doc.Components.Schemas) == 0 {
+		a.doc.Components.Schemas = nil
+	}
+	return a.doc, a.warnings, nil
+}
+
+type analyzer struct {
+	root       string
+	module     string
+	pkgs       map[string]*pkg   // By project-relative directory
+	components map[string]string // Type key to component name
+	owners     map[string]string // Component name to type key
+	doc        *Document
+	warnings   []string
+	depth      int
+}
+
+// pkg is a parsed package of the project.
+type pkg struct {
+	dir     string
+	fset    *token.FileSet
+	types   map[string]decl
+	funcs   map[string]*ast.FuncDecl
+	methods map[string]map[string]*ast.FuncDecl // Receiver type to name
+	files   []*ast.File
+}
+
+// decl is a type declaration, its doc comment and the file declaring it.
+type decl struct {
+	spec *ast.TypeSpec
+	doc  *ast.CommentGroup
+	file *ast.File
+}
+
+// scope resolves the identifiers of a type expression: the package and file
+// it appears in and the type arguments of the generic type it belongs to.
+type scope struct {
+	pkg  *pkg
+	file *ast.File
+	args map[string]scoped
+}
+
+// scoped is a type expression and its scope.
+type scoped struct {
+	expr  ast.Expr
+	scope *scope
+}
+
+func (a *analyzer) warn(format string, args ...any) {
+	a.warnings = append(a.warnings, fmt.Sprintf(format, args...))
+}
+
+// load parses the package in a project-relative directory.
+func (a *analyzer) load(dir string) (*pkg, error) {
+	if p, ok := a.pkgs[dir]; ok {
+		return p, nil
+	}
+
+	p := &pkg{
+		dir:     dir,
+		fset:    token.NewFileSet(),
+		types:   map[string]decl{},
+		funcs:   map[string]*ast.FuncDecl{},
+		methods: map[string]map[string]*ast.FuncDecl{},
+	}
+	a.pkgs[dir] = p
+
+	entries, err := vfs.ReadDir(filepath.Join(a.root, dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return p, nil
+		}
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := gosrc.ParseFile(p.fset, filepath.Join(a.root, dir, entry.Name()), parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		p.files = append(p.files, file)
+
+		for _, d := range file.Decls {
+			switch d := d.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if spec, ok := spec.(*ast.TypeSpec); ok {
+						doc := spec.Doc
+						if doc == nil && len(d.Specs) == 1 {
+							doc = d.Doc
+						}
+						p.types[spec.Name.Name] = decl{spec, doc, file}
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv == nil || len(d.Recv.List) == 0 {
+					p.funcs[d.Name.Name] = d
+					continue
+				}
+				recv := receiverName(d.Recv.List[0].Type)
+				if p.methods[recv] == nil {
+					p.methods[recv] = map[string]*ast.FuncDecl{}
+				}
+				p.methods[recv][d.Name.Name] = d
+			}
+		}
+	}
+	return p, nil
+}
+
+// receiverName returns the type name of a method receiver.
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(e.X)
+	case *ast.IndexExpr:
+		return receiverName(e.X)
+	case *ast.IndexListExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+// importPath returns the import path a file imports under a name.
+func importPath(file *ast.File, name string) string {
+	for _, imp := range file.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		local := path.Base(p)
+		if strings.HasPrefix(local, "v") && len(local) > 1 && unicode.IsDigit(rune(local[1])) {
+			// Major version suffix e.g. huma/v2
+			local = path.Base(path.Dir(p))
+		}
+		if imp.Name != nil {
+			local = imp.Name.Name
+		}
+		if local == name {
+			return p
+		}
+	}
+	return ""
+}
+
+// projectDir returns the directory of a project package, or "" for other
+// import paths.
+func (a *analyzer) projectDir(importPath string) string {
+	rel, ok := strings.CutPrefix(importPath, a.module+"/")
+	if !ok {
+		return ""
+	}
+	return filepath.FromSlash(rel)
+}
+
+// route is a registered operation.
+type route struct {
+	method  string
+	path    string
+	op      *Operation
+	bearer  bool
+	handler *ast.FuncType
+	scope   *scope
+}
+
+// routes adds the operations registered by the RegisterRoutes methods of a
+// package.
+func (a *analyzer) routes(p *pkg) error {
+	recvs := make([]string, 0, len(p.methods))
+	for recv := range p.methods {
+		recvs = append(recvs, recv)
+	}
+	sort.Strings(recvs)
+
+	for _, recv := range recvs {
+		register, ok := p.methods[recv]["RegisterRoutes"]
+		if !ok || register.Body == nil {
+			continue
+		}
+		file := fileOf(p, register)
+		sc := &scope{pkg: p, file: file}
+
+		var err error
+		ast.Inspect(register.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || err != nil {
+				return err == nil
+			}
+			var r *route
+			r, err = a.registration(p, sc, recv, call)
+			if r == nil {
+				return err == nil
+			}
+			if err == nil {
+				err = a.add(r)
+			}
+			return false
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", p.fset.Position(register.Pos()), err)
+		}
+	}
+	return nil
+}
+
+// fileOf returns the file of a package declaring a node.
+func fileOf(p *pkg, node ast.Node) *ast.File {
+	for _, file := range p.files {
+		if file.FileStart <= node.Pos() && node.Pos() <= file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
+
+// registration reads a route registration call, or returns nil for other
+// calls.
+func (a *analyzer) registration(p *pkg, sc *scope, recv string, call *ast.CallExpr) (*route, error) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		if index, ok := call.Fun.(*ast.IndexListExpr); ok {
+			sel, _ = index.X.(*ast.SelectorExpr)
+		}
+		if index, ok := call.Fun.(*ast.IndexExpr); ok {
+			sel, _ = index.X.(*ast.SelectorExpr)
+		}
+		if sel == nil {
+			return nil, nil
+		}
+	}
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+	imported := importPath(sc.file, pkgIdent.Name)
+
+	r := &route{op: &Operation{}, scope: sc}
+	var handler ast.Expr
+	switch {
+	case strings.HasSuffix(imported, "/internal/delivery/http/method") && isMethod(sel.Sel.Name) && len(call.Args) >= 4:
+		// method.GET(api, "/path", method.Operation{...}, h.Handler)
+		r.method = sel.Sel.Name
+		r.path = stringLit(call.Args[1])
+		a.operationFields(call.Args[2], r)
+		handler = call.Args[3]
+	case imported == humaPath && sel.Sel.Name == "Register" && len(call.Args) >= 3:
+		// huma.Register(api, huma.Operation{Method: ..., Path: ...}, h.Handler)
+		a.operationFields(call.Args[1], r)
+		handler = call.Args[2]
+	case imported == humaPath && isMethod(strings.ToUpper(sel.Sel.Name)) && len(call.Args) >= 3:
+		// huma.Get(api, "/path", h.Handler)
+		r.method = strings.ToUpper(sel.Sel.Name)
+		r.path = stringLit(call.Args[1])
+		handler = call.Args[2]
+	default:
+		return nil, nil
+	}
+
+	if r.method == "" || r.path == "" {
+		a.warn("%s: skipping a route whose method or path is not a constant", p.fset.Position(call.Pos()))
+		return nil, nil
+	}
+
+	switch h := handler.(type) {
+	case *ast.FuncLit:
+		r.handler = h.Type
+	case *ast.SelectorExpr:
+		if fn, ok := p.methods[recv][h.Sel.Name]; ok {
+			r.handler = fn.Type
+			r.scope = &scope{pkg: p, file: fileOf(p, fn)}
+		}
+	case *ast.Ident:
+		if fn, ok := p.funcs[h.Name]; ok {
+			r.handler = fn.Type
+			r.scope = &scope{pkg: p, file: fileOf(p, fn)}
+		}
+	}
+	if r.handler == nil {
+		a.warn("%s: skipping %s %s: its handler function was not found", p.fset.Position(call.Pos()), r.method, r.path)
+		return nil, nil
+	}
+	return r, nil
+}
+
+func isMethod(name string) bool {
+	for _, method := range Methods {
+		if name == method {
+			return true
+		}
+	}
+	return false
+}
+
+// stringLit returns the value of a string literal or http.Method constant,
+// or "".
+func stringLit(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			value, _ := strconv.Unquote(e.Value)
+			return value
+		}
+	case *ast.SelectorExpr:
+		if method, ok := strings.CutPrefix(e.Sel.Name, "Method"); ok {
+			return strings.ToUpper(method)
+		}
+	}
+	return ""
+}
+
+// operationFields reads a method.Operation or huma.Operation literal.
+func (a *analyzer) operationFields(expr ast.Expr, r *route) {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Method":
+			r.method = stringLit(kv.Value)
+		case "Path":
+			r.path = stringLit(kv.Value)
+		case "OperationID":
+			r.op.OperationID = stringLit(kv.Value)
+		case "Summary":
+			r.op.Summary = stringLit(kv.Value)
+		case "Description":
+			r.op.Description = stringLit(kv.Value)
+		case "Tags":
+			if tags, ok := kv.Value.(*ast.CompositeLit); ok {
+				for _, tag := range tags.Elts {
+					if value := stringLit(tag); value != "" {
+						r.op.Tags = append(r.op.Tags, value)
+					}
+				}
+			}
+		case "Deprecated", "BearerAuth":
+			if ident, ok := kv.Value.(*ast.Ident); ok && ident.Name == "true" {
+				if key.Name == "Deprecated" {
+					r.op.Deprecated = true
+				} else {
+					r.bearer = true
+				}
+			}
+		}
+	}
+}
+
+// add adds a route's operation with its parameters, request body and
+// response to the document.
+func (a *analyzer) add(r *route) error {
+	item := a.doc.Paths[r.path]
+	if item == nil {
+		item = &PathItem{}
+		a.doc.Paths[r.path] = item
+	}
+	if item.Operation(r.method) != nil {
+		return fmt.Errorf("%s %s is registered twice", r.method, r.path)
+	}
+
+	params := r.handler.Params.List
+	results := r.handler.Results
+	if len(params) == 0 || results == nil || len(results.List) == 0 {
+		a.warn("skipping %s %s: its handler is not func(context.Context, *Input) (*Output, error)", r.method, r.path)
+		return nil
+	}
+	input := params[len(params)-1].Type
+	output := results.List[0].Type
+
+	if r.op.OperationID == "" {
+		r.op.OperationID = operationID(r, input)
+	}
+	if r.bearer {
+		r.op.Security = []map[string][]string{{bearerScheme: {}}}
+		if a.doc.Components.SecuritySchemes == nil {
+			a.doc.Components.SecuritySchemes = map[string]map[string]any{}
+		}
+		a.doc.Components.SecuritySchemes[bearerScheme] = map[string]any{
+			"type":         "http",
+			"scheme":       "bearer",
+			"bearerFormat": "JWT",
+		}
+	}
+
+	if err := a.input(r, input); err != nil {
+		return err
+	}
+	if err := a.output(r, output); err != nil {
+		return err
+	}
+	item.SetOperation(r.method, r.op)
+	return nil
+}
+
+// operationID names an operation after its Input type, e.g. ListPetsInput
+// becomes list-pets, or after its method and path.
+func operationID(r *route, input ast.Expr) string {
+	if star, ok := input.(*ast.StarExpr); ok {
+		input = star.X
+	}
+	if ident, ok := input.(*ast.Ident); ok {
+		if name, ok := strings.CutSuffix(ident.Name, "Input"); ok && name != "" {
+			return kebab(name)
+		}
+	}
+	id := strings.ToLower(r.method)
+	for _, segment := range strings.Split(r.path, "/") {
+		if segment == "" {
+			continue
+		}
+		if param, ok := strings.CutPrefix(segment, "{"); ok {
+			segment = "by-" + strings.TrimSuffix(param, "}")
+		}
+		id += "-" + kebab(segment)
+	}
+	return id
+}
+
+// kebab converts a Go or path name to kebab case, e.g. GetPetByID to
+// get-pet-by-id.
+func kebab(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+				b.WriteByte('-')
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// field is a struct field with its scope.
+type field struct {
+	*ast.Field
+	name  string
+	tag   reflect.StructTag
+	scope *scope
+}
+
+// fields returns the fields of a struct type expression, flattening embedded
+// structs without a json name.
+func (a *analyzer) fields(expr ast.Expr, sc *scope) []field {
+	st, sc := a.structType(expr, sc, 0)
+	if st == nil {
+		return nil
+	}
+
+	var fields []field
+	for _, f := range st.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			value, _ := strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(value)
+		}
+		if len(f.Names) == 0 {
+			if name, _, _ := strings.Cut(tag.Get("json"), ","); name == "" {
+				fields = append(fields, a.fields(f.Type, sc)...)
+				continue
+			}
+			fields = append(fields, field{f, receiverName(f.Type), tag, sc})
+			continue
+		}
+		for _, name := range f.Names {
+			if name.IsExported() {
+				fields = append(fields, field{f, name.Name, tag, sc})
+			}
+		}
+	}
+	return fields
+}
+
+// structType resolves a type expression to the struct type it names, with
+// the scope of the struct's declaration.
+func (a *analyzer) structType(expr ast.Expr, sc *scope, depth int) (*ast.StructType, *scope) {
+	if depth > 32 {
+		return nil, nil
+	}
+	switch e := expr.(type) {
+	case *ast.StructType:
+		return e, sc
+	case *ast.StarExpr:
+		return a.structType(e.X, sc, depth+1)
+	case *ast.ParenExpr:
+		return a.structType(e.X, sc, depth+1)
+	}
+
+	d, declScope := a.lookup(expr, sc)
+	if d.spec == nil {
+		if bound, ok := a.bound(expr, sc); ok {
+			return a.structType(bound.expr, bound.scope, depth+1)
+		}
+		return nil, nil
+	}
+	return a.structType(d.spec.Type, declScope, depth+1)
+}
+
+// bound returns the type argument an identifier is bound to.
+func (a *analyzer) bound(expr ast.Expr, sc *scope) (scoped, bool) {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || sc == nil {
+		return scoped{}, false
+	}
+	bound, ok := sc.args[ident.Name]
+	return bound, ok
+}
+
+// lookup resolves a named, possibly instantiated, project type to its
+// declaration and the scope of the declaration's type expression.
+func (a *analyzer) lookup(expr ast.Expr, sc *scope) (decl, *scope) {
+	var indices []ast.Expr
+	switch e := expr.(type) {
+	case *ast.IndexExpr:
+		expr, indices = e.X, []ast.Expr{e.Index}
+	case *ast.IndexListExpr:
+		expr, indices = e.X, e.Indices
+	}
+
+	var p *pkg
+	var name string
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if _, ok := sc.args[e.Name]; ok {
+			return decl{}, nil
+		}
+		p, name = sc.pkg, e.Name
+	case *ast.SelectorExpr:
+		x, ok := e.X.(*ast.Ident)
+		if !ok || sc.file == nil {
+			return decl{}, nil
+		}
+		dir := a.projectDir(importPath(sc.file, x.Name))
+		if dir == "" {
+			return decl{}, nil
+		}
+		loaded, err := a.load(dir)
+		if err != nil {
+			a.warn("%v", err)
+			return decl{}, nil
+		}
+		p, name = loaded, e.Sel.Name
+	default:
+		return decl{}, nil
+	}
+
+	d, ok := p.types[name]
+	if !ok {
+		return decl{}, nil
+	}
+	declScope := &scope{pkg: p, file: d.file, args: map[string]scoped{}}
+	if d.spec.TypeParams != nil {
+		i := 0
+		for _, param := range d.spec.TypeParams.List {
+			for _, paramName := range param.Names {
+				if i < len(indices) {
+					declScope.args[paramName.Name] = scoped{indices[i], sc}
+				}
+				i++
+			}
+		}
+	}
+	return d, declScope
+}
+
+// input adds the parameters and request body of an Input type.
+func (a *analyzer) input(r *route, expr ast.Expr) error {
+	for _, f := range a.fields(expr, r.scope) {
+		param := false
+		for _, in := range []string{"path", "query", "header", "cookie"} {
+			name := f.tag.Get(in)
+			if name == "" {
+				continue
+			}
+			param = true
+			name, _, _ = strings.Cut(name, ",")
+			p := &Parameter{
+				Name:        name,
+				In:          in,
+				Description: f.tag.Get("doc"),
+				Required:    in == "path" || f.tag.Get("required") == "true",
+				Schema:      a.schema(f.Type, f.scope, f.tag),
+			}
+			p.Schema.Description = ""
+			r.op.Parameters = append(r.op.Parameters, p)
+		}
+
+		if f.name == "Body" && !param {
+			contentType := f.tag.Get("contentType")
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			r.op.RequestBody = &RequestBody{
+				Description: f.tag.Get("doc"),
+				Required:    f.tag.Get("required") != "false",
+				Content:     map[string]*MediaType{contentType: {Schema: a.schema(f.Type, f.scope, "")}},
+			}
+		}
+	}
+	return nil
+}
+
+// output adds the success response of an Output type: 200 with its Body, or
+// 204 without one.
+func (a *analyzer) output(r *route, expr ast.Expr) error {
+	response := &Response{Description: "No Content"}
+	status := "204"
+	for _, f := range a.fields(expr, r.scope) {
+		if f.name != "Body" {
+			continue
+		}
+		contentType := f.tag.Get("contentType")
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		response = &Response{
+			Description: "OK",
+			Content:     map[string]*MediaType{contentType: {Schema: a.schema(f.Type, f.scope, "")}},
+		}
+		status = "200"
+	}
+	r.op.Responses = map[string]*Response{status: response}
+	return nil
+}
+
+// schema returns the schema of a type expression with the validation tags of
+// the field it types.
+func (a *analyzer) schema(expr ast.Expr, sc *scope, tag reflect.StructTag) *Schema {
+	s := a.typeSchema(expr, sc)
+	applyTags(s, tag)
+	return s
+}
+
+// typeSchema returns the schema of a type expression: a $ref for named
+// structs, which are added to the components, and an inline schema for
+// everything else.
+func (a *analyzer) typeSchema(expr ast.Expr, sc *scope) *Schema {
+	a.depth++
+	defer func() { a.depth-- }()
+	if a.depth > 64 {
+		a.warn("type %s nests too deeply", exprString(expr))
+		return &Schema{}
+	}
+
+	if bound, ok := a.bound(expr, sc); ok {
+		return a.typeSchema(bound.expr, bound.scope)
+	}
+
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return a.typeSchema(e.X, sc)
+	case *ast.ParenExpr:
+		return a.typeSchema(e.X, sc)
+	case *ast.ArrayType:
+		if ident, ok := e.Elt.(*ast.Ident); ok && ident.Name == "byte" && e.Len == nil {
+			return &Schema{Type: Types{"string"}, Format: "byte"}
+		}
+		return &Schema{Type: Types{"array"}, Items: a.typeSchema(e.Elt, sc)}
+	case *ast.MapType:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: a.typeSchema(e.Value, sc)}
+	case *ast.InterfaceType:
+		return &Schema{}
+	case *ast.StructType:
+		return a.object(e, sc)
+	case *ast.Ident:
+		if s := basicSchema(e.Name); s != nil {
+			return s
+		}
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok && sc.file != nil {
+			switch importPath(sc.file, x.Name) + "." + e.Sel.Name {
+			case "time.Time":
+				return &Schema{Type: Types{"string"}, Format: "date-time"}
+			case "github.com/google/uuid.UUID":
+				return &Schema{Type: Types{"string"}, Format: "uuid"}
+			case "encoding/json.RawMessage":
+				return &Schema{}
+			}
+		}
+	}
+
+	d, declScope := a.lookup(expr, sc)
+	if d.spec == nil {
+		a.warn("cannot resolve type %s; it is exported as any value", exprString(expr))
+		return &Schema{}
+	}
+	if d.spec.Assign != 0 {
+		return a.typeSchema(d.spec.Type, declScope)
+	}
+	st, ok := d.spec.Type.(*ast.StructType)
+	if !ok {
+		// Named non-struct types are inlined, like huma does
+		return a.typeSchema(d.spec.Type, declScope)
+	}
+
+	key := a.canonical(expr, sc)
+	if name, ok := a.components[key]; ok {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	name := a.componentName(d.spec.Name.Name+a.argsName(expr, sc), declScope.pkg.dir, key)
+	a.components[key] = name
+	a.doc.Components.Schemas[name] = &Schema{}
+
+	object := a.object(st, declScope)
+	if d.doc != nil {
+		object.Description = strings.TrimSpace(d.doc.Text())
+	}
+	a.doc.Components.Schemas[name] = object
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// canonical identifies a type independently of the file it is named in:
+// project types by package directory and name, with their type arguments.
+func (a *analyzer) canonical(expr ast.Expr, sc *scope) string {
+	if bound, ok := a.bound(expr, sc); ok {
+		return a.canonical(bound.expr, bound.scope)
+	}
+
+	var indices []ast.Expr
+	base := expr
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + a.canonical(e.X, sc)
+	case *ast.ArrayType:
+		return "[]" + a.canonical(e.Elt, sc)
+	case *ast.MapType:
+		return "map[" + a.canonical(e.Key, sc) + "]" + a.canonical(e.Value, sc)
+	case *ast.IndexExpr:
+		base, indices = e.X, []ast.Expr{e.Index}
+	case *ast.IndexListExpr:
+		base, indices = e.X, e.Indices
+	}
+
+	name := exprString(base)
+	if d, declScope := a.lookup(expr, sc); d.spec != nil && d.spec.Assign != 0 {
+		return a.canonical(d.spec.Type, declScope)
+	} else if d.spec != nil {
+		name = declScope.pkg.dir + "." + d.spec.Name.Name
+	} else if sel, ok := base.(*ast.SelectorExpr); ok && sc.file != nil {
+		if x, ok := sel.X.(*ast.Ident); ok {
+			name = importPath(sc.file, x.Name) + "." + sel.Sel.Name
+		}
+	}
+	if len(indices) == 0 {
+		return name
+	}
+	args := make([]string, len(indices))
+	for i, index := range indices {
+		args[i] = a.canonical(index, sc)
+	}
+	return name + "[" + strings.Join(args, ",") + "]"
+}
+
+// argsName is the component name suffix of a generic instantiation, e.g.
+// ResponseData[pets_service.Pet] is named ResponseDataPet.
+func (a *analyzer) argsName(expr ast.Expr, sc *scope) string {
+	var indices []ast.Expr
+	switch e := expr.(type) {
+	case *ast.IndexExpr:
+		indices = []ast.Expr{e.Index}
+	case *ast.IndexListExpr:
+		indices = e.Indices
+	}
+	var name string
+	for _, index := range indices {
+		name += a.typeName(index, sc)
+	}
+	return name
+}
+
+// typeName names a type argument without its package qualifier, e.g.
+// []pets_service.Pet is named PetList.
+func (a *analyzer) typeName(expr ast.Expr, sc *scope) string {
+	if bound, ok := a.bound(expr, sc); ok {
+		return a.typeName(bound.expr, bound.scope)
+	}
+	if d, declScope := a.lookup(expr, sc); d.spec != nil && d.spec.Assign != 0 {
+		return a.typeName(d.spec.Type, declScope)
+	}
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return strings.ToUpper(e.Name[:1]) + e.Name[1:]
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.StarExpr:
+		return a.typeName(e.X, sc)
+	case *ast.ArrayType:
+		return a.typeName(e.Elt, sc) + "List"
+	case *ast.MapType:
+		return a.typeName(e.Value, sc) + "Map"
+	case *ast.IndexExpr:
+		return a.typeName(e.X, sc) + a.argsName(e, sc)
+	case *ast.IndexListExpr:
+		return a.typeName(e.X, sc) + a.argsName(e, sc)
+	}
+	return "Any"
+}
+
+// componentName returns a component name for a type, prefixed with its
+// package name when another type already has it.
+func (a *analyzer) componentName(name, dir, key string) string {
+	if owner, ok := a.owners[name]; !ok || owner == key {
+		a.owners[name] = key
+		return name
+	}
+	prefixed := ""
+	for _, word := range strings.FieldsFunc(filepath.Base(dir), func(r rune) bool { return r == '_' || r == '-' }) {
+		prefixed += strings.ToUpper(word[:1]) + word[1:]
+	}
+	prefixed += name
+	candidate := prefixed
+	for i := 2; ; i++ {
+		if owner, ok := a.owners[candidate]; !ok || owner == key {
+			a.owners[candidate] = key
+			return candidate
+		}
+		candidate = prefixed + strconv.Itoa(i)
+	}
+}
+
+// object returns the object schema of a struct. Fields are required unless
+// tagged omitempty or required:"false", like huma.
+func (a *analyzer) object(st *ast.StructType, sc *scope) *Schema {
+	s := &Schema{Type: Types{"object"}, Properties: map[string]*Schema{}}
+	for _, f := range a.fields(st, sc) {
+		name, options, _ := strings.Cut(f.tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.name
+		}
+
+		property := a.schema(f.Type, f.scope, f.tag)
+		s.Properties[name] = property
+
+		optional := strings.Contains(","+options+",", ",omitempty,") || strings.Contains(","+options+",", ",omitzero,")
+		switch f.tag.Get("required") {
+		case "true":
+			optional = false
+		case "false":
+			optional = true
+		}
+		if !optional {
+			s.Required = append(s.Required, name)
+		}
+	}
+	sort.Strings(s.Required)
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+	return s
+}
+
+// basicSchema returns the schema of a predeclared type, or nil.
+func basicSchema(name string) *Schema {
+	switch name {
+	case "string":
+		return &Schema{Type: Types{"string"}}
+	case "bool":
+		return &Schema{Type: Types{"boolean"}}
+	case "int", "int8", "int16", "uint", "uint8", "uint16", "byte":
+		return &Schema{Type: Types{"integer"}}
+	case "int32", "rune", "uint32":
+		return &Schema{Type: Types{"integer"}, Format: "int32"}
+	case "int64", "uint64":
+		return &Schema{Type: Types{"integer"}, Format: "int64"}
+	case "float32":
+		return &Schema{Type: Types{"number"}, Format: "float"}
+	case "float64":
+		return &Schema{Type: Types{"number"}, Format: "double"}
+	case "any":
+		return &Schema{}
+	}
+	return nil
+}
+
+// applyTags applies the huma validation tags of a field to its schema. A
+// $ref keeps only the description, as its sibling keywords would apply to
+// every use of the component.
+func applyTags(s *Schema, tag reflect.StructTag) {
+	if doc := tag.Get("doc"); doc != "" {
+		s.Description = doc
+	}
+	if s.Ref != "" {
+		return
+	}
+	if format := tag.Get("format"); format != "" {
+		s.Format = format
+	}
+	if pattern := tag.Get("pattern"); pattern != "" {
+		s.Pattern = pattern
+	}
+	if enum := tag.Get("enum"); enum != "" {
+		for _, value := range strings.Split(enum, ",") {
+			s.Enum = append(s.Enum, typedValue(s, value))
+		}
+	}
+	if value := tag.Get("default"); value != "" {
+		s.Default = typedValue(s, value)
+	}
+	if value := tag.Get("example"); value != "" {
+		s.Example = typedValue(s, value)
+	}
+	for key, dst := range map[string]**float64{"minimum": &s.Minimum, "maximum": &s.Maximum} {
+		if value, err := strconv.ParseFloat(tag.Get(key), 64); err == nil {
+			*dst = &value
+		}
+	}
+	for key, dst := range map[string]**int{"minLength": &s.MinLength, "maxLength": &s.MaxLength} {
+		if value, err := strconv.Atoi(tag.Get(key)); err == nil {
+			*dst = &value
+		}
+	}
+	if tag.Get("nullable") == "true" && !s.Type.Is("null") && len(s.Type) > 0 {
+		s.Type = append(s.Type, "null")
+	}
+}
+
+// typedValue converts a tag value to the type of its schema.
+func typedValue(s *Schema, value string) any {
+	switch s.Type.Name() {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// exprString renders a type expression.
+func exprString(expr ast.Expr) string {
+	return types.ExprString(expr)
+}
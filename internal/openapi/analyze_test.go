@@ -0,0 +1,319 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const (
+	typesSrc = `package types
+
+type OutputResponseData[T any] struct {
+	Body struct {
+		Data T ` + "`json:\"data\"`" + `
+	}
+}
+`
+	methodSrc = `package method
+
+type Operation struct {
+	Summary     string
+	Description string
+	Tags        []string
+	BearerAuth  bool
+}
+`
+	usersSrc = `package user_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/shopspring/decimal"
+
+	"example.com/app/internal/delivery/http/method"
+	"example.com/app/internal/types"
+)
+
+type GetUserInput struct {
+	ID     int    ` + "`path:\"id\" doc:\"ID of the user\"`" + `
+	Page   int64  ` + "`query:\"page\" default:\"1\" minimum:\"1\"`" + `
+	Locale string ` + "`header:\"Accept-Language\" required:\"true\"`" + `
+}
+
+// UserData is a user.
+type UserData struct {
+	Name    string          ` + "`json:\"name\"`" + `
+	Email   *string         ` + "`json:\"email,omitempty\" format:\"email\"`" + `
+	Role    string          ` + "`json:\"role\" enum:\"admin,member\"`" + `
+	Balance decimal.Decimal ` + "`json:\"balance\"`" + `
+	secret  string
+}
+
+type GetUserOutput types.OutputResponseData[UserData]
+
+type CreateUserInput struct {
+	Body struct {
+		Name  string ` + "`json:\"name\"`" + `
+		Admin bool   ` + "`json:\"admin,omitempty\"`" + `
+	}
+}
+
+type CreateUserOutput struct{}
+
+type users struct {
+	api    huma.API
+	prefix string
+}
+
+func (h *users) RegisterRoutes() {
+	method.GET(h.api, "/users/{id}", method.Operation{
+		Summary:    "Get a user",
+		Tags:       []string{"Users"},
+		BearerAuth: true,
+	}, h.Get)
+	huma.Register(h.api, huma.Operation{Method: http.MethodPost, Path: "/users", OperationID: "new-user", Deprecated: true}, h.Create)
+	huma.Get(h.api, "/health", func(ctx context.Context, input *struct{}) (*CreateUserOutput, error) {
+		return nil, nil
+	})
+	method.GET(h.api, h.prefix+"/users", method.Operation{}, h.Get)
+}
+
+func (h *users) Get(ctx context.Context, input *GetUserInput) (*GetUserOutput, error) {
+	return nil, nil
+}
+
+func (h *users) Create(ctx context.Context, input *CreateUserInput) (*CreateUserOutput, error) {
+	return nil, nil
+}
+`
+	adminSrc = `package admin_handler
+
+import (
+	"context"
+
+	"example.com/app/internal/delivery/http/method"
+	"example.com/app/internal/types"
+)
+
+type ListUsersInput struct{}
+
+type UserData struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type ListUsersOutput types.OutputResponseData[[]UserData]
+
+type listUsers struct{}
+
+func (h *listUsers) RegisterRoutes() {
+	method.GET(nil, "/admin/users", method.Operation{Tags: []string{"Admin"}}, h.Handler)
+}
+
+func (h *listUsers) Handler(ctx context.Context, input *ListUsersInput) (*ListUsersOutput, error) {
+	return nil, nil
+}
+`
+	againSrc = `package admin_handler
+
+import (
+	"context"
+
+	"example.com/app/internal/delivery/http/method"
+)
+
+type listAgain struct{}
+
+func (h *listAgain) RegisterRoutes() {
+	method.GET(nil, "/admin/users", method.Operation{}, h.Handler)
+}
+
+func (h *listAgain) Handler(ctx context.Context, input *ListUsersInput) (*ListUsersOutput, error) {
+	return nil, nil
+}
+`
+)
+
+// writeProject writes files, by slash-separated path, into a temporary
+// project and returns its root.
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestAnalyze(t *testing.T) {
+	root := writeProject(t, map[string]string{
+		"internal/types/types.go":                     typesSrc,
+		"internal/delivery/http/method/method.go":     methodSrc,
+		"internal/delivery/http/route/user/user.go":   usersSrc,
+		"internal/delivery/http/route/admin/admin.go": adminSrc,
+	})
+
+	doc, warnings, err := Analyze(root, "example.com/app", filepath.Join("internal", "delivery", "http", "route"), Info{Title: "App", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != Version || doc.Info.Title != "App" {
+		t.Errorf("document is %s %+v, want %s with the given info", doc.OpenAPI, doc.Info, Version)
+	}
+
+	var routes []string
+	for route, item := range doc.Paths {
+		for _, method := range Methods {
+			if item.Operation(method) != nil {
+				routes = append(routes, method+" "+route)
+			}
+		}
+	}
+	slices.Sort(routes)
+	wantRoutes := []string{"GET /admin/users", "GET /health", "GET /users/{id}", "POST /users"}
+	if !slices.Equal(routes, wantRoutes) {
+		t.Errorf("routes = %v, want %v", routes, wantRoutes)
+	}
+
+	// A path that is not a constant and a type outside the project
+	wantWarnings := []string{"skipping a route whose method or path is not a constant", "cannot resolve type decimal.Decimal"}
+	if len(warnings) != len(wantWarnings) {
+		t.Errorf("warnings = %q, want %d", warnings, len(wantWarnings))
+	}
+	for _, want := range wantWarnings {
+		if !slices.ContainsFunc(warnings, func(w string) bool { return strings.Contains(w, want) }) {
+			t.Errorf("warnings = %q, want one about %q", warnings, want)
+		}
+	}
+
+	get := doc.Paths["/users/{id}"].Get
+	if get.OperationID != "get-user" || get.Summary != "Get a user" || !slices.Equal(get.Tags, []string{"Users"}) {
+		t.Errorf("GET /users/{id} = %q %q %v, want get-user, its summary and tag", get.OperationID, get.Summary, get.Tags)
+	}
+	if len(get.Security) != 1 || get.Security[0][bearerScheme] == nil || doc.Components.SecuritySchemes[bearerScheme]["scheme"] != "bearer" {
+		t.Errorf("security = %v with schemes %v, want bearer", get.Security, doc.Components.SecuritySchemes)
+	}
+	var params []string
+	for _, p := range get.Parameters {
+		params = append(params, fmt.Sprintf("%s %s required=%v %v %s default=%v", p.In, p.Name, p.Required, p.Schema.Type, p.Schema.Format, p.Schema.Default))
+	}
+	wantParams := []string{
+		"path id required=true [integer]  default=<nil>",
+		"query page required=false [integer] int64 default=1",
+		"header Accept-Language required=true [string]  default=<nil>",
+	}
+	if !slices.Equal(params, wantParams) {
+		t.Errorf("parameters = %q, want %q", params, wantParams)
+	}
+	if get.Parameters[0].Description != "ID of the user" || get.Parameters[0].Schema.Description != "" {
+		t.Errorf("path parameter documented as %q with schema %q, want the doc on the parameter", get.Parameters[0].Description, get.Parameters[0].Schema.Description)
+	}
+
+	data := dataSchema(t, doc, get.Responses["200"])
+	// The admin handlers are read first and keep the name UserData
+	if data.Ref != "#/components/schemas/UserUserData" {
+		t.Fatalf("GET /users/{id} data = %+v, want the UserUserData component", data)
+	}
+	user := doc.Components.Schemas["UserUserData"]
+	if user.Description != "UserData is a user." || !slices.Equal(user.PropertyNames(), []string{"balance", "email", "name", "role"}) || !slices.Equal(user.Required, []string{"balance", "name", "role"}) {
+		t.Errorf("UserUserData = %+v, want its doc, exported fields and required non-omitempty ones", user)
+	}
+	if email := user.Properties["email"]; email.Format != "email" {
+		t.Errorf("email format = %q, want email", email.Format)
+	}
+	if role := user.Properties["role"]; fmt.Sprint(role.Enum) != "[admin member]" {
+		t.Errorf("role enum = %v, want [admin member]", role.Enum)
+	}
+
+	post := doc.Paths["/users"].Post
+	if post.OperationID != "new-user" || !post.Deprecated || post.Security != nil {
+		t.Errorf("POST /users = %q deprecated=%v security=%v, want the registered operationId, deprecated and public", post.OperationID, post.Deprecated, post.Security)
+	}
+	if post.RequestBody == nil || !post.RequestBody.Required {
+		t.Fatalf("POST /users request body = %+v, want a required body", post.RequestBody)
+	}
+	if body := post.RequestBody.Content["application/json"].Schema; !slices.Equal(body.PropertyNames(), []string{"admin", "name"}) || !slices.Equal(body.Required, []string{"name"}) {
+		t.Errorf("POST /users body = %+v, want admin and a required name", body)
+	}
+	if response := post.Responses["204"]; response == nil || response.Content != nil {
+		t.Errorf("POST /users responses = %+v, want 204 without content", post.Responses)
+	}
+	if health := doc.Paths["/health"].Get; health.OperationID != "get-health" {
+		t.Errorf("GET /health operationId = %q, want one from its route", health.OperationID)
+	}
+
+	list := dataSchema(t, doc, doc.Paths["/admin/users"].Get.Responses["200"])
+	if !list.Type.Is("array") || list.Items == nil || list.Items.Ref != "#/components/schemas/UserData" {
+		t.Errorf("GET /admin/users data = %+v, want an array of UserData", list)
+	}
+	if admin := doc.Components.Schemas["UserData"]; admin == nil || !slices.Equal(admin.PropertyNames(), []string{"name"}) {
+		t.Errorf("UserData = %+v, want the admin type", admin)
+	}
+}
+
+// dataSchema returns the schema of the data property of a response body.
+func dataSchema(t *testing.T, doc *Document, response *Response) *Schema {
+	t.Helper()
+	if response == nil {
+		t.Fatal("no 200 response")
+	}
+	_, body := JSONSchema(response.Content)
+	body, err := doc.Schema(body)
+	if err != nil || body == nil || body.Properties["data"] == nil {
+		t.Fatalf("response body %+v has no data (%v)", body, err)
+	}
+	return body.Properties["data"]
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	dir := filepath.Join("internal", "delivery", "http", "route")
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "no handlers",
+			files:   map[string]string{"go.mod": "module example.com/app\n"},
+			wantErr: "cannot read handlers in " + dir,
+		},
+		{
+			name: "route registered twice",
+			files: map[string]string{
+				"internal/types/types.go":                     typesSrc,
+				"internal/delivery/http/method/method.go":     methodSrc,
+				"internal/delivery/http/route/admin/admin.go": adminSrc,
+				"internal/delivery/http/route/admin/again.go": againSrc,
+			},
+			wantErr: "GET /admin/users is registered twice",
+		},
+		{
+			name: "syntax error",
+			files: map[string]string{
+				"internal/delivery/http/route/admin/admin.go": "package admin_handler\n\nfunc {\n",
+			},
+			wantErr: "admin.go:3:6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeProject(t, tt.files)
+			_, _, err := Analyze(root, "example.com/app", dir, Info{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+// Package openapi models the parts of an OpenAPI 3.0/3.1 document vandor
+// reads and writes: paths, operations, parameters, bodies, responses and
+// schemas, with local $ref resolution.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Methods are the operation methods of a path item, in document order.
+var Methods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `yaml:"openapi" json:"openapi"`
+	Info       Info                 `yaml:"info" json:"info"`
+	Servers    []Server             `yaml:"servers,omitempty" json:"servers,omitempty"`
+	Paths      map[string]*PathItem `yaml:"paths,omitempty" json:"paths,omitempty"`
+	Components *Components          `yaml:"components,omitempty" json:"components,omitempty"`
+	Tags       []Tag                `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+type Info struct {
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Version     string `yaml:"version" json:"version"`
+}
+
+type Server struct {
+	URL         string `yaml:"url" json:"url"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+	Parameters      map[string]*Parameter     `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBodies   map[string]*RequestBody   `yaml:"requestBodies,omitempty" json:"requestBodies,omitempty"`
+	Responses       map[string]*Response      `yaml:"responses,omitempty" json:"responses,omitempty"`
+	SecuritySchemes map[string]map[string]any `yaml:"securitySchemes,omitempty" json:"securitySchemes,omitempty"`
+}
+
+// PathItem holds the operations of a path.
+type PathItem struct {
+	Ref        string       `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Summary    string       `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Parameters []*Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Get        *Operation   `yaml:"get,omitempty" json:"get,omitempty"`
+	Put        *Operation   `yaml:"put,omitempty" json:"put,omitempty"`
+	Post       *Operation   `yaml:"post,omitempty" json:"post,omitempty"`
+	Delete     *Operation   `yaml:"delete,omitempty" json:"delete,omitempty"`
+	Options    *Operation   `yaml:"options,omitempty" json:"options,omitempty"`
+	Head       *Operation   `yaml:"head,omitempty" json:"head,omitempty"`
+	Patch      *Operation   `yaml:"patch,omitempty" json:"patch,omitempty"`
+	Trace      *Operation   `yaml:"trace,omitempty" json:"trace,omitempty"`
+}
+
+// Operation returns the operation of a method, or nil.
+func (p *PathItem) Operation(method string) *Operation {
+	return *p.slot(method)
+}
+
+// SetOperation sets the operation of a method.
+func (p *PathItem) SetOperation(method string, op *Operation) {
+	*p.slot(method) = op
+}
+
+func (p *PathItem) slot(method string) **Operation {
+	switch strings.ToUpper(method) {
+	case "GET":
+		return &p.Get
+	case "PUT":
+		return &p.Put
+	case "POST":
+		return &p.Post
+	case "DELETE":
+		return &p.Delete
+	case "OPTIONS":
+		return &p.Options
+	case "HEAD":
+		return &p.Head
+	case "PATCH":
+		return &p.Patch
+	case "TRACE":
+		return &p.Trace
+	}
+	panic("openapi: unknown method " + method)
+}
+
+type Operation struct {
+	OperationID string                `yaml:"operationId,omitempty" json:"operationId,omitempty"`
+	Summary     string                `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Description string                `yaml:"description,omitempty" json:"description,omitempty"`
+	Tags        []string              `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Parameters  []*Parameter          `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBody *RequestBody          `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `yaml:"responses,omitempty" json:"responses,omitempty"`
+	Security    []map[string][]string `yaml:"security,omitempty" json:"security,omitempty"`
+	Deprecated  bool                  `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Ref         string  `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Name        string  `yaml:"name,omitempty" json:"name,omitempty"`
+	In          string  `yaml:"in,omitempty" json:"in,omitempty"` // path, query, header or cookie
+	Description string  `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool    `yaml:"required,omitempty" json:"required,omitempty"`
+	Schema      *Schema `yaml:"schema,omitempty" json:"schema,omitempty"`
+}
+
+type RequestBody struct {
+	Ref         string                `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Description string                `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool                  `yaml:"required,omitempty" json:"required,omitempty"`
+	Content     map[string]*MediaType `yaml:"content,omitempty" json:"content,omitempty"`
+}
+
+type Response struct {
+	Ref         string                `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Description string                `yaml:"description" json:"description"`
+	Content     map[string]*MediaType `yaml:"content,omitempty" json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `yaml:"schema,omitempty" json:"schema,omitempty"`
+}
+
+// Schema is a JSON schema. Keywords vandor does not use are dropped.
+type Schema struct {
+	Ref                  string             `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Type                 Types              `yaml:"type,omitempty" json:"type,omitempty"`
+	Format               string             `yaml:"format,omitempty" json:"format,omitempty"`
+	Description          string             `yaml:"description,omitempty" json:"description,omitempty"`
+	Enum                 []any              `yaml:"enum,omitempty" json:"enum,omitempty"`
+	Default              any                `yaml:"default,omitempty" json:"default,omitempty"`
+	Example              any                `yaml:"example,omitempty" json:"example,omitempty"`
+	Nullable             bool               `yaml:"nullable,omitempty" json:"nullable,omitempty"` // OpenAPI 3.0
+	Properties           map[string]*Schema `yaml:"properties,omitempty" json:"properties,omitempty"`
+	Required             []string           `yaml:"required,omitempty" json:"required,omitempty"`
+	Items                *Schema            `yaml:"items,omitempty" json:"items,omitempty"`
+	AdditionalProperties *Schema            `yaml:"additionalProperties,omitempty" json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `yaml:"allOf,omitempty" json:"allOf,omitempty"`
+	OneOf                []*Schema          `yaml:"oneOf,omitempty" json:"oneOf,omitempty"`
+	AnyOf                []*Schema          `yaml:"anyOf,omitempty" json:"anyOf,omitempty"`
+	Minimum              *float64           `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	Maximum              *float64           `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	MinLength            *int               `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength            *int               `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	Pattern              string             `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+}
+
+// Types is a schema type: a single name, or a list of names in OpenAPI 3.1
+// such as [string, "null"].
+type Types []string
+
+func (t *Types) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = Types{node.Value}
+		return nil
+	}
+	var types []string
+	if err := node.Decode(&types); err != nil {
+		return err
+	}
+	*t = types
+	return nil
+}
+
+func (t Types) MarshalYAML() (any, error) {
+	if len(t) == 1 {
+		return t[0], nil
+	}
+	return []string(t), nil
+}
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = Types{name}
+		return nil
+	}
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return err
+	}
+	*t = types
+	return nil
+}
+
+// Is reports whether the schema has a type.
+func (t Types) Is(name string) bool {
+	for _, typ := range t {
+		if typ == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the type other than "null", or "" when there is none or
+// several.
+func (t Types) Name() string {
+	var name string
+	for _, typ := range t {
+		if typ == "null" {
+			continue
+		}
+		if name != "" {
+			return ""
+		}
+		name = typ
+	}
+	return name
+}
+
+// IsNullable reports whether the schema allows null in OpenAPI 3.0 or 3.1.
+func (s *Schema) IsNullable() bool {
+	return s.Nullable || s.Type.Is("null")
+}
+
+// IsRequired reports whether an object schema requires a property.
+func (s *Schema) IsRequired(name string) bool {
+	for _, required := range s.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PropertyNames returns the property names of an object schema in order.
+func (s *Schema) PropertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load reads an OpenAPI document in YAML or JSON.
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses an OpenAPI document in YAML or JSON.
+func Parse(data []byte) (*Document, error) {
+	var doc Document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q: only 3.x documents are supported", doc.OpenAPI)
+	}
+	if doc.Components == nil {
+		doc.Components = &Components{}
+	}
+	return &doc, nil
+}
+
+// SchemaRef returns the component name of a "#/components/schemas/<name>"
+// reference, or "" for other schemas.
+func SchemaRef(s *Schema) string {
+	if s == nil {
+		return ""
+	}
+	name, _ := strings.CutPrefix(s.Ref, "#/components/schemas/")
+	if name == s.Ref {
+		return ""
+	}
+	return name
+}
+
+// Schema resolves a schema reference to the component it names.
+func (d *Document) Schema(s *Schema) (*Schema, error) {
+	for seen := 0; s != nil && s.Ref != ""; seen++ {
+		name := SchemaRef(s)
+		if name == "" || seen > 32 {
+			return nil, fmt.Errorf("unsupported schema reference %q", s.Ref)
+		}
+		target, ok := d.Components.Schemas[name]
+		if !ok {
+			return nil, fmt.Errorf("schema %q not found in components", name)
+		}
+		s = target
+	}
+	return s, nil
+}
+
+// Parameter resolves a parameter reference.
+func (d *Document) Parameter(p *Parameter) (*Parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+	if target, found := d.Components.Parameters[name]; ok && found {
+		return d.Parameter(target)
+	}
+	return nil, fmt.Errorf("parameter %q not found in components", p.Ref)
+}
+
+// RequestBody resolves a request body reference.
+func (d *Document) RequestBody(b *RequestBody) (*RequestBody, error) {
+	if b.Ref == "" {
+		return b, nil
+	}
+	name, ok := strings.CutPrefix(b.Ref, "#/components/requestBodies/")
+	if target, found := d.Components.RequestBodies[name]; ok && found {
+		return d.RequestBody(target)
+	}
+	return nil, fmt.Errorf("request body %q not found in components", b.Ref)
+}
+
+// Response resolves a response reference.
+func (d *Document) Response(r *Response) (*Response, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+	name, ok := strings.CutPrefix(r.Ref, "#/components/responses/")
+	if target, found := d.Components.Responses[name]; ok && found {
+		return d.Response(target)
+	}
+	return nil, fmt.Errorf("response %q not found in components", r.Ref)
+}
+
+// JSONSchema returns the schema of the JSON content of a body, preferring
+// application/json over other JSON media types, or nil when there is none.
+func JSONSchema(content map[string]*MediaType) (string, *Schema) {
+	if media, ok := content["application/json"]; ok {
+		return "application/json", media.Schema
+	}
+	types := make([]string, 0, len(content))
+	for typ := range content {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		if strings.HasSuffix(typ, "+json") || strings.HasSuffix(typ, "/json") {
+			return typ, content[typ].Schema
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,181 @@
+package openapi
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantErr  string
+		wantType []string // Type of the Pet.tag property
+		nullable bool
+	}{
+		{
+			name:     "3.0 YAML",
+			data:     "openapi: 3.0.3\ninfo: {title: Pets, version: '1'}\ncomponents:\n  schemas:\n    Pet:\n      properties:\n        tag: {type: string, nullable: true}\n",
+			wantType: []string{"string"},
+			nullable: true,
+		},
+		{
+			name:     "3.1 JSON",
+			data:     `{"openapi": "3.1.0", "info": {"title": "Pets", "version": "1"}, "components": {"schemas": {"Pet": {"properties": {"tag": {"type": ["string", "null"]}}}}}}`,
+			wantType: []string{"string", "null"},
+			nullable: true,
+		},
+		{
+			name:     "not nullable",
+			data:     "openapi: 3.1.0\ninfo: {title: Pets, version: '1'}\ncomponents:\n  schemas:\n    Pet:\n      properties:\n        tag: {type: string}\n",
+			wantType: []string{"string"},
+		},
+		{
+			name:    "Swagger 2",
+			data:    "swagger: '2.0'\ninfo: {title: Pets, version: '1'}\n",
+			wantErr: `unsupported OpenAPI version "": only 3.x documents are supported`,
+		},
+		{
+			name:    "not a document",
+			data:    "openapi: [3\n",
+			wantErr: "invalid OpenAPI document",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if doc.Info.Title != "Pets" {
+				t.Errorf("title = %q, want Pets", doc.Info.Title)
+			}
+			tag := doc.Components.Schemas["Pet"].Properties["tag"]
+			if !slices.Equal(tag.Type, tt.wantType) {
+				t.Errorf("type = %v, want %v", tag.Type, tt.wantType)
+			}
+			if tag.Type.Name() != "string" {
+				t.Errorf("type name = %q, want string", tag.Type.Name())
+			}
+			if tag.IsNullable() != tt.nullable {
+				t.Errorf("nullable = %v, want %v", tag.IsNullable(), tt.nullable)
+			}
+		})
+	}
+}
+
+func TestParseWithoutComponents(t *testing.T) {
+	doc, err := Parse([]byte("openapi: 3.0.0\ninfo: {title: Pets, version: '1'}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Components == nil {
+		t.Fatal("components are nil, want them empty so references resolve to not found")
+	}
+	if _, err := doc.Schema(&Schema{Ref: "#/components/schemas/Pet"}); err == nil {
+		t.Error("expected an error for a missing schema")
+	}
+}
+
+const refs = `openapi: 3.0.3
+info: {title: Pets, version: "1"}
+paths: {}
+components:
+  schemas:
+    Pet: {$ref: "#/components/schemas/Animal"}
+    Animal: {type: object, properties: {name: {type: string}}}
+    Loop: {$ref: "#/components/schemas/Loop"}
+  parameters:
+    PetID: {name: petId, in: path, required: true}
+    Alias: {$ref: "#/components/parameters/PetID"}
+  requestBodies:
+    NewPet: {required: true}
+  responses:
+    Pet: {description: A pet}
+`
+
+func TestReferences(t *testing.T) {
+	doc, err := Parse([]byte(refs))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemas := []struct {
+		ref     string
+		want    string // Name of the property the resolved schema has
+		wantErr string
+	}{
+		{ref: "#/components/schemas/Animal", want: "name"},
+		{ref: "#/components/schemas/Pet", want: "name"},
+		{ref: "#/components/schemas/Cat", wantErr: `schema "Cat" not found in components`},
+		{ref: "#/components/schemas/Loop", wantErr: `unsupported schema reference "#/components/schemas/Loop"`},
+		{ref: "other.yaml#/Pet", wantErr: `unsupported schema reference "other.yaml#/Pet"`},
+	}
+	for _, tt := range schemas {
+		s, err := doc.Schema(&Schema{Ref: tt.ref})
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("%s: error = %v, want %q", tt.ref, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.ref, err)
+		} else if s.Properties[tt.want] == nil {
+			t.Errorf("%s resolved to %+v, want a schema with %s", tt.ref, s, tt.want)
+		}
+	}
+
+	if p, err := doc.Parameter(&Parameter{Ref: "#/components/parameters/Alias"}); err != nil || p.Name != "petId" {
+		t.Errorf("parameter = %+v, %v; want petId", p, err)
+	}
+	if _, err := doc.Parameter(&Parameter{Ref: "#/components/schemas/Pet"}); err == nil {
+		t.Error("a schema reference resolved as a parameter")
+	}
+	if b, err := doc.RequestBody(&RequestBody{Ref: "#/components/requestBodies/NewPet"}); err != nil || !b.Required {
+		t.Errorf("request body = %+v, %v; want NewPet", b, err)
+	}
+	if _, err := doc.RequestBody(&RequestBody{Ref: "#/components/requestBodies/Pet"}); err == nil {
+		t.Error("expected an error for a missing request body")
+	}
+	if r, err := doc.Response(&Response{Ref: "#/components/responses/Pet"}); err != nil || r.Description != "A pet" {
+		t.Errorf("response = %+v, %v; want Pet", r, err)
+	}
+	if _, err := doc.Response(&Response{Ref: "#/components/responses/NewPet"}); err == nil {
+		t.Error("expected an error for a missing response")
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []string
+		want    string
+	}{
+		{name: "application/json first", content: []string{"application/problem+json", "application/json"}, want: "application/json"},
+		{name: "other JSON type", content: []string{"text/plain", "application/merge-patch+json"}, want: "application/merge-patch+json"},
+		{name: "first JSON type by name", content: []string{"application/vnd.b+json", "application/vnd.a+json"}, want: "application/vnd.a+json"},
+		{name: "not JSON", content: []string{"text/plain", "multipart/form-data"}, want: ""},
+	}
+	for _, tt := range tests {
+		content := map[string]*MediaType{}
+		for _, typ := range tt.content {
+			content[typ] = &MediaType{Schema: &Schema{Description: typ}}
+		}
+		got, schema := JSONSchema(content)
+		if got != tt.want {
+			t.Errorf("%s: media type = %q, want %q", tt.name, got, tt.want)
+		}
+		if (schema != nil) != (tt.want != "") || (schema != nil && schema.Description != tt.want) {
+			t.Errorf("%s: schema = %+v, want the schema of %q", tt.name, schema, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Package overwrite decides what add commands do with files they would
+// create that already exist, so that hand-written code is never clobbered
+// silently.
+package overwrite
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/alfariiizi/vandor-cli/internal/textdiff"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Mode is how existing files are handled.
+type Mode int
+
+const (
+	// Ask prompts for each existing file on a terminal, and refuses
+	// elsewhere. A preview overwrites, so that its diff shows the change.
+	Ask Mode = iota
+	// Refuse fails on the first existing file.
+	Refuse
+	// Force overwrites existing files.
+	Force
+	// Skip keeps existing files and writes the rest.
+	Skip
+)
+
+// NewSuffix is appended to the path of an existing file to write the
+// generated version next to it for a manual merge.
+const NewSuffix = ".new"
+
+var (
+	mode       = Ask
+	stdin      = bufio.NewReader(os.Stdin)
+	isTerminal = func() bool { return term.IsTerminal(int(os.Stdin.Fd())) }
+)
+
+// Use sets the mode and returns a function restoring the previous one.
+func Use(m Mode) (restore func()) {
+	previous := mode
+	mode = m
+	return func() { mode = previous }
+}
+
+// Check reports whether content should be written to path, asking the mode
+// when path already exists with different content. A skipped file is
+// reported on stdout; a refused one is an error.
+func Check(path string, content []byte) (bool, error) {
+	existing, err := vfs.ReadFile(path)
+	if err != nil || bytes.Equal(existing, content) {
+		return true, nil
+	}
+
+	switch effective() {
+	case Force:
+		return true, nil
+	case Skip:
+		fmt.Printf("⏭️  Skipping %s: it already exists\n", path)
+		return false, nil
+	case Ask:
+		if vfs.IsVirtual() {
+			fmt.Fprintf(os.Stderr, "⚠️  Preview: %s already exists; vandor would ask before overwriting it\n", path)
+			return true, nil
+		}
+		return ask(path, existing, content)
+	default:
+		return false, fmt.Errorf("%s already exists (use --force to overwrite it or --skip-existing to keep it)", path)
+	}
+}
+
+// Current returns the mode set.
+func Current() Mode {
+	return mode
+}
+
+// effective returns the mode in effect: Ask needs a terminal to ask on,
+// unless in a preview, which records the write instead of asking.
+func effective() Mode {
+	if mode == Ask && !vfs.IsVirtual() && !isTerminal() {
+		return Refuse
+	}
+	return mode
+}
+
+func ask(path string, existing, content []byte) (bool, error) {
+	for {
+		fmt.Fprintf(os.Stderr, "⚠️  %s already exists. Overwrite? [y]es, [n]o, [a]ll, [d]iff, [m]erge, [q]uit: ", path)
+		answer, err := stdin.ReadString('\n')
+		if err != nil {
+			return false, fmt.Errorf("%s already exists: %w", path, err)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			fmt.Printf("⏭️  Skipping %s: it already exists\n", path)
+			return false, nil
+		case "a", "all":
+			mode = Force
+			return true, nil
+		case "d", "diff":
+			name := filepath.ToSlash(path)
+			fmt.Fprint(os.Stderr, textdiff.Unified(name, name+" (generated)", existing, content))
+		case "m", "merge":
+			// Keep the file and put the generated version next to it
+			if err := vfs.WriteFile(path+NewSuffix, content, 0644); err != nil {
+				return false, fmt.Errorf("failed to write %s: %w", path+NewSuffix, err)
+			}
+			fmt.Printf("📝 Kept %s; the generated version is in %s to merge by hand\n", path, path+NewSuffix)
+			return false, nil
+		case "q", "quit":
+			return false, fmt.Errorf("aborted: %s already exists", path)
+		default:
+			fmt.Fprintln(os.Stderr, "Please answer y, n, a, d, m or q.")
+		}
+	}
+}
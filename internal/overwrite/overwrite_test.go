@@ -0,0 +1,149 @@
+package overwrite
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// withStdin answers prompts with input, as a terminal when terminal is set.
+func withStdin(t *testing.T, input string, terminal bool) {
+	t.Helper()
+	previousStdin, previousIsTerminal := stdin, isTerminal
+	stdin = bufio.NewReader(strings.NewReader(input))
+	isTerminal = func() bool { return terminal }
+	t.Cleanup(func() { stdin, isTerminal = previousStdin, previousIsTerminal })
+}
+
+func existingFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "user.go")
+	if err := os.WriteFile(path, []byte("package user\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     Mode
+		input    string
+		terminal bool
+		want     bool
+		wantErr  string
+		wantMode Mode
+		wantNew  bool // The generated version is written next to the file
+	}{
+		{name: "force", mode: Force, want: true, wantMode: Force},
+		{name: "skip", mode: Skip, want: false, wantMode: Skip},
+		{name: "refuse", mode: Refuse, wantErr: "already exists (use --force", wantMode: Refuse},
+		{name: "refuse on a terminal", mode: Refuse, terminal: true, wantErr: "already exists (use --force", wantMode: Refuse},
+		{name: "ask without a terminal", mode: Ask, wantErr: "already exists (use --force", wantMode: Ask},
+		{name: "ask yes", mode: Ask, terminal: true, input: "y\n", want: true, wantMode: Ask},
+		{name: "ask no", mode: Ask, terminal: true, input: "n\n", want: false, wantMode: Ask},
+		{name: "ask all", mode: Ask, terminal: true, input: "a\n", want: true, wantMode: Force},
+		{name: "ask diff then yes", mode: Ask, terminal: true, input: "d\nyes\n", want: true, wantMode: Ask},
+		{name: "ask again on other answers", mode: Ask, terminal: true, input: "maybe\nN\n", want: false, wantMode: Ask},
+		{name: "ask merge", mode: Ask, terminal: true, input: "m\n", want: false, wantMode: Ask, wantNew: true},
+		{name: "ask quit", mode: Ask, terminal: true, input: "q\n", wantErr: "aborted", wantMode: Ask},
+		{name: "ask without an answer", mode: Ask, terminal: true, input: "", wantErr: "EOF", wantMode: Ask},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer Use(tt.mode)()
+			withStdin(t, tt.input, tt.terminal)
+			path := existingFile(t)
+
+			got, err := Check(path, []byte("package user\n\nvar generated int\n"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("write = %v, want %v", got, tt.want)
+			}
+			if Current() != tt.wantMode {
+				t.Errorf("mode after = %v, want %v", Current(), tt.wantMode)
+			}
+			if _, err := os.Stat(path + NewSuffix); (err == nil) != tt.wantNew {
+				t.Errorf("%s written = %v, want %v", NewSuffix, err == nil, tt.wantNew)
+			}
+		})
+	}
+}
+
+func TestCheckWithoutConflict(t *testing.T) {
+	defer Use(Refuse)()
+	path := existingFile(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		{name: "new file", path: filepath.Join(filepath.Dir(path), "post.go"), content: "package post\n"},
+		{name: "same content", path: path, content: "package user\n"},
+	}
+	for _, tt := range tests {
+		if ok, err := Check(tt.path, []byte(tt.content)); !ok || err != nil {
+			t.Errorf("%s: write = %v, %v; want true", tt.name, ok, err)
+		}
+	}
+}
+
+func TestCheckInPreview(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    Mode
+		want    bool
+		wantErr bool
+	}{
+		{name: "ask records the write", mode: Ask, want: true},
+		{name: "force", mode: Force, want: true},
+		{name: "skip", mode: Skip, want: false},
+		{name: "refuse", mode: Refuse, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer Use(tt.mode)()
+			// A preview is not interactive even from a terminal
+			withStdin(t, "", true)
+			path := existingFile(t)
+			overlay := vfs.NewOverlay(vfs.OS{})
+			defer vfs.Use(overlay)()
+
+			content := []byte("package user\n\nvar generated int\n")
+			got, err := Check(path, content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want an error: %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("write = %v, want %v", got, tt.want)
+			}
+			if !got {
+				return
+			}
+
+			if err := vfs.WriteFile(path, content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			changes := overlay.Changes()
+			if len(changes) != 1 || changes[0].Path != path || changes[0].Created || string(changes[0].Old) != "package user\n" {
+				t.Errorf("changes = %+v, want %s modified", changes, path)
+			}
+			if onDisk, _ := os.ReadFile(path); string(onDisk) != "package user\n" {
+				t.Errorf("preview wrote %s to disk: %q", path, onDisk)
+			}
+		})
+	}
+}
@@ -2,29 +2,40 @@ package entgo
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
-func RegenerateEntgo() error {
+func RegenerateEntgo(out io.Writer) error {
+	if vfs.IsVirtual() {
+		fmt.Fprintf(os.Stderr, "⚠️  Preview: not running the Ent generator (external program)\n")
+		return nil
+	}
+
 	// Generate ent code
 	cmd := exec.Command("go", "run", "./cmd/entgo/main.go")
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = out
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to generate DB model: %w", err)
 	}
 
-	// Run goimports on the generated code
-	cmd = exec.Command("goimports", "-w", "./internal/infrastructure/db/rest/.")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to run goimports: %w", err)
+	// Format the generated code in-process
+	moduleName, err := utils.DetectGoModule()
+	if err != nil {
+		return err
+	}
+	if err := gosrc.FormatDir(filepath.Join("internal", "infrastructure", "db", "rest"), moduleName); err != nil {
+		return fmt.Errorf("failed to format DB model: %w", err)
 	}
 
-	fmt.Printf("✅ Successfully regenerated DB Model\n")
+	fmt.Fprintf(out, "✅ Successfully regenerated DB Model\n")
 	return nil
 }
@@ -2,21 +2,29 @@ package enum
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
-func RegenerateEnum() error {
+func RegenerateEnum(out io.Writer) error {
+	if vfs.IsVirtual() {
+		fmt.Fprintf(os.Stderr, "⚠️  Preview: not running the enum generator (external program)\n")
+		return nil
+	}
+
 	// For now, execute the original command
 	// TODO: Implement native Go regeneration logic
 	cmd := exec.Command("go", "run", "./cmd/enum/cmd/main.go", "generate")
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = out
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to regenerate enum: %w", err)
 	}
 
-	fmt.Printf("✅ Successfully regenerated enum\n")
+	fmt.Fprintf(out, "✅ Successfully regenerated enum\n")
 	return nil
 }
@@ -2,21 +2,29 @@ package scheduler
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
-func RegenerateScheduler() error {
+func RegenerateScheduler(out io.Writer) error {
+	if vfs.IsVirtual() {
+		fmt.Fprintf(os.Stderr, "⚠️  Preview: not running the scheduler generator (external program)\n")
+		return nil
+	}
+
 	// For now, execute the original command
 	// TODO: Implement native Go regeneration logic
 	cmd := exec.Command("go", "run", "./cmd/scheduler/cmd-regenerate-scheduler/main.go")
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = out
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to regenerate scheduler: %w", err)
 	}
 
-	fmt.Printf("✅ Successfully regenerated scheduler\n")
+	fmt.Fprintf(out, "✅ Successfully regenerated scheduler\n")
 	return nil
 }
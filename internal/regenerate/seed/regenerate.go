@@ -1,22 +1,511 @@
 package seed
 
 import (
+	"bytes"
+	_ "embed"
+	"errors"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
-func RegenerateSeed() error {
-	// For now, execute the original command
-	// TODO: Implement native Go regeneration logic
-	cmd := exec.Command("go", "run", "./cmd/seed/cmd-generate/main.go")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+//go:embed seed_runner.tmpl
+var seedRunnerTemplate string
+
+// SeedDir returns where seeds live in the project, from its layout.
+func SeedDir() string {
+	return layout.Current().Seed
+}
+
+// registryFile is the generated runner, skipped during discovery.
+const registryFile = "seeds.go"
+
+type SeedInfo struct {
+	Name         string   // Constructor suffix e.g. "UserSeed" (from NewUserSeed)
+	SeedName     string   // Value returned by GetName e.g. "user_seed"
+	Dependencies []string // Values returned by GetDependencies
+	File         string   // Source file
+}
+
+type TemplateData struct {
+	Package    string
+	ModuleName string
+	Seeds      []SeedInfo
+}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to regenerate seed: %w", err)
+// RunnerPath returns the generated seed runner.
+func RunnerPath() string {
+	return filepath.Join(SeedDir(), registryFile)
+}
+
+func RegenerateSeed(out io.Writer) error {
+	content, ordered, err := RenderSeedRunner(utils.GetModuleName())
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		fmt.Fprintf(out, "No seeds found in %s\n", SeedDir())
+		return nil
 	}
 
-	fmt.Printf("✅ Successfully regenerated seed\n")
+	if _, err := gosrc.WriteFile(RunnerPath(), content); err != nil {
+		return fmt.Errorf("error generating seed runner: %w", err)
+	}
+
+	fmt.Fprintf(out, "Seed runner generated successfully at %s\n", RunnerPath())
+	fmt.Fprintf(out, "Registered %d seeds in run order:\n", len(ordered))
+	for _, s := range ordered {
+		fmt.Fprintf(out, "  - %s\n", s.SeedName)
+	}
 	return nil
 }
+
+// RenderSeedRunner discovers the project's seeds and renders the runner
+// without writing it. It also returns the seeds in run order. Without seeds
+// there is no runner, and the content is nil, unless one was generated
+// before: it is regenerated empty instead of going stale.
+func RenderSeedRunner(moduleName string) ([]byte, []SeedInfo, error) {
+	seeds, err := DiscoverSeeds(SeedDir())
+	if err != nil {
+		return nil, nil, fmt.Errorf("error discovering seeds: %w", err)
+	}
+	if len(seeds) == 0 && !vfs.Exists(RunnerPath()) {
+		return nil, nil, nil
+	}
+
+	ordered, err := SortSeeds(seeds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkg, err := seedPackage(SeedDir())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := renderSeedRunner(pkg, ordered, moduleName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating seed runner: %w", err)
+	}
+	return content, ordered, nil
+}
+
+// DiscoverSeeds finds seed implementations in dir. A seed is a New<Name>
+// constructor taking a *db.Client and returning a pointer to a struct with
+// GetName and GetDependencies methods that return literals.
+func DiscoverSeeds(dir string) ([]SeedInfo, error) {
+	entries, err := vfs.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var seeds []SeedInfo
+	var errs []error
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == registryFile || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		found, err := parseSeedFile(filepath.Join(dir, name))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		seeds = append(seeds, found...)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	seen := map[string]string{}
+	for _, s := range seeds {
+		if prev, ok := seen[s.SeedName]; ok {
+			errs = append(errs, fmt.Errorf("seed name %q is declared by both %s and %s", s.SeedName, prev, s.File))
+		}
+		seen[s.SeedName] = s.File
+	}
+
+	return seeds, errors.Join(errs...)
+}
+
+// seedPackage returns the package of the seeds in dir, which the runner
+// joins: the package clause of its seeds, else the directory name.
+func seedPackage(dir string) (string, error) {
+	entries, err := vfs.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == registryFile || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		node, err := gosrc.ParseFile(token.NewFileSet(), path, parser.PackageClauseOnly)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return node.Name.Name, nil
+	}
+	return filepath.Base(dir), nil
+}
+
+func parseSeedFile(path string) ([]SeedInfo, error) {
+	fset := token.NewFileSet()
+	node, err := gosrc.ParseFile(fset, path, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	type methods struct {
+		name    *ast.FuncDecl
+		deps    *ast.FuncDecl
+		hasRun  bool
+		ctor    *ast.FuncDecl
+		ctorSfx string
+	}
+	receivers := map[string]*methods{}
+	get := func(recv string) *methods {
+		if receivers[recv] == nil {
+			receivers[recv] = &methods{}
+		}
+		return receivers[recv]
+	}
+
+	for _, decl := range node.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if fn.Recv == nil {
+			if !strings.HasPrefix(fn.Name.Name, "New") || !strings.HasSuffix(fn.Name.Name, "Seed") {
+				continue
+			}
+			recv := returnedStruct(fn)
+			if recv == "" {
+				continue
+			}
+			m := get(recv)
+			m.ctor = fn
+			m.ctorSfx = strings.TrimPrefix(fn.Name.Name, "New")
+			continue
+		}
+
+		recv := receiverName(fn)
+		switch fn.Name.Name {
+		case "GetName":
+			get(recv).name = fn
+		case "GetDependencies":
+			get(recv).deps = fn
+		case "Run":
+			get(recv).hasRun = true
+		}
+	}
+
+	var seeds []SeedInfo
+	var errs []error
+
+	recvNames := make([]string, 0, len(receivers))
+	for recv := range receivers {
+		recvNames = append(recvNames, recv)
+	}
+	sort.Strings(recvNames)
+
+	for _, recv := range recvNames {
+		m := receivers[recv]
+		if m.ctor == nil {
+			continue // not constructed as a seed, e.g. a helper type
+		}
+		pos := fset.Position(m.ctor.Pos())
+
+		if !takesDBClient(m.ctor) {
+			errs = append(errs, fmt.Errorf("%s: %s must take a single *db.Client parameter", pos, m.ctor.Name.Name))
+			continue
+		}
+		if !m.hasRun || m.name == nil || m.deps == nil {
+			errs = append(errs, fmt.Errorf("%s: %s must implement Run, GetName and GetDependencies", pos, recv))
+			continue
+		}
+
+		seedName, ok := returnedString(m.name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: GetName must return a string literal", fset.Position(m.name.Pos())))
+			continue
+		}
+		deps, ok := returnedStrings(m.deps)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: GetDependencies must return a []string literal", fset.Position(m.deps.Pos())))
+			continue
+		}
+
+		seeds = append(seeds, SeedInfo{
+			Name:         m.ctorSfx,
+			SeedName:     seedName,
+			Dependencies: deps,
+			File:         path,
+		})
+	}
+
+	return seeds, errors.Join(errs...)
+}
+
+// SortSeeds orders seeds so that every seed comes after its dependencies.
+// Ties are broken by seed name so the output is stable.
+func SortSeeds(seeds []SeedInfo) ([]SeedInfo, error) {
+	byName := make(map[string]SeedInfo, len(seeds))
+	names := make([]string, 0, len(seeds))
+	for _, s := range seeds {
+		byName[s.SeedName] = s
+		names = append(names, s.SeedName)
+	}
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := map[string]int{}
+	var ordered []SeedInfo
+	var stack []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			start := 0
+			for i, n := range stack {
+				if n == name {
+					start = i
+				}
+			}
+			cycle := append(append([]string{}, stack[start:]...), name)
+			return fmt.Errorf("seed dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		s := byName[name]
+		state[name] = visiting
+		stack = append(stack, name)
+
+		deps := append([]string(nil), s.Dependencies...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if _, ok := byName[dep]; !ok {
+				return fmt.Errorf("seed %s depends on unknown seed %q", name, dep)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[name] = done
+		ordered = append(ordered, s)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// Plan returns the seeds needed to run the given names, including their
+// transitive dependencies, in run order. Names may omit the "_seed" suffix.
+// Without names every seed is planned.
+func Plan(ordered []SeedInfo, names []string) ([]SeedInfo, error) {
+	if len(names) == 0 {
+		return ordered, nil
+	}
+
+	byName := make(map[string]SeedInfo, len(ordered))
+	for _, s := range ordered {
+		byName[s.SeedName] = s
+	}
+
+	selected := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		if selected[name] {
+			return
+		}
+		selected[name] = true
+		for _, dep := range byName[name].Dependencies {
+			visit(dep)
+		}
+	}
+
+	for _, name := range names {
+		if _, ok := byName[name]; !ok {
+			if _, ok := byName[name+"_seed"]; !ok {
+				return nil, fmt.Errorf("unknown seed %q", name)
+			}
+			name += "_seed"
+		}
+		visit(name)
+	}
+
+	var plan []SeedInfo
+	for _, s := range ordered {
+		if selected[s.SeedName] {
+			plan = append(plan, s)
+		}
+	}
+	return plan, nil
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if ident, ok := t.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// returnedStruct reports the struct type a constructor returns via
+// `return &name{...}`.
+func returnedStruct(fn *ast.FuncDecl) string {
+	if fn.Body == nil {
+		return ""
+	}
+	var name string
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			return true
+		}
+		unary, ok := ret.Results[0].(*ast.UnaryExpr)
+		if !ok || unary.Op != token.AND {
+			return true
+		}
+		if lit, ok := unary.X.(*ast.CompositeLit); ok {
+			if ident, ok := lit.Type.(*ast.Ident); ok {
+				name = ident.Name
+			}
+		}
+		return true
+	})
+	return name
+}
+
+func takesDBClient(fn *ast.FuncDecl) bool {
+	params := fn.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 {
+		return false
+	}
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Client" {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "db"
+}
+
+func singleReturn(fn *ast.FuncDecl) ast.Expr {
+	if fn.Body == nil {
+		return nil
+	}
+	var result ast.Expr
+	for _, stmt := range fn.Body.List {
+		if ret, ok := stmt.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			result = ret.Results[0]
+		}
+	}
+	return result
+}
+
+func returnedString(fn *ast.FuncDecl) (string, bool) {
+	lit, ok := singleReturn(fn).(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+func returnedStrings(fn *ast.FuncDecl) ([]string, bool) {
+	expr := singleReturn(fn)
+	if ident, ok := expr.(*ast.Ident); ok && ident.Name == "nil" {
+		return nil, true
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil, false
+	}
+	arr, ok := lit.Type.(*ast.ArrayType)
+	if !ok || arr.Len != nil {
+		return nil, false
+	}
+	if elt, ok := arr.Elt.(*ast.Ident); !ok || elt.Name != "string" {
+		return nil, false
+	}
+
+	var values []string
+	for _, elt := range lit.Elts {
+		bl, ok := elt.(*ast.BasicLit)
+		if !ok || bl.Kind != token.STRING {
+			return nil, false
+		}
+		s, err := strconv.Unquote(bl.Value)
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, s)
+	}
+	return values, true
+}
+
+func renderSeedRunner(pkg string, seeds []SeedInfo, moduleName string) ([]byte, error) {
+	tmpl, err := template.New("seed_runner").Parse(seedRunnerTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	data := TemplateData{
+		Package:    pkg,
+		ModuleName: moduleName,
+		Seeds:      seeds,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return gosrc.Format(buf.Bytes(), moduleName)
+}
@@ -0,0 +1,238 @@
+package seed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+)
+
+func seeds(deps map[string][]string) []SeedInfo {
+	var seeds []SeedInfo
+	for name, d := range deps {
+		seeds = append(seeds, SeedInfo{SeedName: name, Dependencies: d})
+	}
+	return seeds
+}
+
+func names(seeds []SeedInfo) string {
+	var names []string
+	for _, s := range seeds {
+		names = append(names, s.SeedName)
+	}
+	return strings.Join(names, " ")
+}
+
+func TestSortSeeds(t *testing.T) {
+	tests := []struct {
+		name    string
+		deps    map[string][]string
+		want    string
+		wantErr string
+	}{
+		{name: "none", deps: map[string][]string{}, want: ""},
+		{
+			name: "independent seeds by name",
+			deps: map[string][]string{"user_seed": nil, "role_seed": nil, "post_seed": nil},
+			want: "post_seed role_seed user_seed",
+		},
+		{
+			name: "dependencies come first",
+			deps: map[string][]string{"post_seed": {"user_seed"}, "user_seed": {"role_seed"}, "role_seed": nil},
+			want: "role_seed user_seed post_seed",
+		},
+		{
+			name: "shared dependency once",
+			deps: map[string][]string{"post_seed": {"user_seed", "role_seed"}, "comment_seed": {"user_seed"}, "user_seed": {"role_seed"}, "role_seed": nil},
+			want: "role_seed user_seed comment_seed post_seed",
+		},
+		{
+			name:    "cycle",
+			deps:    map[string][]string{"user_seed": {"role_seed"}, "role_seed": {"team_seed"}, "team_seed": {"user_seed"}},
+			wantErr: "seed dependency cycle: role_seed -> team_seed -> user_seed -> role_seed",
+		},
+		{
+			name:    "self dependency",
+			deps:    map[string][]string{"user_seed": {"user_seed"}},
+			wantErr: "seed dependency cycle: user_seed -> user_seed",
+		},
+		{
+			name:    "unknown dependency",
+			deps:    map[string][]string{"post_seed": {"user_seed"}},
+			wantErr: `seed post_seed depends on unknown seed "user_seed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SortSeeds(seeds(tt.deps))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if names(got) != tt.want {
+				t.Errorf("order = %q, want %q", names(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	ordered, err := SortSeeds(seeds(map[string][]string{
+		"role_seed": nil,
+		"user_seed": {"role_seed"},
+		"post_seed": {"user_seed"},
+		"tag_seed":  nil,
+		"settings":  nil,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{name: "every seed", want: "role_seed user_seed post_seed settings tag_seed"},
+		{name: "with the suffix", args: []string{"user_seed"}, want: "role_seed user_seed"},
+		{name: "without the suffix", args: []string{"user"}, want: "role_seed user_seed"},
+		{name: "both forms", args: []string{"post", "tag_seed"}, want: "role_seed user_seed post_seed tag_seed"},
+		{name: "name without the suffix", args: []string{"settings"}, want: "settings"},
+		{name: "in run order", args: []string{"user", "role"}, want: "role_seed user_seed"},
+		{name: "unknown", args: []string{"comment"}, wantErr: `unknown seed "comment"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Plan(ordered, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if names(got) != tt.want {
+				t.Errorf("plan = %q, want %q", names(got), tt.want)
+			}
+		})
+	}
+}
+
+const userSeed = `package PKG
+
+import (
+	"context"
+
+	"example.com/app/internal/infrastructure/db"
+)
+
+type userSeed struct {
+	client *db.Client
+}
+
+func NewUserSeed(client *db.Client) *userSeed {
+	return &userSeed{client: client}
+}
+
+func (s *userSeed) Run(ctx context.Context) error {
+	return nil
+}
+
+func (s *userSeed) GetName() string {
+	return "user_seed"
+}
+
+func (s *userSeed) GetDependencies() []string {
+	return nil
+}
+`
+
+// inProject runs the test from a project with seeds in dir, declared in
+// package pkg, and the layout loaded again.
+func inProject(t *testing.T, dir, pkg string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(config.FileEnv, "")
+	config.Setup("", nil)
+	layout.ResetCurrent()
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+		config.Setup("", nil)
+		layout.ResetCurrent()
+	})
+
+	files := map[string]string{
+		"go.mod":                           "module example.com/app\n\ngo 1.23\n",
+		"internal/infrastructure/db/db.go": "package db\n\ntype Client struct{}\n",
+		config.FileName:                    "layout:\n  seed: " + dir + "\n",
+		filepath.Join(dir, "user.go"):      strings.Replace(userSeed, "PKG", pkg, 1),
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRenderSeedRunnerPackage(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+		pkg  string
+	}{
+		{name: "default", dir: "internal/infrastructure/db/seed", pkg: "seed"},
+		{name: "other directory", dir: "internal/fixtures", pkg: "fixtures"},
+		{name: "package clause over directory name", dir: "db/seeds", pkg: "dbseed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inProject(t, tt.dir, tt.pkg)
+
+			content, ordered, err := RenderSeedRunner("example.com/app")
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			if names(ordered) != "user_seed" {
+				t.Errorf("seeds = %q, want user_seed", names(ordered))
+			}
+			if RunnerPath() != filepath.Join(filepath.FromSlash(tt.dir), registryFile) {
+				t.Errorf("runner path = %q, want it in %s", RunnerPath(), tt.dir)
+			}
+			if want := "package " + tt.pkg + "\n"; !strings.Contains(string(content), want) {
+				t.Errorf("runner does not declare %q:\n%s", want, content)
+			}
+
+			if err := os.WriteFile(RunnerPath(), content, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := gosrc.NewChecker(".", "example.com/app").CheckProject(); err != nil {
+				t.Errorf("runner does not type-check with its seeds:\n%v\n%s", err, content)
+			}
+		})
+	}
+}
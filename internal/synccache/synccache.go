@@ -0,0 +1,162 @@
+// Package synccache remembers a fingerprint of the files each sync generator
+// reads and writes, so unchanged generators can be skipped on the next run.
+package synccache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// Dir is the project-relative directory the cache is stored in.
+var Dir = filepath.Join(".vandor", "cache")
+
+const cacheFile = "sync.json"
+
+// Cache maps generator names to the fingerprint recorded after their last
+// successful run. It is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	path    string
+	Entries map[string]string `json:"generators"`
+}
+
+// Load reads the cache of the project at root. A missing or unreadable cache
+// is treated as empty so the next sync simply regenerates everything.
+func Load(root string) *Cache {
+	c := &Cache{
+		path:    filepath.Join(root, Dir, cacheFile),
+		Entries: map[string]string{},
+	}
+
+	data, err := vfs.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+	if err := json.Unmarshal(data, c); err != nil || c.Entries == nil {
+		c.Entries = map[string]string{}
+	}
+	return c
+}
+
+// Fresh reports whether name was last run against the given fingerprint.
+func (c *Cache) Fresh(name, fingerprint string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	recorded, ok := c.Entries[name]
+	return ok && recorded == fingerprint
+}
+
+// Set records the fingerprint of a successful run.
+func (c *Cache) Set(name, fingerprint string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Entries[name] = fingerprint
+}
+
+// Forget drops the entry for name so the generator runs next time.
+func (c *Cache) Forget(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.Entries, name)
+}
+
+// Save writes the cache back to disk.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := vfs.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := vfs.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write sync cache: %w", err)
+	}
+	return nil
+}
+
+// Fingerprint hashes the names and contents of every file under the given
+// project-relative paths. Paths may be files or directories; missing paths
+// contribute their absence, so creating or deleting them changes the result.
+func Fingerprint(root string, paths []string) (string, error) {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	for _, rel := range sorted {
+		files, err := listFiles(root, rel)
+		if err != nil {
+			return "", err
+		}
+		if files == nil {
+			fmt.Fprintf(h, "%s\x00missing\x00", filepath.ToSlash(rel))
+			continue
+		}
+		for _, file := range files {
+			sum, err := hashFile(filepath.Join(root, file))
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "%s\x00%s\x00", filepath.ToSlash(file), sum)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// listFiles returns the sorted project-relative files under rel, or nil when
+// rel does not exist.
+func listFiles(root, rel string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(filepath.Join(root, rel), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, relPath)
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fingerprint %s: %w", rel, err)
+	}
+	if files == nil {
+		files = []string{}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,171 @@
+package synccache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	paths := []string{"internal/domain", "internal/core/domain/domain.go", "missing"}
+
+	tests := []struct {
+		name    string
+		change  func(t *testing.T, root string)
+		changed bool
+	}{
+		{
+			name:    "nothing changed",
+			change:  func(t *testing.T, root string) {},
+			changed: false,
+		},
+		{
+			name: "file edited",
+			change: func(t *testing.T, root string) {
+				writeFiles(t, root, map[string]string{"internal/domain/user.go": "package domain // edited\n"})
+			},
+			changed: true,
+		},
+		{
+			name: "file added",
+			change: func(t *testing.T, root string) {
+				writeFiles(t, root, map[string]string{"internal/domain/product.go": "package domain\n"})
+			},
+			changed: true,
+		},
+		{
+			name: "file deleted",
+			change: func(t *testing.T, root string) {
+				if err := os.Remove(filepath.Join(root, "internal/domain/user.go")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			changed: true,
+		},
+		{
+			name: "missing path created",
+			change: func(t *testing.T, root string) {
+				writeFiles(t, root, map[string]string{"missing/file.go": "package missing\n"})
+			},
+			changed: true,
+		},
+		{
+			name: "output deleted",
+			change: func(t *testing.T, root string) {
+				if err := os.Remove(filepath.Join(root, "internal/core/domain/domain.go")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			changed: true,
+		},
+		{
+			name: "unrelated file changed",
+			change: func(t *testing.T, root string) {
+				writeFiles(t, root, map[string]string{"internal/usecase/create_user.go": "package usecase // edited\n"})
+			},
+			changed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, map[string]string{
+				"internal/domain/user.go":         "package domain\n",
+				"internal/core/domain/domain.go":  "package domain\n",
+				"internal/usecase/create_user.go": "package usecase\n",
+			})
+
+			before, err := Fingerprint(root, paths)
+			if err != nil {
+				t.Fatalf("fingerprint: %v", err)
+			}
+			tt.change(t, root)
+			after, err := Fingerprint(root, paths)
+			if err != nil {
+				t.Fatalf("fingerprint: %v", err)
+			}
+
+			if changed := before != after; changed != tt.changed {
+				t.Errorf("fingerprint changed = %v, want %v", changed, tt.changed)
+			}
+		})
+	}
+}
+
+func TestFingerprintIgnoresPathOrder(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"a/a.go": "package a\n", "b/b.go": "package b\n"})
+
+	ab, err := Fingerprint(root, []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ba, err := Fingerprint(root, []string{"b", "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ab != ba {
+		t.Error("fingerprint depends on the order of paths")
+	}
+}
+
+func TestCache(t *testing.T) {
+	root := t.TempDir()
+
+	c := Load(root)
+	if c.Fresh("domain", "abc") {
+		t.Fatal("empty cache reports domain fresh")
+	}
+
+	c.Set("domain", "abc")
+	c.Set("usecase", "def")
+	c.Forget("usecase")
+	if err := c.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded := Load(root)
+	tests := []struct {
+		name        string
+		fingerprint string
+		fresh       bool
+	}{
+		{"domain", "abc", true},
+		{"domain", "changed", false},
+		{"usecase", "def", false},
+		{"service", "", false},
+	}
+	for _, tt := range tests {
+		if got := loaded.Fresh(tt.name, tt.fingerprint); got != tt.fresh {
+			t.Errorf("Fresh(%q, %q) = %v, want %v", tt.name, tt.fingerprint, got, tt.fresh)
+		}
+	}
+}
+
+func TestLoadCorruptCache(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{filepath.Join(Dir, cacheFile): "{not json"})
+
+	c := Load(root)
+	if len(c.Entries) != 0 {
+		t.Errorf("entries = %v, want none", c.Entries)
+	}
+	c.Set("domain", "abc")
+	if !c.Fresh("domain", "abc") {
+		t.Error("cache loaded from a corrupt file cannot record entries")
+	}
+}
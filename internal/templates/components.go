@@ -0,0 +1,158 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"text/template"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+	"github.com/alfariiizi/vandor-cli/internal/tmplfunc"
+)
+
+// BuiltinComponents are the component kinds of the CLI itself, which custom
+// components cannot redefine.
+var BuiltinComponents = []string{
+	"domain", "usecase", "service", "job", "handler", "scheduler", "seed", "enum",
+	"schema", "handler-crud", "service-handler",
+}
+
+// Component is a custom component kind declared under components in
+// vandor-config.yaml, e.g.
+//
+//	components:
+//	  repository:
+//	    description: Create a new repository
+//	    output: internal/infrastructure/repository/{{.Group}}/{{.NameSnake}}.go
+//	    args: [group, name]
+//	    sync: go generate ./internal/infrastructure/repository/...
+type Component struct {
+	Kind        string
+	Description string
+	Template    string   // Template file; defaults to the usual lookup of <kind>/<kind>.go.tmpl
+	Output      string   // Output path pattern, rendered with TemplateData
+	Args        []string // Required arguments in order; defaults to [name]
+	Sync        string   // A vandor sync target or a shell command run after generation
+}
+
+var componentKind = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// LoadComponents returns the custom components declared in the project
+// configuration, sorted by kind. A missing config declares none.
+func LoadComponents() ([]Component, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return ComponentsOf(cfg)
+}
+
+// ComponentsOf returns the custom components declared in cfg, sorted by
+// kind.
+func ComponentsOf(cfg config.Config) ([]Component, error) {
+	components := make([]Component, 0, len(cfg.Components))
+	for kind, declared := range cfg.Components {
+		component := Component{
+			Kind:        kind,
+			Description: declared.Description,
+			Template:    declared.Template,
+			Output:      declared.Output,
+			Args:        declared.Args,
+			Sync:        declared.Sync,
+		}
+		if len(component.Args) == 0 {
+			component.Args = []string{"name"}
+		}
+		if err := component.validate(); err != nil {
+			return nil, fmt.Errorf("%s: component %s: %w", cfg.Source(), kind, err)
+		}
+		components = append(components, component)
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i].Kind < components[j].Kind })
+	return components, nil
+}
+
+// FindComponent returns the custom component of a kind, if one is declared.
+func FindComponent(kind string) (Component, bool, error) {
+	components, err := LoadComponents()
+	if err != nil {
+		return Component{}, false, err
+	}
+	for _, component := range components {
+		if component.Kind == kind {
+			return component, true, nil
+		}
+	}
+	return Component{}, false, nil
+}
+
+func (c Component) validate() error {
+	if !componentKind.MatchString(c.Kind) {
+		return fmt.Errorf("kind must be lowercase letters, digits and dashes")
+	}
+	if contains(BuiltinComponents, c.Kind) {
+		return fmt.Errorf("%s is a built-in component", c.Kind)
+	}
+	if c.Output == "" {
+		return fmt.Errorf("output is required")
+	}
+	if _, err := template.New("output").Funcs(tmplfunc.Funcs()).Parse(c.Output); err != nil {
+		return fmt.Errorf("invalid output pattern: %w", err)
+	}
+	if !contains(c.Args, "name") {
+		return fmt.Errorf("args must include name")
+	}
+	seen := map[string]bool{}
+	for _, arg := range c.Args {
+		if !componentKind.MatchString(arg) {
+			return fmt.Errorf("invalid arg %q", arg)
+		}
+		if seen[arg] {
+			return fmt.Errorf("duplicate arg %q", arg)
+		}
+		seen[arg] = true
+	}
+	return nil
+}
+
+// Config returns the template config of the component for its arguments, in
+// the order of Args. Name, group and method fill the fields of the same name;
+// any other argument is available to templates as .Extra.<arg>.
+func (c Component) Config(args []string) (TemplateConfig, error) {
+	if len(args) != len(c.Args) {
+		return TemplateConfig{}, fmt.Errorf("%s requires %d argument(s): %v", c.Kind, len(c.Args), c.Args)
+	}
+
+	config := TemplateConfig{ComponentType: c.Kind, ExtraData: map[string]string{}}
+	for i, arg := range c.Args {
+		if args[i] == "" {
+			return TemplateConfig{}, fmt.Errorf("%s cannot be empty", arg)
+		}
+		switch arg {
+		case "name":
+			config.Name = args[i]
+		case "group":
+			config.Group = args[i]
+		case "method":
+			config.Method = args[i]
+		default:
+			config.ExtraData[arg] = args[i]
+		}
+	}
+	return config, nil
+}
+
+// outputPath renders the output path pattern of the component.
+func (c Component) outputPath(data TemplateData) (string, error) {
+	tmpl, err := template.New("output").Funcs(tmplfunc.Funcs()).Option("missingkey=error").Parse(c.Output)
+	if err != nil {
+		return "", fmt.Errorf("invalid output pattern of %s: %w", c.Kind, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render output path of %s: %w", c.Kind, err)
+	}
+	return filepath.FromSlash(buf.String()), nil
+}
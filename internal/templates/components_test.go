@@ -0,0 +1,138 @@
+package templates
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+)
+
+const repositoryConfig = `components:
+  repository:
+    description: Create a new repository
+    output: internal/infrastructure/repository/{{.Group}}/{{.NameSnake}}.go
+    args: [group, name, table]
+  gateway:
+    template: templates/gateway.tmpl
+    output: internal/gateway/{{.NameSnake}}.go
+`
+
+const repositoryTemplate = `package {{.Group}}
+
+// {{.Name}} reads the {{.Extra.table}} table.
+type {{.Name}} struct{}
+`
+
+func TestCustomComponent(t *testing.T) {
+	inProject(t, map[string]string{
+		"go.mod":        "module example.com/app\n\ngo 1.23\n",
+		config.FileName: repositoryConfig,
+		filepath.Join(ProjectDir, "repository", "repository.go.tmpl"): repositoryTemplate,
+		filepath.Join("templates", "gateway.tmpl"):                    "package gateway\n\n// {{.Name}} is a gateway.\ntype {{.Name}} struct{}\n",
+	})
+	tm := withEmbedded(map[string]string{"usecase/usecase.go.tmpl": usecaseTemplate(SourceEmbedded)})
+
+	for _, kind := range []string{"repository", "gateway", "usecase"} {
+		if err := tm.ValidateComponentType(kind); err != nil {
+			t.Errorf("%s: %v", kind, err)
+		}
+	}
+	if err := tm.ValidateComponentType("mapper"); err == nil || err.Error() != "unsupported component type: mapper. Available: usecase, gateway, repository" {
+		t.Errorf("unknown kind error = %v", err)
+	}
+
+	templates, err := tm.Templates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var listed []string
+	for _, tmpl := range templates {
+		listed = append(listed, tmpl.Component+" "+tmpl.Source+" "+filepath.ToSlash(tmpl.Path))
+	}
+	want := "gateway project templates/gateway.tmpl, repository project .vandor/templates/repository/repository.go.tmpl, usecase embedded usecase/usecase.go.tmpl"
+	if strings.Join(listed, ", ") != want {
+		t.Errorf("templates = %q, want %q", strings.Join(listed, ", "), want)
+	}
+
+	tests := []struct {
+		kind        string
+		args        []string
+		wantPath    string
+		wantContent string
+	}{
+		{
+			kind:        "repository",
+			args:        []string{"billing", "InvoiceRepository", "invoices"},
+			wantPath:    "internal/infrastructure/repository/billing/invoice_repository.go",
+			wantContent: "package billing\n\n// InvoiceRepository reads the invoices table.\ntype InvoiceRepository struct{}\n",
+		},
+		{
+			kind:        "gateway",
+			args:        []string{"payment"},
+			wantPath:    "internal/gateway/payment.go",
+			wantContent: "package gateway\n\n// Payment is a gateway.\ntype Payment struct{}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			overlay := previewWrites(t)
+			component, ok, err := FindComponent(tt.kind)
+			if err != nil || !ok {
+				t.Fatalf("component not found: %v", err)
+			}
+			cfg, err := component.Config(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tm.Generate(cfg); err != nil {
+				t.Fatal(err)
+			}
+
+			changes := overlay.Changes()
+			if len(changes) != 1 || filepath.ToSlash(changes[0].Path) != tt.wantPath {
+				t.Fatalf("changes = %+v, want %s", changes, tt.wantPath)
+			}
+			if string(changes[0].New) != tt.wantContent {
+				t.Errorf("%s =\n%s\nwant\n%s", tt.wantPath, changes[0].New, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestComponentsOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		component config.Component
+		kind      string
+		wantErr   string
+	}{
+		{name: "default args", kind: "repository", component: config.Component{Output: "x/{{.NameSnake}}.go"}},
+		{name: "built-in kind", kind: "usecase", component: config.Component{Output: "x.go"}, wantErr: "usecase is a built-in component"},
+		{name: "invalid kind", kind: "Repo", component: config.Component{Output: "x.go"}, wantErr: "kind must be lowercase letters, digits and dashes"},
+		{name: "no output", kind: "repository", wantErr: "output is required"},
+		{name: "invalid output", kind: "repository", component: config.Component{Output: "{{.Name"}, wantErr: "invalid output pattern"},
+		{name: "no name arg", kind: "repository", component: config.Component{Output: "x.go", Args: []string{"group"}}, wantErr: "args must include name"},
+		{name: "duplicate arg", kind: "repository", component: config.Component{Output: "x.go", Args: []string{"name", "name"}}, wantErr: `duplicate arg "name"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{Path: config.FileName, Components: map[string]config.Component{tt.kind: tt.component}}
+			components, err := ComponentsOf(cfg)
+			if tt.wantErr != "" {
+				want := config.FileName + ": component " + tt.kind + ": " + tt.wantErr
+				if err == nil || !strings.HasPrefix(err.Error(), want) {
+					t.Fatalf("error = %v, want %q", err, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(components) != 1 || strings.Join(components[0].Args, " ") != "name" {
+				t.Errorf("components = %+v, want %s taking a name", components, tt.kind)
+			}
+		})
+	}
+}
@@ -1,28 +1,79 @@
 package templates
 
 import (
+	"bytes"
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+	"github.com/alfariiizi/vandor-cli/internal/naming"
+	"github.com/alfariiizi/vandor-cli/internal/overwrite"
+	"github.com/alfariiizi/vandor-cli/internal/tmplfunc"
 	"github.com/alfariiizi/vandor-cli/internal/utils"
 )
 
 //go:embed usecase/*.tmpl domain/*.tmpl service/*.tmpl job/*.tmpl handler/*.tmpl scheduler/*.tmpl seed/*.tmpl enum/*.tmpl
 var templateFS embed.FS
 
+// ProjectDir is where a project keeps its template overrides.
+var ProjectDir = filepath.Join(".vandor", "templates")
+
+// Template sources, in lookup order.
+const (
+	SourceProject  = "project"
+	SourceUser     = "user"
+	SourceEmbedded = "embedded"
+)
+
+// Template is a component template resolved from one of the sources.
+type Template struct {
+	Component string
+	Source    string // SourceProject, SourceUser or SourceEmbedded
+	Path      string // File path of the template, or its embedded path
+	Content   []byte
+	Files     []TemplateFile // Other files of the template directory
+}
+
+// TemplateFile is a file of a multi-file component template, rendered next
+// to the main file. Its name may contain template actions, e.g.
+// mocks/mock_{{.NameSnake}}.go.tmpl, and template extensions are removed.
+type TemplateFile struct {
+	Name    string // Slash-separated path within the template directory
+	Content []byte
+}
+
+// templateExtensions are the extensions of files rendered as templates, as in
+// vpkg; other files are copied as they are.
+var templateExtensions = []string{".tmpl", ".templ", ".gotmpl"}
+
+// UserDir returns the directory of user-global template overrides,
+// ~/.config/vandor/templates on Linux.
+func UserDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vandor", "templates"), nil
+}
+
 // TemplateData contains common template variables
 type TemplateData struct {
-	ModuleName string // e.g., "github.com/user/project"
-	Name       string // e.g., "CreateUser"
-	Receiver   string // e.g., "createUser"
-	NameSnake  string // e.g., "create_user"
-	PathName   string // e.g., "create-user" (for URLs)
-	Group      string // e.g., "user" (for services/handlers)
-	Method     string // e.g., "POST" (for handlers)
+	ModuleName string            // e.g., "github.com/user/project"
+	Name       string            // e.g., "CreateUser"
+	Receiver   string            // e.g., "createUser"
+	NameSnake  string            // e.g., "create_user"
+	PathName   string            // e.g., "create-user" (for URLs)
+	Group      string            // e.g., "user" (for services/handlers)
+	Method     string            // e.g., "POST" (for handlers)
+	Extra      map[string]string // Additional arguments of custom components
 }
 
 // TemplateConfig defines configuration for template generation
@@ -37,7 +88,7 @@ type TemplateConfig struct {
 
 // TemplateManager handles template operations
 type TemplateManager struct {
-	fs embed.FS
+	fs fs.FS // The embedded templates, one directory per component
 }
 
 // NewTemplateManager creates a new template manager
@@ -59,16 +110,205 @@ func (tm *TemplateManager) Generate(config TemplateConfig) error {
 		return fmt.Errorf("failed to prepare template data: %w", err)
 	}
 
-	// Get template path
-	templatePath := fmt.Sprintf("%s/%s.go.tmpl", config.ComponentType, config.ComponentType)
-
-	// Check if template exists
-	if !tm.templateExists(templatePath) {
-		return fmt.Errorf("template not found: %s", templatePath)
+	// Resolve the template from the project, user or embedded templates
+	tmpl, err := tm.Resolve(config.ComponentType)
+	if err != nil {
+		return err
 	}
 
 	// Generate the file
-	return tm.generateFile(templatePath, config, data)
+	return tm.generateFile(tmpl, config, data)
+}
+
+// templateFile returns the file name of a component's template.
+func templateFile(component string) string {
+	return component + ".go.tmpl"
+}
+
+// overrideDirs returns the override directories by source, in lookup order.
+func overrideDirs() [][2]string {
+	dirs := [][2]string{{SourceProject, ProjectDir}}
+	if dir, err := UserDir(); err == nil {
+		dirs = append(dirs, [2]string{SourceUser, dir})
+	}
+	return dirs
+}
+
+// Resolve returns the template of a component, looking it up first in
+// .vandor/templates/<component>/<component>.go.tmpl, then in the user-global
+// template directory, then in the templates embedded in the CLI. Every other
+// file in the directory of the template found is part of the template too.
+//
+// A custom component declaring a template in vandor-config.yaml uses that
+// file, or the <component>.go.tmpl of that directory, instead.
+func (tm *TemplateManager) Resolve(component string) (Template, error) {
+	custom, ok, err := FindComponent(component)
+	if err != nil {
+		return Template{}, err
+	}
+	if ok && custom.Template != "" {
+		info, err := os.Stat(custom.Template)
+		if err != nil {
+			return Template{}, fmt.Errorf("failed to read template %s: %w", custom.Template, err)
+		}
+		if !info.IsDir() {
+			content, err := os.ReadFile(custom.Template)
+			if err != nil {
+				return Template{}, fmt.Errorf("failed to read template %s: %w", custom.Template, err)
+			}
+			return Template{Component: component, Source: SourceProject, Path: custom.Template, Content: content}, nil
+		}
+		return resolveDir(component, SourceProject, os.DirFS(custom.Template), custom.Template)
+	}
+
+	for _, dir := range overrideDirs() {
+		path := filepath.Join(dir[1], component)
+		tmpl, err := resolveDir(component, dir[0], os.DirFS(path), path)
+		if err == nil {
+			return tmpl, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return Template{}, err
+		}
+	}
+
+	sub, err := fs.Sub(tm.fs, component)
+	if err != nil {
+		return Template{}, err
+	}
+	tmpl, err := resolveDir(component, SourceEmbedded, sub, component)
+	if err != nil {
+		return Template{}, fmt.Errorf("template not found: %s/%s", component, templateFile(component))
+	}
+	return tmpl, nil
+}
+
+// resolveDir reads the template of a component from its template directory.
+func resolveDir(component, source string, fsys fs.FS, dir string) (Template, error) {
+	main := templateFile(component)
+	path := filepath.Join(dir, main)
+	if source == SourceEmbedded {
+		path = dir + "/" + main
+	}
+
+	content, err := fs.ReadFile(fsys, main)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Template{}, err
+		}
+		return Template{}, fmt.Errorf("failed to read template %s: %w", path, err)
+	}
+	tmpl := Template{Component: component, Source: source, Path: path, Content: content}
+
+	err = fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && name != "." {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || name == main {
+			return nil
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		tmpl.Files = append(tmpl.Files, TemplateFile{Name: name, Content: content})
+		return nil
+	})
+	if err != nil {
+		return Template{}, fmt.Errorf("failed to read template directory %s: %w", dir, err)
+	}
+	return tmpl, nil
+}
+
+// Templates returns the resolved template of every component that has an
+// embedded template or an override, or is declared in vandor-config.yaml,
+// sorted by component.
+func (tm *TemplateManager) Templates() ([]Template, error) {
+	components, err := tm.ListAvailableTemplates()
+	if err != nil {
+		return nil, err
+	}
+	custom, err := LoadComponents()
+	if err != nil {
+		return nil, err
+	}
+	for _, component := range custom {
+		components = append(components, component.Kind)
+	}
+	for _, dir := range overrideDirs() {
+		entries, err := os.ReadDir(dir[1])
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() && !contains(components, entry.Name()) {
+				if _, err := os.Stat(filepath.Join(dir[1], entry.Name(), templateFile(entry.Name()))); err == nil {
+					components = append(components, entry.Name())
+				}
+			}
+		}
+	}
+	sort.Strings(components)
+
+	resolved := make([]Template, 0, len(components))
+	for _, component := range components {
+		tmpl, err := tm.Resolve(component)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, tmpl)
+	}
+	return resolved, nil
+}
+
+// Eject copies the embedded template of a component into dir, the project or
+// user-global template directory, so that it can be edited. An existing
+// override is only replaced when force is set.
+func (tm *TemplateManager) Eject(component, dir string, force bool) (string, error) {
+	sub, err := fs.Sub(tm.fs, component)
+	if err == nil {
+		_, err = fs.Stat(sub, templateFile(component))
+	}
+	if err != nil {
+		templates, _ := tm.ListAvailableTemplates()
+		return "", fmt.Errorf("no embedded template for %s. Available: %s", component, strings.Join(templates, ", "))
+	}
+	tmpl, err := resolveDir(component, SourceEmbedded, sub, component)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, component, templateFile(component))
+	if _, err := os.Stat(path); err == nil && !force {
+		return "", fmt.Errorf("%s already exists (use --force to overwrite it)", path)
+	}
+
+	files := append([]TemplateFile{{Name: templateFile(component), Content: tmpl.Content}}, tmpl.Files...)
+	for _, file := range files {
+		target := filepath.Join(dir, component, filepath.FromSlash(file.Name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		if err := os.WriteFile(target, file.Content, 0644); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+	return path, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 // prepareTemplateData prepares the data for template rendering
@@ -77,21 +317,32 @@ func (tm *TemplateManager) prepareTemplateData(config TemplateConfig) (TemplateD
 	if err != nil {
 		return TemplateData{}, fmt.Errorf("failed to detect Go module: %w", err)
 	}
+	return newTemplateData(moduleName, config)
+}
 
-	// Ensure proper capitalization for type names
-	name := utils.ToPascalCase(config.Name)
-	receiver := utils.ToCamelCase(config.Name)
-	nameSnake := utils.ToSnakeCase(config.Name)
-	pathName := utils.ToKebabCase(config.Name)
+// newTemplateData returns the data of a template rendered in module,
+// validating the name and group of the component.
+func newTemplateData(moduleName string, config TemplateConfig) (TemplateData, error) {
+	name, err := naming.Component(config.ComponentType, config.Name)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	group := config.Group
+	if group != "" {
+		if group, err = naming.Package(config.ComponentType+" group", group); err != nil {
+			return TemplateData{}, err
+		}
+	}
 
 	data := TemplateData{
 		ModuleName: moduleName,
-		Name:       name,
-		Receiver:   receiver,
-		NameSnake:  nameSnake,
-		PathName:   pathName,
-		Group:      config.Group,
+		Name:       name.Pascal,
+		Receiver:   name.Camel,
+		NameSnake:  name.Snake,
+		PathName:   name.Kebab,
+		Group:      group,
 		Method:     strings.ToUpper(config.Method),
+		Extra:      config.ExtraData,
 	}
 
 	return data, nil
@@ -99,24 +350,13 @@ func (tm *TemplateManager) prepareTemplateData(config TemplateConfig) (TemplateD
 
 // templateExists checks if a template file exists
 func (tm *TemplateManager) templateExists(templatePath string) bool {
-	_, err := tm.fs.ReadFile(templatePath)
+	_, err := fs.ReadFile(tm.fs, templatePath)
 	return err == nil
 }
 
-// generateFile generates a file from template
-func (tm *TemplateManager) generateFile(templatePath string, config TemplateConfig, data TemplateData) error {
-	// Read template content
-	content, err := tm.fs.ReadFile(templatePath)
-	if err != nil {
-		return fmt.Errorf("failed to read template: %w", err)
-	}
-
-	// Parse template
-	tmpl, err := template.New(filepath.Base(templatePath)).Parse(string(content))
-	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
-	}
-
+// generateFile generates the files of a template. Every file is rendered
+// before any is written, so a broken template leaves no partial set behind.
+func (tm *TemplateManager) generateFile(source Template, config TemplateConfig, data TemplateData) error {
 	// Debug: Print the receiver value
 	fmt.Printf("🔍 Debug - Receiver: '%s', Name: '%s'\n", data.Receiver, data.Name)
 
@@ -128,27 +368,122 @@ func (tm *TemplateManager) generateFile(templatePath string, config TemplateConf
 
 	fmt.Printf("🔍 Debug - Output path: '%s'\n", outputPath)
 
-	// Ensure output directory exists
-	if errDir := os.MkdirAll(filepath.Dir(outputPath), 0755); errDir != nil {
-		return fmt.Errorf("failed to create output directory: %w", errDir)
+	outputs, err := render(source, outputPath, data)
+	if err != nil {
+		return err
+	}
+
+	// Settle every existing file before writing any
+	var create [][2]string
+	for _, output := range outputs {
+		if filepath.Ext(output[0]) == ".go" {
+			if err := naming.Collisions(output[0], []byte(output[1])); err != nil {
+				return err
+			}
+		}
+		ok, err := overwrite.Check(output[0], []byte(output[1]))
+		if err != nil {
+			return err
+		}
+		if ok {
+			create = append(create, output)
+		}
+	}
+
+	for _, output := range create {
+		if _, err := gosrc.WriteFile(output[0], []byte(output[1])); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Printf("✅ Generated %s: %s\n", config.ComponentType, output[0])
 	}
+	return nil
+}
 
-	// Create output file
-	file, err := os.Create(outputPath)
+// render renders the files of a template, the main one to outputPath and
+// the others next to it, returning their paths and contents.
+func render(source Template, outputPath string, data TemplateData) ([][2]string, error) {
+	main, err := renderFile(source.Path, source.Content, outputPath, data)
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return nil, err
 	}
-	defer func() {
-		_ = file.Close()
-	}()
+	outputs := [][2]string{{outputPath, string(main)}}
+
+	// Other files of the template go next to the main file, keeping their
+	// place in the template directory
+	for _, file := range source.Files {
+		name, err := renderName(file.Name, data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file name %s in template %s: %w", file.Name, source.Component, err)
+		}
+		path := filepath.Join(filepath.Dir(outputPath), filepath.FromSlash(name))
 
-	// Execute template
-	if err := tmpl.Execute(file, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+		content := file.Content
+		if isTemplateFile(file.Name) {
+			content, err = renderFile(filepath.Join(filepath.Dir(source.Path), filepath.FromSlash(file.Name)), file.Content, path, data)
+			if err != nil {
+				return nil, err
+			}
+		}
+		outputs = append(outputs, [2]string{path, string(content)})
 	}
+	return outputs, nil
+}
 
-	fmt.Printf("✅ Generated %s: %s\n", config.ComponentType, outputPath)
-	return nil
+// renderFile executes the template file name for the file at outputPath,
+// formatting Go output in-process so generated files are gofmt'd with
+// grouped imports. Errors in the template give its path and line.
+func renderFile(name string, content []byte, outputPath string, data TemplateData) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(tmplfunc.Funcs()).Parse(string(content))
+	if err != nil {
+		// Errors of text/template read "template: <name>:<line>: ..."
+		return nil, fmt.Errorf("failed to parse %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute %w", err)
+	}
+	if filepath.Ext(outputPath) != ".go" {
+		return buf.Bytes(), nil
+	}
+
+	formatted, err := gosrc.Format(buf.Bytes(), data.ModuleName)
+	if err != nil {
+		return nil, fmt.Errorf("template %s produced invalid Go: %w", name, err)
+	}
+	return formatted, nil
+}
+
+// renderName renders the path pattern of a template file and removes its
+// template extension, e.g. mocks/mock_{{.NameSnake}}.go.tmpl becomes
+// mocks/mock_create_user.go.
+func renderName(name string, data TemplateData) (string, error) {
+	tmpl, err := template.New(name).Funcs(tmplfunc.Funcs()).Option("missingkey=error").Parse(name)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	rendered := buf.String()
+	for _, ext := range templateExtensions {
+		if strings.HasSuffix(rendered, ext) {
+			return strings.TrimSuffix(rendered, ext), nil
+		}
+	}
+	return rendered, nil
+}
+
+// isTemplateFile reports whether a file is rendered as a template.
+func isTemplateFile(name string) bool {
+	for _, ext := range templateExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
 }
 
 // getOutputPath determines the output file path
@@ -160,32 +495,40 @@ func (tm *TemplateManager) getOutputPath(config TemplateConfig, data TemplateDat
 		return filepath.Join(config.OutputDir, filename), nil
 	}
 
-	// Default paths based on component type
+	// Default paths from the project layout, where sync discovers components
+	l := layout.Current()
 	switch config.ComponentType {
 	case "usecase":
-		return filepath.Join("internal", "core", "usecase", filename), nil
+		return filepath.Join(l.Usecase, filename), nil
 	case "domain":
-		return filepath.Join("internal", "core", "domain", filename), nil
+		return filepath.Join(l.Domain, filename), nil
 	case "service":
-		if config.Group == "" {
+		if data.Group == "" {
 			return "", fmt.Errorf("group is required for service generation")
 		}
-		return filepath.Join("internal", "core", "service", config.Group, filename), nil
+		return filepath.Join(l.Service, data.Group, filename), nil
 	case "job":
-		return filepath.Join("internal", "core", "job", filename), nil
+		return filepath.Join(l.Job, filename), nil
 	case "handler":
-		if config.Group == "" {
+		if data.Group == "" {
 			return "", fmt.Errorf("group is required for handler generation")
 		}
-		return filepath.Join("internal", "delivery", "http", "handler", config.Group, filename), nil
+		return filepath.Join(l.Handler, data.Group, filename), nil
 	case "scheduler":
-		return filepath.Join("internal", "core", "scheduler", filename), nil
+		return filepath.Join(l.Scheduler, filename), nil
 	case "seed":
-		return filepath.Join("internal", "infrastructure", "seed", filename), nil
+		return filepath.Join(l.Seed, filename), nil
 	case "enum":
-		return filepath.Join("internal", "core", "enum", filename), nil
+		return filepath.Join(l.Enum, filename), nil
 	default:
-		return "", fmt.Errorf("unknown component type: %s", config.ComponentType)
+		custom, ok, err := FindComponent(config.ComponentType)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", fmt.Errorf("unknown component type: %s", config.ComponentType)
+		}
+		return custom.outputPath(data)
 	}
 }
 
@@ -193,7 +536,7 @@ func (tm *TemplateManager) getOutputPath(config TemplateConfig, data TemplateDat
 func (tm *TemplateManager) ListAvailableTemplates() ([]string, error) {
 	var templates []string
 
-	entries, err := tm.fs.ReadDir(".")
+	entries, err := fs.ReadDir(tm.fs, ".")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read template directory: %w", err)
 	}
@@ -217,6 +560,13 @@ func (tm *TemplateManager) ValidateComponentType(componentType string) error {
 	if err != nil {
 		return err
 	}
+	custom, err := LoadComponents()
+	if err != nil {
+		return err
+	}
+	for _, component := range custom {
+		templates = append(templates, component.Kind)
+	}
 
 	for _, template := range templates {
 		if template == componentType {
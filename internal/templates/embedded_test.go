@@ -0,0 +1,291 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
+)
+
+// usecaseTemplate is a usecase template saying where it comes from.
+func usecaseTemplate(source string) string {
+	return "package usecase\n\n// {{.Name}} comes from the " + source + " templates.\ntype {{.Name}} struct{}\n"
+}
+
+// withEmbedded returns a template manager embedding files instead of the
+// templates of the CLI.
+func withEmbedded(files map[string]string) *TemplateManager {
+	fsys := fstest.MapFS{}
+	for name, content := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	return &TemplateManager{fs: fsys}
+}
+
+// previewWrites runs the test with writes kept in an overlay, returned to
+// read what was written.
+func previewWrites(t *testing.T) *vfs.Overlay {
+	t.Helper()
+	overlay := vfs.NewOverlay(vfs.OS{})
+	t.Cleanup(vfs.Use(overlay))
+	return overlay
+}
+
+func TestResolve(t *testing.T) {
+	project := filepath.Join(ProjectDir, "usecase", "usecase.go.tmpl")
+
+	tests := []struct {
+		name       string
+		project    map[string]string // Files of the project
+		user       map[string]string // Files of the user template directory
+		component  string
+		wantSource string
+		wantPath   string // Relative to the user directory for user templates
+		wantErr    string
+	}{
+		{
+			name:       "embedded",
+			component:  "usecase",
+			wantSource: SourceEmbedded,
+			wantPath:   "usecase/usecase.go.tmpl",
+		},
+		{
+			name:       "user over embedded",
+			user:       map[string]string{"usecase/usecase.go.tmpl": usecaseTemplate(SourceUser)},
+			component:  "usecase",
+			wantSource: SourceUser,
+			wantPath:   filepath.Join("usecase", "usecase.go.tmpl"),
+		},
+		{
+			name:       "project over user",
+			project:    map[string]string{project: usecaseTemplate(SourceProject)},
+			user:       map[string]string{"usecase/usecase.go.tmpl": usecaseTemplate(SourceUser)},
+			component:  "usecase",
+			wantSource: SourceProject,
+			wantPath:   project,
+		},
+		{
+			name:       "project directory without the template",
+			project:    map[string]string{filepath.Join(ProjectDir, "usecase", "README.md"): "Notes\n"},
+			user:       map[string]string{"usecase/usecase.go.tmpl": usecaseTemplate(SourceUser)},
+			component:  "usecase",
+			wantSource: SourceUser,
+			wantPath:   filepath.Join("usecase", "usecase.go.tmpl"),
+		},
+		{
+			name:      "nowhere",
+			component: "job",
+			wantErr:   "template not found: job/job.go.tmpl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inProject(t, tt.project)
+			userDir, err := UserDir()
+			if err != nil {
+				t.Fatal(err)
+			}
+			user := map[string]string{}
+			for name, content := range tt.user {
+				user[filepath.Join(userDir, filepath.FromSlash(name))] = content
+			}
+			writeFiles(t, user)
+
+			tmpl, err := withEmbedded(map[string]string{"usecase/usecase.go.tmpl": usecaseTemplate(SourceEmbedded)}).Resolve(tt.component)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantPath := tt.wantPath
+			if tt.wantSource == SourceUser {
+				wantPath = filepath.Join(userDir, tt.wantPath)
+			}
+			if tmpl.Source != tt.wantSource || tmpl.Path != wantPath {
+				t.Errorf("template = %s %s, want %s %s", tmpl.Source, tmpl.Path, tt.wantSource, wantPath)
+			}
+			if string(tmpl.Content) != usecaseTemplate(tt.wantSource) {
+				t.Errorf("content = %q, want the %s template", tmpl.Content, tt.wantSource)
+			}
+			if len(tmpl.Files) != 0 {
+				t.Errorf("files = %+v, want none", tmpl.Files)
+			}
+		})
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	inProject(t, map[string]string{
+		filepath.Join(ProjectDir, "repository", "repository.go.tmpl"): "package repository\n",
+		filepath.Join(ProjectDir, "notes", "README.md"):               "Not a template\n",
+	})
+	userDir, err := UserDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, map[string]string{filepath.Join(userDir, "job", "job.go.tmpl"): "package job\n"})
+
+	templates, err := withEmbedded(map[string]string{
+		"usecase/usecase.go.tmpl": usecaseTemplate(SourceEmbedded),
+		"job/job.go.tmpl":         "package job\n\n// embedded\n",
+	}).Templates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, tmpl := range templates {
+		got = append(got, tmpl.Component+" "+tmpl.Source)
+	}
+	want := "job user, repository project, usecase embedded"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("templates = %q, want %q", strings.Join(got, ", "), want)
+	}
+}
+
+func TestGenerateFromOverride(t *testing.T) {
+	inProject(t, map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.23\n",
+		filepath.Join(ProjectDir, "usecase", "usecase.go.tmpl"): usecaseTemplate(SourceProject),
+	})
+	overlay := previewWrites(t)
+
+	err := withEmbedded(map[string]string{"usecase/usecase.go.tmpl": usecaseTemplate(SourceEmbedded)}).Generate(TemplateConfig{ComponentType: "usecase", Name: "create user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes := overlay.Changes()
+	want := filepath.Join("internal", "core", "usecase", "CreateUser.go")
+	if len(changes) != 1 || changes[0].Path != want {
+		t.Fatalf("changes = %+v, want %s", changes, want)
+	}
+	if !strings.Contains(string(changes[0].New), "// CreateUser comes from the project templates.") {
+		t.Errorf("%s = %s, want it rendered from the project override", want, changes[0].New)
+	}
+	if _, err := os.Stat(want); err == nil {
+		t.Errorf("%s written to disk in a preview", want)
+	}
+}
+
+func TestGenerateMultiFile(t *testing.T) {
+	files := map[string]string{
+		"usecase.go.tmpl":                      usecaseTemplate("multi-file"),
+		"mocks/mock_{{.NameSnake}}.go.tmpl":    "package mocks\n\n// Mock{{.Name}} mocks {{.Name}}.\ntype Mock{{.Name}} struct{}\n",
+		"{{.NameSnake}}_test.go.gotmpl":        "package usecase\n\nimport \"testing\"\n\nfunc Test{{.Name}}(t *testing.T) {}\n",
+		"docs/{{.PathName}}.md.templ":          "# {{.Name}}\n",
+		"README.md":                            "Copied as is: {{.Name}}\n",
+		".notes":                               "Hidden files are not part of the template\n",
+		".drafts/draft_{{.NameSnake}}.go.tmpl": "package drafts\n",
+	}
+	dir := filepath.Join("internal", "core", "usecase")
+	want := map[string]string{
+		filepath.Join(dir, "CreateUser.go"):                "package usecase\n\n// CreateUser comes from the multi-file templates.\ntype CreateUser struct{}\n",
+		filepath.Join(dir, "mocks", "mock_create_user.go"): "package mocks\n\n// MockCreateUser mocks CreateUser.\ntype MockCreateUser struct{}\n",
+		filepath.Join(dir, "create_user_test.go"):          "package usecase\n\nimport \"testing\"\n\nfunc TestCreateUser(t *testing.T) {}\n",
+		filepath.Join(dir, "docs", "create-user.md"):       "# CreateUser\n",
+		filepath.Join(dir, "README.md"):                    "Copied as is: {{.Name}}\n",
+	}
+
+	tests := []struct {
+		name     string
+		project  map[string]string
+		embedded map[string]string
+	}{
+		{name: "project override", project: prefixed(filepath.Join(ProjectDir, "usecase"), files)},
+		{name: "embedded", embedded: prefixed("usecase", files)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := map[string]string{"go.mod": "module example.com/app\n\ngo 1.23\n"}
+			for name, content := range tt.project {
+				project[name] = content
+			}
+			inProject(t, project)
+			overlay := previewWrites(t)
+
+			if err := withEmbedded(tt.embedded).Generate(TemplateConfig{ComponentType: "usecase", Name: "CreateUser"}); err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[string]string{}
+			for _, change := range overlay.Changes() {
+				got[change.Path] = string(change.New)
+			}
+			for path, content := range want {
+				if got[path] != content {
+					t.Errorf("%s =\n%s\nwant\n%s", path, got[path], content)
+				}
+			}
+			for path := range got {
+				if _, ok := want[path]; !ok {
+					t.Errorf("unexpected file %s", path)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateMultiFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "unknown field in a file name",
+			files:   map[string]string{"mock_{{.Table}}.go.tmpl": "package usecase\n"},
+			wantErr: "invalid file name mock_{{.Table}}.go.tmpl in template usecase",
+		},
+		{
+			name:    "broken file",
+			files:   map[string]string{"mock.go.tmpl": "package usecase\n\nvar _ = {{nope .Name}}\n"},
+			wantErr: "failed to parse template: " + filepath.Join(ProjectDir, "usecase", "mock.go.tmpl") + `:3: function "nope" not defined`,
+		},
+		{
+			name:    "file producing invalid Go",
+			files:   map[string]string{"mock.go.tmpl": "package usecase\n\nfunc {\n"},
+			wantErr: "template " + filepath.Join(ProjectDir, "usecase", "mock.go.tmpl") + " produced invalid Go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{"usecase.go.tmpl": usecaseTemplate(SourceProject)}
+			for name, content := range tt.files {
+				files[name] = content
+			}
+			project := prefixed(filepath.Join(ProjectDir, "usecase"), files)
+			project["go.mod"] = "module example.com/app\n\ngo 1.23\n"
+			inProject(t, project)
+			overlay := previewWrites(t)
+
+			err := withEmbedded(nil).Generate(TemplateConfig{ComponentType: "usecase", Name: "CreateUser"})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			// No file is written when one of them cannot be rendered
+			if changes := overlay.Changes(); len(changes) != 0 {
+				t.Errorf("changes = %+v, want none", changes)
+			}
+		})
+	}
+}
+
+// prefixed returns files with their slash-separated names moved into dir.
+func prefixed(dir string, files map[string]string) map[string]string {
+	moved := make(map[string]string, len(files))
+	for name, content := range files {
+		moved[filepath.Join(dir, filepath.FromSlash(name))] = content
+	}
+	return moved
+}
@@ -0,0 +1,133 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/alfariiizi/vandor-cli/internal/gosrc"
+	"github.com/alfariiizi/vandor-cli/internal/naming"
+)
+
+// TestNames are the component names Test renders every template with:
+// acronyms, snake and kebab case, digits, a name whose camel case shadows a
+// builtin, and names whose camel case is a keyword or a predeclared type,
+// which the naming rules reject.
+var TestNames = []string{"User", "HTTPServer", "UserID", "user_profile", "order-item", "V2Report", "Delete", "type", "func", "Map", "String"}
+
+// TestGroups are the groups Test renders the templates of grouped
+// components with.
+var TestGroups = []string{"user", "blog_post"}
+
+// TestModule is the module templates are rendered for by Test.
+const TestModule = "example.com/project"
+
+// TestCase is a rendering of a component template by Test.
+type TestCase struct {
+	Component string
+	Source    string // Source of the template, as in Template
+	Name      string
+	Group     string // Empty for components without groups
+	Rejected  error  // Why add rejects the name or group, which is then not rendered
+	Err       error  // Why the output is not valid, type-correct Go; nil when it is
+}
+
+// Test renders the templates of components, every one when none is given,
+// with each of names and TestGroups, then parses and type-checks the Go
+// files of every rendering. Imports other than the standard library cannot
+// be resolved outside the project, so uses of them are not checked. Names
+// add rejects are reported as rejected instead of rendered.
+func (tm *TemplateManager) Test(components, names []string) ([]TestCase, error) {
+	var resolved []Template
+	if len(components) == 0 {
+		all, err := tm.Templates()
+		if err != nil {
+			return nil, err
+		}
+		resolved = all
+	}
+	for _, component := range components {
+		tmpl, err := tm.Resolve(component)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, tmpl)
+	}
+
+	checker := gosrc.NewChecker("", "")
+	var cases []TestCase
+	for _, tmpl := range resolved {
+		config, groups, err := testConfig(tmpl.Component)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			for _, group := range groups {
+				config.Name, config.Group = name, group
+				rejected, err := tm.test(checker, tmpl, config)
+				cases = append(cases, TestCase{
+					Component: tmpl.Component,
+					Source:    tmpl.Source,
+					Name:      name,
+					Group:     group,
+					Rejected:  rejected,
+					Err:       err,
+				})
+			}
+		}
+	}
+	return cases, nil
+}
+
+// testConfig returns the config a component is rendered with, less its name
+// and group, and the groups to render it with.
+func testConfig(component string) (TemplateConfig, []string, error) {
+	config := TemplateConfig{ComponentType: component, Method: "GET", ExtraData: map[string]string{}}
+	switch component {
+	case "service", "handler":
+		return config, TestGroups, nil
+	}
+
+	custom, ok, err := FindComponent(component)
+	if err != nil || !ok {
+		return config, []string{""}, err
+	}
+	groups := []string{""}
+	for _, arg := range custom.Args {
+		switch arg {
+		case "name", "method":
+		case "group":
+			groups = TestGroups
+		default:
+			config.ExtraData[arg] = "example"
+		}
+	}
+	return config, groups, nil
+}
+
+// test renders a template and checks the Go files it produces. A name or
+// group the naming rules reject is not rendered: add rejects it too, so it
+// is returned as rejected rather than as a failure.
+func (tm *TemplateManager) test(checker *gosrc.Checker, tmpl Template, config TemplateConfig) (rejected, err error) {
+	data, err := newTemplateData(TestModule, config)
+	var invalid *naming.Error
+	if errors.As(err, &invalid) {
+		return err, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	outputPath, err := tm.getOutputPath(config, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine output path: %w", err)
+	}
+	outputs, err := render(tmpl, outputPath, data)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string]string, len(outputs))
+	for _, output := range outputs {
+		sources[output[0]] = output[1]
+	}
+	return nil, checker.CheckFiles(sources)
+}
@@ -0,0 +1,152 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alfariiizi/vandor-cli/internal/config"
+	"github.com/alfariiizi/vandor-cli/internal/layout"
+)
+
+// inProject runs the test from a project directory holding files, with no
+// user configuration or templates.
+func inProject(t *testing.T, files map[string]string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv(config.FileEnv, "")
+	config.Setup("", nil)
+	layout.ResetCurrent()
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+		config.Setup("", nil)
+		layout.ResetCurrent()
+	})
+	// Keep the go command from writing telemetry while the directory is
+	// removed
+	writeFiles(t, map[string]string{filepath.Join(configDir, "go", "telemetry", "mode"): "off"})
+	writeFiles(t, files)
+}
+
+// writeFiles writes files by path.
+func writeFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEmbeddedTemplatesPass(t *testing.T) {
+	inProject(t, nil)
+
+	cases, err := NewTemplateManager().Test(nil, TestNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatal("no template was tested")
+	}
+
+	rejectedNames := map[string]bool{"type": true, "func": true, "Map": true, "String": true}
+	for _, c := range cases {
+		if c.Err != nil {
+			t.Errorf("%s %s/%s: %v", c.Component, c.Group, c.Name, c.Err)
+		}
+		if rejected := c.Rejected != nil; rejected != rejectedNames[c.Name] {
+			t.Errorf("%s %s/%s rejected = %v (%v), want %v", c.Component, c.Group, c.Name, rejected, c.Rejected, rejectedNames[c.Name])
+		}
+	}
+}
+
+func TestRejectedNames(t *testing.T) {
+	inProject(t, nil)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"type", "its camel case type is a Go keyword"},
+		{"func", "its camel case func is a Go keyword"},
+		{"Map", "its camel case map is a Go keyword"},
+		{"String", "its camel case string is a predeclared Go identifier"},
+		{"2fa", "starts with a digit"},
+	}
+	for _, tt := range tests {
+		cases, err := NewTemplateManager().Test([]string{"usecase"}, []string{tt.name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cases) != 1 {
+			t.Fatalf("%s: %d cases, want 1", tt.name, len(cases))
+		}
+		c := cases[0]
+		if c.Err != nil {
+			t.Errorf("%s: a rejected name fails the template: %v", tt.name, c.Err)
+		}
+		if c.Rejected == nil || !strings.Contains(c.Rejected.Error(), tt.want) {
+			t.Errorf("%s: rejected = %v, want %q", tt.name, c.Rejected, tt.want)
+		}
+	}
+}
+
+func TestBrokenOverride(t *testing.T) {
+	override := filepath.Join(ProjectDir, "usecase", "usecase.go.tmpl")
+	generated := filepath.ToSlash(filepath.Join(layout.Default().Usecase, "User.go"))
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "parse error",
+			content: "package usecase\n\ntype {{.Name}} struct{}\n\nvar _ = {{nope .Name}}\n",
+			want:    override + `:5: function "nope" not defined`,
+		},
+		{
+			name:    "execution error",
+			content: "package usecase\n\n// {{.Name}}\n{{.Missing}}\n",
+			want:    override + ":4:2: executing",
+		},
+		{
+			name:    "type error",
+			content: "package usecase\n\ntype {{.Name}} struct{}\n\nfunc New{{.Name}}() *{{.Name}} {\n\treturn &{{.Name}}{id: 1}\n}\n",
+			want:    generated + ":6:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inProject(t, map[string]string{override: tt.content})
+
+			cases, err := NewTemplateManager().Test([]string{"usecase"}, []string{"User"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(cases) != 1 {
+				t.Fatalf("%d cases, want 1", len(cases))
+			}
+			c := cases[0]
+			if c.Source != SourceProject {
+				t.Errorf("source = %s, want the project override", c.Source)
+			}
+			if c.Err == nil || !strings.Contains(c.Err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", c.Err, tt.want)
+			}
+		})
+	}
+}
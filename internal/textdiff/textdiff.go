@@ -0,0 +1,165 @@
+// Package textdiff renders line-based unified diffs of generated files.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change.
+const contextLines = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// Unified returns a unified diff turning oldContent into newContent,
+// labelled with the given file names, or "" when the contents are identical.
+func Unified(oldName, newName string, oldContent, newContent []byte) string {
+	if string(oldContent) == string(newContent) {
+		return ""
+	}
+
+	ops := diffLines(splitLines(string(oldContent)), splitLines(string(newContent)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(ops) {
+		writeHunk(&b, ops, h)
+	}
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes an edit script from the longest common subsequence of
+// the two line slices.
+func diffLines(a, b []string) []op {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{opEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{opDelete, a[i]})
+			i++
+		default:
+			ops = append(ops, op{opInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, op{opDelete, a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, op{opInsert, b[j]})
+	}
+	return ops
+}
+
+// hunk is a half-open range of ops to print.
+type hunk struct{ start, end int }
+
+// hunks groups changed ops with their surrounding context, merging groups
+// whose context overlaps.
+func hunks(ops []op) []hunk {
+	var result []hunk
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+		start := max(i-contextLines, 0)
+		end := min(i+1+contextLines, len(ops))
+		if len(result) > 0 && start <= result[len(result)-1].end {
+			result[len(result)-1].end = end
+			continue
+		}
+		result = append(result, hunk{start, end})
+	}
+	return result
+}
+
+func writeHunk(b *strings.Builder, ops []op, h hunk) {
+	// Line numbers of the hunk's first line in each file.
+	oldLine, newLine := 1, 1
+	for _, o := range ops[:h.start] {
+		if o.kind != opInsert {
+			oldLine++
+		}
+		if o.kind != opDelete {
+			newLine++
+		}
+	}
+
+	var oldCount, newCount int
+	var body strings.Builder
+	for _, o := range ops[h.start:h.end] {
+		prefix := " "
+		switch o.kind {
+		case opDelete:
+			prefix = "-"
+			oldCount++
+		case opInsert:
+			prefix = "+"
+			newCount++
+		default:
+			oldCount++
+			newCount++
+		}
+		body.WriteString(prefix + o.line)
+		if !strings.HasSuffix(o.line, "\n") {
+			body.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+	b.WriteString(body.String())
+}
+
+// hunkRange formats a hunk header range; an empty range points at the line
+// before it, as in diff -u.
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line--
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
@@ -0,0 +1,65 @@
+package textdiff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "identical",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name: "changed line",
+			old:  "a\nb\nc\n",
+			new:  "a\nx\nc\n",
+			want: "--- old.go\n+++ new.go\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			name: "created file",
+			old:  "",
+			new:  "a\nb\n",
+			want: "--- old.go\n+++ new.go\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "emptied file",
+			old:  "a\n",
+			new:  "",
+			want: "--- old.go\n+++ new.go\n@@ -1 +0,0 @@\n-a\n",
+		},
+		{
+			name: "missing final newline",
+			old:  "a\n",
+			new:  "a",
+			want: "--- old.go\n+++ new.go\n@@ -1 +1 @@\n-a\n+a\n\\ No newline at end of file\n",
+		},
+		{
+			name: "distant changes make separate hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			new:  "X\n2\n3\n4\n5\n6\n7\n8\n9\nY\n",
+			want: "--- old.go\n+++ new.go\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+X\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+Y\n",
+		},
+		{
+			name: "nearby changes share a hunk",
+			old:  "1\n2\n3\n4\n5\n",
+			new:  "X\n2\n3\n4\nY\n",
+			want: "--- old.go\n+++ new.go\n@@ -1,5 +1,5 @@\n-1\n+X\n 2\n 3\n 4\n-5\n+Y\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("old.go", "new.go", []byte(tt.old), []byte(tt.new))
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Package tmplfunc is the function library of the templates rendered by the
+// CLI: the component templates of add, embedded or overridden in a project
+// or user directory, the output patterns of custom components, and vpkg
+// package templates.
+package tmplfunc
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/alfariiizi/vandor-cli/internal/utils"
+)
+
+// Funcs returns the template functions, e.g.
+//
+//	{{Pascal .Name}}                     CreateUser
+//	{{GoPascal "http_server"}}           HTTPServer
+//	{{GoCamel "user_id"}}                userID
+//	{{Plural "Category"}}                Categories
+//	{{Import .ModuleName "internal/db"}} github.com/user/project/internal/db
+//	{{.Extra.table | Default "users"}}   users when table is not given
+//	{{Required "entity" .Extra.entity}}  fails when entity is not given
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		// Case conversions
+		"Title":    utils.ToTitle,
+		"Camel":    utils.ToCamelCase,
+		"Snake":    utils.ToSnakeCase,
+		"Kebab":    utils.ToKebabCase,
+		"Upper":    strings.ToUpper,
+		"Lower":    strings.ToLower,
+		"Pascal":   utils.ToPascalCase,
+		"GoPascal": utils.ToGoPascalCase,
+		"GoCamel":  utils.ToGoCamelCase,
+		"GoIdent":  utils.ToGoIdentifier,
+
+		// Inflections
+		"Plural":   utils.ToPlural,
+		"Singular": utils.ToSingular,
+
+		// Import paths
+		"Import":  Import,
+		"PkgName": PkgName,
+
+		// Values
+		"Default":  Default,
+		"Required": Required,
+	}
+}
+
+// Import joins a module path and slash- or OS-separated path elements into
+// an import path.
+func Import(module string, elem ...string) string {
+	parts := []string{module}
+	for _, e := range elem {
+		parts = append(parts, strings.ReplaceAll(e, `\`, "/"))
+	}
+	return path.Join(parts...)
+}
+
+// PkgName returns the conventional package name of an import path: its
+// last element, lower case, without the characters an identifier cannot
+// hold.
+func PkgName(importPath string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(path.Base(strings.ReplaceAll(importPath, `\`, "/"))) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "pkg" + name
+	}
+	return name
+}
+
+// Default returns value, or def when value is missing or empty. It takes
+// the value last so that it can end a pipeline: {{.Extra.table | Default
+// "users"}}.
+func Default(def interface{}, value ...interface{}) interface{} {
+	if len(value) == 0 || empty(value[0]) {
+		return def
+	}
+	return value[0]
+}
+
+// Required returns value, failing the template when it is missing or empty.
+func Required(name string, value ...interface{}) (interface{}, error) {
+	if len(value) == 0 || empty(value[0]) {
+		return nil, fmt.Errorf("%s is required", name)
+	}
+	return value[0], nil
+}
+
+// empty reports whether v is nil or the zero value of its type, or an empty
+// collection.
+func empty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
@@ -0,0 +1,66 @@
+package tmplfunc
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncs(t *testing.T) {
+	type data struct {
+		Name       string
+		ModuleName string
+		Extra      map[string]string
+	}
+	base := data{Name: "create_user", ModuleName: "example.com/app", Extra: map[string]string{"entity": "User"}}
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		extra   map[string]string
+		want    string
+		wantErr string
+	}{
+		{name: "pascal", tmpl: `{{Pascal .Name}}`, want: "CreateUser"},
+		{name: "go pascal", tmpl: `{{GoPascal "http_server"}}`, want: "HTTPServer"},
+		{name: "go camel", tmpl: `{{GoCamel "user_id"}}`, want: "userID"},
+		{name: "plural", tmpl: `{{Plural "Category"}}`, want: "Categories"},
+		{name: "singular", tmpl: `{{Singular "Categories"}}`, want: "Category"},
+		{name: "import", tmpl: `{{Import .ModuleName "internal/db"}}`, want: "example.com/app/internal/db"},
+		{name: "import backslashes", tmpl: `{{Import .ModuleName "internal\\core" "model"}}`, want: "example.com/app/internal/core/model"},
+		{name: "package name", tmpl: `{{PkgName "example.com/app/user-service"}}`, want: "userservice"},
+		{name: "package name digit", tmpl: `{{PkgName "example.com/app/2fa"}}`, want: "pkg2fa"},
+		{name: "default missing", tmpl: `{{.Extra.table | Default "users"}}`, want: "users"},
+		{name: "default given", tmpl: `{{.Extra.table | Default "users"}}`, extra: map[string]string{"table": "accounts"}, want: "accounts"},
+		{name: "required given", tmpl: `{{Required "entity" .Extra.entity}}`, want: "User"},
+		{name: "required missing", tmpl: `{{Required "entity" .Extra.entity}}`, extra: map[string]string{}, wantErr: "entity is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := base
+			if tt.extra != nil {
+				d.Extra = tt.extra
+			}
+
+			tmpl, err := template.New(tt.name).Funcs(Funcs()).Parse(tt.tmpl)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			var b strings.Builder
+			err = tmpl.Execute(&b, d)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("execute: %v", err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/alfariiizi/vandor-cli/internal/command"
+	"github.com/alfariiizi/vandor-cli/internal/overwrite"
 )
 
 // ExecutionResult represents the result of a command execution
@@ -60,6 +61,12 @@ func (e *CommandExecutor) ExecuteCommand(category, name string, args []string) E
 		Stderr: &stderr,
 	}
 
+	// Existing files cannot be asked about while the TUI owns the terminal
+	if overwrite.Current() == overwrite.Ask {
+		restore := overwrite.Use(overwrite.Refuse)
+		defer restore()
+	}
+
 	// Execute the command
 	err := cmd.Execute(ctx)
 
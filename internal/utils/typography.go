@@ -135,3 +135,115 @@ func toTitle(s string) string {
 	r[0] = unicode.ToUpper(r[0])
 	return string(r)
 }
+
+// ToPlural returns the English plural of a singular noun, e.g. "Category"
+// becomes "Categories". It covers the regular forms used for resource names.
+func ToPlural(s string) string {
+	lower := strings.ToLower(s)
+	switch {
+	case s == "":
+		return s
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return s + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return s[:len(s)-1] + "ies"
+	}
+	return s + "s"
+}
+
+// ToSingular returns the English singular of a plural noun, reversing
+// ToPlural, e.g. "Categories" becomes "Category".
+func ToSingular(s string) string {
+	lower := strings.ToLower(s)
+	switch {
+	case len(lower) > 3 && strings.HasSuffix(lower, "ies"):
+		return s[:len(s)-3] + matchCase("y", s[len(s)-3:])
+	case strings.HasSuffix(lower, "ses"), strings.HasSuffix(lower, "xes"), strings.HasSuffix(lower, "zes"),
+		strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		return s[:len(s)-2]
+	case strings.HasSuffix(lower, "ss"), strings.HasSuffix(lower, "us"), strings.HasSuffix(lower, "is"):
+		return s
+	case strings.HasSuffix(lower, "s") && len(lower) > 1:
+		return s[:len(s)-1]
+	}
+	return s
+}
+
+// matchCase returns s in upper case when like is upper case.
+func matchCase(s, like string) string {
+	if like == strings.ToUpper(like) {
+		return strings.ToUpper(s)
+	}
+	return s
+}
+
+// initialisms are the words Go names keep in upper case, as in golint.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "CSV": true,
+	"DB": true, "DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "JWT": true, "LHS": true,
+	"QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true, "SMTP": true,
+	"SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true,
+	"UI": true, "UID": true, "URI": true, "URL": true, "UTF8": true, "UUID": true,
+	"VM": true, "XML": true, "XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// ToGoPascalCase converts s to an exported Go name, keeping initialisms in
+// upper case: "http_server" becomes "HTTPServer" and "user_id" "UserID".
+func ToGoPascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range goWords(s) {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			b.WriteString(upper)
+		} else {
+			b.WriteString(toTitle(word))
+		}
+	}
+	return b.String()
+}
+
+// ToGoCamelCase converts s to an unexported Go name, keeping initialisms
+// after the first word in upper case: "user_id" becomes "userID" and
+// "HTTPServer" "httpServer".
+func ToGoCamelCase(s string) string {
+	words := goWords(s)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.ToLower(words[0]) + ToGoPascalCase(strings.Join(words[1:], "_"))
+}
+
+// goWords splits s into words on delimiters and case boundaries, splitting
+// runs of capitals before their last letter when a lower case letter
+// follows, so that "HTTPServer" is "HTTP" and "Server".
+func goWords(s string) []string {
+	var words []string
+	var word []rune
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = word[:0]
+			}
+			continue
+		}
+
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && next) {
+				words = append(words, string(word))
+				word = word[:0]
+			}
+		}
+		word = append(word, r)
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
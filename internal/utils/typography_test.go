@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToPlural(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"", ""},
+		{"User", "Users"},
+		{"Category", "Categories"},
+		{"Day", "Days"},
+		{"Box", "Boxes"},
+		{"Address", "Addresses"},
+		{"Match", "Matches"},
+		{"Wish", "Wishes"},
+	}
+	for _, tt := range tests {
+		if got := ToPlural(tt.in); got != tt.want {
+			t.Errorf("ToPlural(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if tt.in == "" {
+			continue
+		}
+		if got := ToSingular(tt.want); got != tt.in {
+			t.Errorf("ToSingular(%q) = %q, want %q", tt.want, got, tt.in)
+		}
+	}
+}
+
+func TestToSingular(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"", ""},
+		{"s", "s"},
+		{"User", "User"},
+		{"CATEGORIES", "CATEGORY"},
+		{"Status", "Status"},
+		{"Class", "Class"},
+		{"Analysis", "Analysis"},
+	}
+	for _, tt := range tests {
+		if got := ToSingular(tt.in); got != tt.want {
+			t.Errorf("ToSingular(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGoWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"user_id", []string{"user", "id"}},
+		{"order-item", []string{"order", "item"}},
+		{"UserID", []string{"User", "ID"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"getHTTPResponseCode", []string{"get", "HTTP", "Response", "Code"}},
+		{"V2Report", []string{"V2", "Report"}},
+	}
+	for _, tt := range tests {
+		if got := goWords(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("goWords(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToGoCase(t *testing.T) {
+	tests := []struct{ in, pascal, camel string }{
+		{"", "", ""},
+		{"http_server", "HTTPServer", "httpServer"},
+		{"HTTPServer", "HTTPServer", "httpServer"},
+		{"user_id", "UserID", "userID"},
+		{"UserID", "UserID", "userID"},
+		{"api_url", "APIURL", "apiURL"},
+		{"user_profile", "UserProfile", "userProfile"},
+		{"order-item", "OrderItem", "orderItem"},
+		{"V2Report", "V2Report", "v2Report"},
+		{"utf8_string", "UTF8String", "utf8String"},
+	}
+	for _, tt := range tests {
+		if got := ToGoPascalCase(tt.in); got != tt.pascal {
+			t.Errorf("ToGoPascalCase(%q) = %q, want %q", tt.in, got, tt.pascal)
+		}
+		if got := ToGoCamelCase(tt.in); got != tt.camel {
+			t.Errorf("ToGoCamelCase(%q) = %q, want %q", tt.in, got, tt.camel)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package vfs
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Overlay is an in-memory layer over a base filesystem. Reads see the
+// overlay's own writes; the base is never modified. It is safe for
+// concurrent use.
+type Overlay struct {
+	mu    sync.Mutex
+	base  FS
+	files map[string][]byte
+	dirs  map[string]bool
+}
+
+// NewOverlay returns an empty overlay over base.
+func NewOverlay(base FS) *Overlay {
+	return &Overlay{
+		base:  base,
+		files: map[string][]byte{},
+		dirs:  map[string]bool{},
+	}
+}
+
+// Change is a file the overlay would create or modify on the base.
+type Change struct {
+	Path    string
+	Old     []byte // Content on the base, nil when Created
+	New     []byte
+	Created bool
+}
+
+// Changes returns the files whose overlay content differs from the base,
+// sorted by path.
+func (o *Overlay) Changes() []Change {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var changes []Change
+	for name, content := range o.files {
+		old, err := o.base.ReadFile(name)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, Change{Path: name, New: content, Created: true})
+		case err == nil && bytes.Equal(old, content):
+		default:
+			changes = append(changes, Change{Path: name, Old: old, New: content})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func (o *Overlay) ReadFile(name string) ([]byte, error) {
+	o.mu.Lock()
+	content, ok := o.files[filepath.Clean(name)]
+	o.mu.Unlock()
+	if ok {
+		return append([]byte(nil), content...), nil
+	}
+	return o.base.ReadFile(name)
+}
+
+func (o *Overlay) Stat(name string) (fs.FileInfo, error) {
+	name = filepath.Clean(name)
+	o.mu.Lock()
+	content, isFile := o.files[name]
+	isDir := o.dirs[name]
+	o.mu.Unlock()
+
+	switch {
+	case isFile:
+		return memInfo{name: filepath.Base(name), size: int64(len(content))}, nil
+	case isDir:
+		if info, err := o.base.Stat(name); err == nil {
+			return info, nil
+		}
+		return memInfo{name: filepath.Base(name), dir: true}, nil
+	}
+	return o.base.Stat(name)
+}
+
+func (o *Overlay) ReadDir(name string) ([]fs.DirEntry, error) {
+	name = filepath.Clean(name)
+	entries, err := o.base.ReadDir(name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err != nil && !o.dirs[name] {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		seen[entry.Name()] = true
+	}
+	for file, content := range o.files {
+		if filepath.Dir(file) == name && !seen[filepath.Base(file)] {
+			seen[filepath.Base(file)] = true
+			entries = append(entries, fs.FileInfoToDirEntry(memInfo{name: filepath.Base(file), size: int64(len(content))}))
+		}
+	}
+	for dir := range o.dirs {
+		if dir != name && filepath.Dir(dir) == name && !seen[filepath.Base(dir)] {
+			seen[filepath.Base(dir)] = true
+			entries = append(entries, fs.FileInfoToDirEntry(memInfo{name: filepath.Base(dir), dir: true}))
+		}
+	}
+	sortEntries(entries)
+	return entries, nil
+}
+
+func (o *Overlay) WriteFile(name string, data []byte, _ fs.FileMode) error {
+	name = filepath.Clean(name)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.dirs[name] {
+		return &fs.PathError{Op: "write", Path: name, Err: errors.New("is a directory")}
+	}
+	o.files[name] = append([]byte(nil), data...)
+	o.addDirs(filepath.Dir(name))
+	return nil
+}
+
+func (o *Overlay) MkdirAll(path string, _ fs.FileMode) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.addDirs(filepath.Clean(path))
+	return nil
+}
+
+// addDirs records dir and its parents. The caller holds o.mu.
+func (o *Overlay) addDirs(dir string) {
+	for {
+		o.dirs[dir] = true
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return
+		}
+		dir = parent
+	}
+}
+
+// memInfo describes a file or directory that only exists in an overlay.
+type memInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i memInfo) Name() string       { return i.name }
+func (i memInfo) Size() int64        { return i.size }
+func (i memInfo) ModTime() time.Time { return time.Time{} }
+func (i memInfo) IsDir() bool        { return i.dir }
+func (i memInfo) Sys() any           { return nil }
+
+func (i memInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o755
+	}
+	return 0o644
+}
@@ -0,0 +1,170 @@
+package vfs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newBase creates a directory on disk holding files, for an overlay to sit
+// on.
+func newBase(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestOverlayReadsItsWritesAndLeavesTheBase(t *testing.T) {
+	root := newBase(t, map[string]string{"a.go": "base"})
+	o := NewOverlay(OS{})
+
+	if err := o.WriteFile(filepath.Join(root, "a.go"), []byte("overlay"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.WriteFile(filepath.Join(root, "new", "b.go"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		overlay string
+		base    string // "" when the file must not exist on disk
+	}{
+		{"a.go", "overlay", "base"},
+		{filepath.Join("new", "b.go"), "new", ""},
+	}
+	for _, tt := range tests {
+		got, err := o.ReadFile(filepath.Join(root, tt.path))
+		if err != nil || string(got) != tt.overlay {
+			t.Errorf("overlay %s = %q, %v; want %q", tt.path, got, err, tt.overlay)
+		}
+
+		onDisk, err := os.ReadFile(filepath.Join(root, tt.path))
+		switch {
+		case tt.base == "" && !errors.Is(err, fs.ErrNotExist):
+			t.Errorf("%s was written to disk", tt.path)
+		case tt.base != "" && string(onDisk) != tt.base:
+			t.Errorf("disk %s = %q, want %q", tt.path, onDisk, tt.base)
+		}
+	}
+}
+
+func TestOverlayChanges(t *testing.T) {
+	root := newBase(t, map[string]string{"same.go": "same", "edited.go": "old"})
+	o := NewOverlay(OS{})
+
+	writes := map[string]string{"same.go": "same", "edited.go": "new", "created.go": "created"}
+	for name, content := range writes {
+		if err := o.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []Change{
+		{Path: filepath.Join(root, "created.go"), New: []byte("created"), Created: true},
+		{Path: filepath.Join(root, "edited.go"), Old: []byte("old"), New: []byte("new")},
+	}
+	if got := o.Changes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("changes = %+v, want %+v", got, want)
+	}
+}
+
+func TestOverlayDirectories(t *testing.T) {
+	root := newBase(t, map[string]string{"dir/base.go": "base"})
+	o := NewOverlay(OS{})
+
+	if err := o.WriteFile(filepath.Join(root, "dir", "added.go"), []byte("added"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.MkdirAll(filepath.Join(root, "dir", "sub", "deeper"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := o.ReadDir(filepath.Join(root, "dir"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := []string{"added.go", "base.go", "sub"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("entries = %v, want %v", names, want)
+	}
+
+	tests := []struct {
+		path  string
+		isDir bool
+	}{
+		{filepath.Join("dir", "added.go"), false},
+		{filepath.Join("dir", "base.go"), false},
+		{filepath.Join("dir", "sub"), true},
+		{filepath.Join("dir", "sub", "deeper"), true},
+	}
+	for _, tt := range tests {
+		info, err := o.Stat(filepath.Join(root, tt.path))
+		if err != nil {
+			t.Errorf("stat %s: %v", tt.path, err)
+			continue
+		}
+		if info.IsDir() != tt.isDir {
+			t.Errorf("%s: IsDir = %v, want %v", tt.path, info.IsDir(), tt.isDir)
+		}
+	}
+
+	if _, err := o.ReadDir(filepath.Join(root, "missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("reading a missing directory: err = %v, want not exist", err)
+	}
+	if err := o.WriteFile(filepath.Join(root, "dir", "sub"), nil, 0o644); err == nil {
+		t.Error("writing over an overlay directory succeeded")
+	}
+}
+
+func TestUseOverlay(t *testing.T) {
+	root := newBase(t, map[string]string{"a.go": "a"})
+	o := NewOverlay(OS{})
+
+	restore := Use(o)
+	if !IsVirtual() {
+		t.Error("IsVirtual is false while an overlay is in use")
+	}
+	if err := WriteFile(filepath.Join(root, "pkg", "b.go"), []byte("b"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var walked []string
+	err := WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		walked = append(walked, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{".", "a.go", "pkg", "pkg/b.go"}; !reflect.DeepEqual(walked, want) {
+		t.Errorf("walked %v, want %v", walked, want)
+	}
+
+	restore()
+	if IsVirtual() {
+		t.Error("IsVirtual is true after restoring the disk")
+	}
+	if Exists(filepath.Join(root, "pkg", "b.go")) {
+		t.Error("overlay write is visible after restoring the disk")
+	}
+}
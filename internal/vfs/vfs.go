@@ -0,0 +1,142 @@
+// Package vfs is the filesystem generators read and write through. By
+// default it is the real disk; previews swap in an Overlay that keeps writes
+// in memory so they can be reported instead of applied.
+package vfs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FS is the set of filesystem operations generators use.
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	Stat(name string) (fs.FileInfo, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+}
+
+// OS is the real filesystem.
+type OS struct{}
+
+func (OS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (OS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (OS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+func (OS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+func (OS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+var (
+	mu      sync.RWMutex
+	current FS = OS{}
+)
+
+// Current returns the filesystem generators are using.
+func Current() FS {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Use makes fsys the current filesystem and returns a function restoring the
+// previous one.
+func Use(fsys FS) (restore func()) {
+	mu.Lock()
+	previous := current
+	current = fsys
+	mu.Unlock()
+
+	return func() {
+		mu.Lock()
+		current = previous
+		mu.Unlock()
+	}
+}
+
+// IsVirtual reports whether writes are currently kept in memory. Steps that
+// shell out to external programs, which would write to disk directly, check
+// this and skip themselves.
+func IsVirtual() bool {
+	_, ok := Current().(*Overlay)
+	return ok
+}
+
+// ReadFile reads a file from the current filesystem.
+func ReadFile(name string) ([]byte, error) { return Current().ReadFile(name) }
+
+// ReadDir lists a directory of the current filesystem, sorted by name.
+func ReadDir(name string) ([]fs.DirEntry, error) { return Current().ReadDir(name) }
+
+// Stat describes a file of the current filesystem.
+func Stat(name string) (fs.FileInfo, error) { return Current().Stat(name) }
+
+// WriteFile writes a file to the current filesystem.
+func WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return Current().WriteFile(name, data, perm)
+}
+
+// MkdirAll creates a directory and its parents on the current filesystem.
+func MkdirAll(path string, perm fs.FileMode) error { return Current().MkdirAll(path, perm) }
+
+// Exists reports whether name exists on the current filesystem.
+func Exists(name string) bool {
+	_, err := Stat(name)
+	return err == nil
+}
+
+// WalkDir walks the tree rooted at root on the current filesystem, like
+// filepath.WalkDir.
+func WalkDir(root string, fn fs.WalkDirFunc) error {
+	info, err := Stat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkDir(root, fs.FileInfoToDirEntry(info), fn)
+	}
+	if errors.Is(err, filepath.SkipDir) || errors.Is(err, filepath.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+func walkDir(path string, d fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(path, d, nil); err != nil || !d.IsDir() {
+		if errors.Is(err, filepath.SkipDir) && d.IsDir() {
+			err = nil
+		}
+		return err
+	}
+
+	entries, err := ReadDir(path)
+	if err != nil {
+		if err = fn(path, d, err); err != nil {
+			if errors.Is(err, filepath.SkipDir) {
+				err = nil
+			}
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		if err := walkDir(filepath.Join(path, entry.Name()), entry, fn); err != nil {
+			if errors.Is(err, filepath.SkipDir) {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// sortEntries orders directory entries by name, as os.ReadDir does.
+func sortEntries(entries []fs.DirEntry) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+}
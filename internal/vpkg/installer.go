@@ -1,6 +1,7 @@
 package vpkg
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,7 +11,10 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/alfariiizi/vandor-cli/internal/config"
+	"github.com/alfariiizi/vandor-cli/internal/tmplfunc"
 	"github.com/alfariiizi/vandor-cli/internal/utils"
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
 // Installer handles package installation and removal
@@ -18,10 +22,10 @@ type Installer struct {
 	registryClient *RegistryClient
 }
 
-// NewInstaller creates a new package installer
-func NewInstaller(registryURL string) *Installer {
+// NewInstaller creates a new package installer using the given registries
+func NewInstaller(registryURLs ...string) *Installer {
 	return &Installer{
-		registryClient: NewRegistryClient(registryURL),
+		registryClient: NewRegistryClient(registryURLs...),
 	}
 }
 
@@ -73,7 +77,7 @@ func (i *Installer) Install(packageName string, opts InstallOptions) error {
 
 	// Create destination directory
 	if !opts.DryRun {
-		if errDir := os.MkdirAll(destPath, 0o755); errDir != nil {
+		if errDir := vfs.MkdirAll(destPath, 0o755); errDir != nil {
 			return fmt.Errorf("failed to create destination directory: %w", errDir)
 		}
 	}
@@ -193,30 +197,28 @@ func (i *Installer) installTemplate(packageWithRepo *PackageWithRepo, templatePa
 	}
 
 	// Create directory structure
-	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+	if err := vfs.MkdirAll(outputDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create directory %s: %w", outputDir, err)
 	}
 
 	// Render template if it's a template file (supports multiple extensions)
 	if i.isTemplateFile(templatePath) {
 		outputName := filepath.Base(relPath)
-		tmpl, err := template.New(outputName).Funcs(templateFuncs()).Parse(string(content))
+		tmpl, err := template.New(outputName).Funcs(tmplfunc.Funcs()).Parse(string(content))
 		if err != nil {
 			return fmt.Errorf("failed to parse template: %w", err)
 		}
 
-		file, err := os.Create(outputPath)
-		if err != nil {
-			return fmt.Errorf("failed to create output file: %w", err)
-		}
-		defer func() { _ = file.Close() }()
-
-		if err := tmpl.Execute(file, ctx); err != nil {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, ctx); err != nil {
 			return fmt.Errorf("failed to execute template: %w", err)
 		}
+		if err := vfs.WriteFile(outputPath, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
 	} else {
 		// Copy file as-is (for non-template files like static assets)
-		if err := os.WriteFile(outputPath, content, 0o644); err != nil {
+		if err := vfs.WriteFile(outputPath, content, 0o644); err != nil {
 			return fmt.Errorf("failed to write file: %w", err)
 		}
 	}
@@ -272,20 +274,6 @@ func (i *Installer) prepareTemplateContext(packageName string, pkg *Package, des
 	}, nil
 }
 
-// templateFuncs returns template helper functions
-func templateFuncs() template.FuncMap {
-	return template.FuncMap{
-		"Title":   utils.ToTitle,
-		"Camel":   utils.ToCamelCase,
-		"Snake":   utils.ToSnakeCase,
-		"Kebab":   utils.ToKebabCase,
-		"Upper":   strings.ToUpper,
-		"Lower":   strings.ToLower,
-		"Pascal":  utils.ToPascalCase,
-		"GoIdent": utils.ToGoIdentifier,
-	}
-}
-
 // packageExists checks if a package already exists at the given path
 func (i *Installer) packageExists(path string) bool {
 	_, err := os.Stat(path)
@@ -321,7 +309,7 @@ func (i *Installer) writeInstalledMeta(destPath, name, version string, pkg *Pack
 	}
 
 	metaPath := filepath.Join(destPath, "meta.yaml")
-	return os.WriteFile(metaPath, data, 0o644)
+	return vfs.WriteFile(metaPath, data, 0o644)
 }
 
 // loadInstalledPackage loads an installed package from its meta.yaml
@@ -436,16 +424,14 @@ func (i *Installer) findProjectRoot() (string, error) {
 		return "", fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	// Walk up the directory tree looking for project markers
+	// The directory of vandor-config.yaml (primary marker)
+	if path, err := config.Find(currentDir); err == nil && path != "" {
+		return filepath.Dir(path), nil
+	}
+
+	// Walk up the directory tree looking for go.mod as fallback
 	dir := currentDir
 	for {
-		// Check for vandor-config.yaml (primary marker)
-		vandorConfig := filepath.Join(dir, "vandor-config.yaml")
-		if _, err := os.Stat(vandorConfig); err == nil {
-			return dir, nil
-		}
-
-		// Check for go.mod as fallback
 		goMod := filepath.Join(dir, "go.mod")
 		if _, err := os.Stat(goMod); err == nil {
 			return dir, nil
@@ -12,6 +12,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"golang.org/x/term"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
 // ProgressState represents different stages of installation
@@ -342,8 +344,8 @@ type ProgressInstaller struct {
 }
 
 // NewProgressInstaller creates a new installer with progress tracking
-func NewProgressInstaller(registryURL, packageName string) *ProgressInstaller {
-	installer := NewInstaller(registryURL)
+func NewProgressInstaller(registryURLs []string, packageName string) *ProgressInstaller {
+	installer := NewInstaller(registryURLs...)
 	model := NewProgressModel(packageName)
 
 	// Create program with proper TTY input handling
@@ -520,7 +522,7 @@ func (pi *ProgressInstaller) installWithProgressTracking(packageName string, opt
 
 // createDestinationDir creates the destination directory
 func (pi *ProgressInstaller) createDestinationDir(destPath string) error {
-	return os.MkdirAll(destPath, 0755)
+	return vfs.MkdirAll(destPath, 0755)
 }
 
 // canUseTUI checks if we can use the TUI (TTY is available)
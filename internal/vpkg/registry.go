@@ -16,29 +16,58 @@ const (
 	DefaultRegistryURL = "https://raw.githubusercontent.com/alfariiizi/vpkg-registry/main/registry.yaml"
 )
 
-// RegistryClient handles fetching data from the package registry
+// RegistryClient handles fetching data from the package registries
 type RegistryClient struct {
-	registryURL string
-	httpClient  *http.Client
+	registryURLs []string
+	httpClient   *http.Client
 }
 
-// NewRegistryClient creates a new registry client
-func NewRegistryClient(registryURL string) *RegistryClient {
-	if registryURL == "" {
-		registryURL = DefaultRegistryURL
+// NewRegistryClient creates a new registry client for the given registries,
+// the default one when none is given
+func NewRegistryClient(registryURLs ...string) *RegistryClient {
+	var urls []string
+	for _, url := range registryURLs {
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	if len(urls) == 0 {
+		urls = []string{DefaultRegistryURL}
 	}
 
 	return &RegistryClient{
-		registryURL: registryURL,
+		registryURLs: urls,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
 }
 
-// FetchRegistry fetches and parses the registry index (new repository-based format)
+// FetchRegistry fetches the registry indexes and merges their repositories
+// and tags, in the order the registries are given
 func (r *RegistryClient) FetchRegistry() (*Registry, error) {
-	resp, err := r.httpClient.Get(r.registryURL)
+	var merged *Registry
+	for _, url := range r.registryURLs {
+		registry, err := r.fetchRegistry(url)
+		if err != nil {
+			if len(r.registryURLs) > 1 {
+				return nil, fmt.Errorf("%s: %w", url, err)
+			}
+			return nil, err
+		}
+		if merged == nil {
+			merged = registry
+			continue
+		}
+		merged.Repositories = append(merged.Repositories, registry.Repositories...)
+		merged.Tags = append(merged.Tags, registry.Tags...)
+	}
+	return merged, nil
+}
+
+// fetchRegistry fetches and parses a registry index (new repository-based format)
+func (r *RegistryClient) fetchRegistry(url string) (*Registry, error) {
+	resp, err := r.httpClient.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch registry: %w", err)
 	}
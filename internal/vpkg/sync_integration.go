@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/alfariiizi/vandor-cli/internal/vfs"
 )
 
 // SyncCapability represents a vpkg package's sync capability
@@ -106,6 +108,11 @@ func (m *VpkgSyncManager) ExecuteSyncCapabilities() error {
 		return nil // No packages with sync capabilities
 	}
 
+	if vfs.IsVirtual() {
+		fmt.Fprintf(os.Stderr, "⚠️  Preview: not running vpkg sync functions (external programs)\n")
+		return nil
+	}
+
 	fmt.Printf("🔄 Syncing vpkg packages with sync capabilities...\n")
 
 	for _, capability := range capabilities {